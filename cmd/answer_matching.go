@@ -0,0 +1,251 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Answer matching for memoryquiz. Answers are compared after folding away the differences that
+// don't matter: case, accents, punctuation, extra spaces, and thousands separators in numbers.
+// So "george washington" is George Washington, "Bogota" is Bogotá, and "1,024" is 1024.
+// Questions can also list aliases, other answers that count as right (Alfred the Great for Alfred).
+//
+// Answers that are wrong but near the right answer, a misspelling or just the last name, are
+// reported as close rather than lumped in with the outright misses. With --typos, misspellings
+// of up to that many letters count as correct, unless the answer has digits in it.
+
+var typoTolerance int
+
+type answerMatch int
+
+const (
+	answerIncorrect answerMatch = iota
+	// answerClose is a wrong answer that was near the right one
+	answerClose
+	// answerTypo is a misspelling within the typo tolerance, which counts as correct
+	answerTypo
+	answerCorrect
+)
+
+func (match answerMatch) correct() bool {
+	return match == answerCorrect || match == answerTypo
+}
+
+// letters that don't decompose into a base letter and an accent, so folding has to spell them out
+var letterFolder = strings.NewReplacer("æ", "ae", "œ", "oe", "ø", "o", "ß", "ss", "ł", "l", "đ", "d", "þ", "th")
+
+var numericAnswer = regexp.MustCompile(`^[-+]?\$?[0-9][0-9, _]*(\.[0-9]+)?$`)
+
+// normalizeAnswer folds an answer down to what matters for comparing it
+func normalizeAnswer(answer string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), answer)
+	if err != nil {
+		folded = answer
+	}
+	folded = letterFolder.Replace(strings.ToLower(strings.TrimSpace(folded)))
+
+	if numericAnswer.MatchString(folded) {
+		return strings.NewReplacer(",", "", "_", "", " ", "", "$", "", "+", "").Replace(folded)
+	}
+
+	// punctuation separates words, so "us-east-1" and "us east 1" match and "Chester A. Arthur"
+	// matches "chester a arthur"
+	words := strings.FieldsFunc(folded, func(r rune) bool {
+		return unicode.IsSpace(r) || (unicode.IsPunct(r) && r != '\'') || unicode.IsSymbol(r)
+	})
+	return strings.ReplaceAll(strings.Join(words, " "), "'", "")
+}
+
+// matchAnswer compares what the user typed with the question's response and aliases
+func matchAnswer(userResponse string, prompt promptAndResponse) answerMatch {
+	if len(prompt.choices) > 0 {
+		return matchChoice(userResponse, prompt)
+	}
+	if len(prompt.allOf) > 0 {
+		return matchAllAnswers(userResponse, prompt.allOf)
+	}
+	if len(prompt.sequence) > 0 {
+		return matchSequence(userResponse, prompt.sequence)
+	}
+
+	answer := normalizeAnswer(userResponse)
+	if answer == "" {
+		return answerIncorrect
+	}
+
+	accepted := append([]string{prompt.response}, prompt.aliases...)
+	best := answerIncorrect
+	for _, acceptable := range accepted {
+		match := matchNormalizedAnswer(answer, normalizeAnswer(acceptable))
+		if match > best {
+			best = match
+		}
+	}
+	return best
+}
+
+// matchAllAnswers matches a comma separated list of answers, in any order, against expected.
+// Getting some of them, or all of them plus some wrong ones, is close.
+func matchAllAnswers(userResponse string, expected []string) answerMatch {
+	found, missed, wrong, typos := diffAllAnswers(userResponse, expected)
+	switch {
+	case len(missed) == 0 && len(wrong) == 0 && typos:
+		return answerTypo
+	case len(missed) == 0 && len(wrong) == 0:
+		return answerCorrect
+	case found > 0:
+		return answerClose
+	}
+	return answerIncorrect
+}
+
+// diffAllAnswers splits a comma separated list of answers and works out how many of expected
+// were found, which were missed, and which answers weren't expected
+func diffAllAnswers(userResponse string, expected []string) (int, []string, []string, bool) {
+	answers := make([]string, 0)
+	for _, answer := range strings.Split(userResponse, ",") {
+		if strings.TrimSpace(answer) != "" {
+			answers = append(answers, strings.TrimSpace(answer))
+		}
+	}
+
+	used := make([]bool, len(answers))
+	found := 0
+	missed := make([]string, 0)
+	typos := false
+	for _, expectedAnswer := range expected {
+		normalizedExpected := normalizeAnswer(expectedAnswer)
+		best, bestIndex := answerIncorrect, -1
+		for index, answer := range answers {
+			if match := matchNormalizedAnswer(normalizeAnswer(answer), normalizedExpected); !used[index] && match.correct() && match > best {
+				best, bestIndex = match, index
+			}
+		}
+		if bestIndex >= 0 {
+			used[bestIndex] = true
+			found++
+			typos = typos || best == answerTypo
+		} else {
+			missed = append(missed, expectedAnswer)
+		}
+	}
+
+	wrong := make([]string, 0)
+	for index, answer := range answers {
+		if !used[index] {
+			wrong = append(wrong, answer)
+		}
+	}
+	return found, missed, wrong, typos
+}
+
+// printAllAnswersDiff shows how many of a list of answers were found and which were missed or wrong
+func printAllAnswersDiff(userResponse string, expected []string) {
+	found, missed, wrong, _ := diffAllAnswers(userResponse, expected)
+	quizPrintf("You named %d of %d\n", found, len(expected))
+	if len(missed) > 0 {
+		quizPrintf("Missed: %s\n", strings.Join(missed, ", "))
+	}
+	if len(wrong) > 0 {
+		quizPrintf("Not on the list: %s\n", strings.Join(wrong, ", "))
+	}
+}
+
+func matchNormalizedAnswer(answer string, expected string) answerMatch {
+	if answer == expected {
+		return answerCorrect
+	}
+	// a wrong number is just wrong; 1024 isn't a typo of 1025
+	if numericAnswer.MatchString(answer) || numericAnswer.MatchString(expected) {
+		return answerIncorrect
+	}
+
+	// nor are digits anywhere else, as in codes like 12R3, so only letters get typo tolerance
+	distance := editDistance(answer, expected)
+	if distance <= typoTolerance && !containsDigit(answer) && !containsDigit(expected) {
+		return answerTypo
+	}
+	if distance <= closeAnswerDistance(expected) || isPartOfAnswer(answer, expected) {
+		return answerClose
+	}
+	return answerIncorrect
+}
+
+func containsDigit(answer string) bool {
+	return strings.IndexFunc(answer, unicode.IsDigit) >= 0
+}
+
+// closeAnswerDistance is how many edits an answer can be from the right one and still be
+// reported as close: about one letter in four
+func closeAnswerDistance(expected string) int {
+	distance := len([]rune(expected)) / 4
+	if distance < 1 {
+		return 1
+	}
+	return distance
+}
+
+// isPartOfAnswer reports whether every word of answer is a word of expected, such as a last name
+func isPartOfAnswer(answer string, expected string) bool {
+	expectedWords := strings.Fields(expected)
+	for _, word := range strings.Fields(answer) {
+		if !isStringInSlice(word, expectedWords) {
+			return false
+		}
+	}
+	return true
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a string, b string) int {
+	aRunes := []rune(a)
+	bRunes := []rune(b)
+	previous := make([]int, len(bRunes)+1)
+	current := make([]int, len(bRunes)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(aRunes); i++ {
+		current[0] = i
+		for j := 1; j <= len(bRunes); j++ {
+			cost := 1
+			if aRunes[i-1] == bRunes[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(bRunes)]
+}
+
+func init() {
+	memoryquizCmd.PersistentFlags().IntVar(&typoTolerance, "typos", 0, "Count answers misspelled by up to this many letters as correct (answers with digits must be exact)")
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import "testing"
+
+func TestNormalizeAnswer(t *testing.T) {
+	cases := map[string]string{
+		"George Washington":       "george washington",
+		"  Kinshasa ":             "kinshasa",
+		"KINSHASA":                "kinshasa",
+		"Bogotá":                  "bogota",
+		"Æthelred":                "aethelred",
+		"Chester A. Arthur":       "chester a arthur",
+		"us-east-1":               "us east 1",
+		"Who's  on first":         "whos on first",
+		"1,024":                   "1024",
+		"1 024":                   "1024",
+		"$400":                    "400",
+		"3.14159":                 "3.14159",
+		"0314":                    "0314",
+		"Elizabeth 'Bess' Truman": "elizabeth bess truman",
+	}
+	for answer, expected := range cases {
+		if normalized := normalizeAnswer(answer); normalized != expected {
+			t.Errorf("Expected %q to normalize to %q but got %q", answer, expected, normalized)
+		}
+	}
+}
+
+func TestMatchAnswer(t *testing.T) {
+	alfred := promptAndResponse{prompt: "Who ruled England after Aethelred I?", response: "Alfred", aliases: []string{"Alfred the Great"}}
+	washington := promptAndResponse{prompt: "Who was President before John Adams?", response: "George Washington"}
+	power := promptAndResponse{prompt: "What is 2^10?", response: "1024"}
+
+	cases := []struct {
+		answer   string
+		prompt   promptAndResponse
+		expected answerMatch
+	}{
+		{"george washington", washington, answerCorrect},
+		{"Washington", washington, answerClose},
+		{"George Washingtin", washington, answerClose},
+		{"John Adams", washington, answerIncorrect},
+		{"alfred the great", alfred, answerCorrect},
+		{"Alfred", alfred, answerCorrect},
+		{"1,024", power, answerCorrect},
+		{"1025", power, answerIncorrect},
+		{"", washington, answerIncorrect},
+	}
+	for _, c := range cases {
+		if match := matchAnswer(c.answer, c.prompt); match != c.expected {
+			t.Errorf("Expected %q for %q to match as %v but got %v", c.answer, c.prompt.response, c.expected, match)
+		}
+	}
+}
+
+func TestTypoTolerance(t *testing.T) {
+	defer func(tolerance int) { typoTolerance = tolerance }(typoTolerance)
+	washington := promptAndResponse{prompt: "Who was President before John Adams?", response: "George Washington"}
+
+	typoTolerance = 1
+	if match := matchAnswer("George Washingtin", washington); match != answerTypo || !match.correct() {
+		t.Errorf("Expected a one letter typo to count as correct but got %v", match)
+	}
+	if match := matchAnswer("Gorge Washingtin", washington); match != answerClose || match.correct() {
+		t.Errorf("Expected a two letter typo to be close but got %v", match)
+	}
+
+	code := promptAndResponse{prompt: "What's the code?", response: "12R3"}
+	if match := matchAnswer("12R4", code); match.correct() {
+		t.Errorf("Expected a wrong digit in a code to be wrong but got %v", match)
+	}
+	if match := matchAnswer("Apolo 11", promptAndResponse{prompt: "Which mission landed first?", response: "Apollo 11"}); match.correct() {
+		t.Errorf("Expected typos not to count in an answer with digits but got %v", match)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		distance int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"bogotá", "bogota", 1},
+	}
+	for _, c := range cases {
+		if distance := editDistance(c.a, c.b); distance != c.distance {
+			t.Errorf("Expected distance from %s to %s to be %d but got %d", c.a, c.b, c.distance, distance)
+		}
+	}
+}
+
+func TestCrossQueryAliases(t *testing.T) {
+	royal := englishRoyal{6, "Alfred", "the Great"}
+	for i := 0; i < 20; i++ {
+		question := constructCrossQuery("English royal", royal)
+		if question.response == "Alfred" && (len(question.aliases) != 1 || question.aliases[0] != "Alfred the Great") {
+			t.Errorf("Expected Alfred the Great as an alias but got %v", question.aliases)
+		}
+	}
+
+	cleveland := president{22, "Grover Cleveland (22)", 1885, nil, nil}
+	if aliases := cleveland.answerAliases("name"); len(aliases) != 1 || aliases[0] != "Grover Cleveland" {
+		t.Errorf("Expected Grover Cleveland as an alias but got %v", aliases)
+	}
+}
@@ -15,17 +15,14 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"math/rand"
-
-	"github.com/spf13/cobra"
-)
-
-var arrondisementsCmd = &cobra.Command{
-	Use:   "arrondisements",
-	Short: "Quiz command of Parisian arrondisements",
-	Run:   quizArrondisements,
-}
+var arrondisementsCmd = registerQuizArea(quizArea{
+	name:       "arrondisements",
+	tags:       []string{"geography"},
+	short:      "Quiz command of Parisian arrondisements",
+	generators: arrondisementQuestions,
+	dataset:    &arrondisements,
+	ordinal:    "arrondisement",
+})
 
 var arrondisements = []string{
 	"Louvre",
@@ -52,14 +49,13 @@ var arrondisements = []string{
 
 type quizArrondisementFunc func([]string) promptAndResponse
 
-func quizArrondisements(cmd *cobra.Command, args []string) {
+func arrondisementQuestions() []questionGenerator {
 	funcs := []quizArrondisementFunc{
 		quizArrondisementFromIndex,
 		quizIndexOfArrondisement,
 	}
 
-	function := funcs[rand.Intn(len(funcs))]
-	promptAndCheckResponse(function(arrondisements))
+	return generatorsFor(funcs, arrondisements)
 }
 
 func quizArrondisementFromIndex(arrondisements []string) promptAndResponse {
@@ -69,7 +65,3 @@ func quizArrondisementFromIndex(arrondisements []string) promptAndResponse {
 func quizIndexOfArrondisement(arrondisements []string) promptAndResponse {
 	return quizIndexOfStringInList(arrondisements)
 }
-
-func init() {
-	memoryquizCmd.AddCommand(arrondisementsCmd)
-}
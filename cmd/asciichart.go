@@ -124,30 +124,47 @@ func generateBarChart(command *cobra.Command, args []string) {
 			}
 		}
 
-		longestLabel := data.longestLabel()
-		largestValue := data.largestValue()
-		// the area you have to draw a bar (and the maximum bar width you'll have)
-		// is the total width of the screen
-		// minus the length of the longest label,
-		// minus the length of the largest value (printed at the end of the bar),
-		// minus the length of " | " which is between the label and the chart
-		// minus the length of " " printed between the bar and the number
-		barAreaWidth := screenWidth - len(longestLabel) - len(strconv.Itoa(largestValue)) - len(" | ") - len(" ")
-
 		for _, label := range labelOrder {
-			point, exists := data[label]
-			if !exists {
+			if _, exists := data[label]; !exists {
 				// very unlikely, but just in case
 				fmt.Printf("Unexpected error: %s should have been in data set but was not\n", label)
 				os.Exit(1)
 			}
+		}
 
-			barWidth := scaledBarWidth(point.quantity, largestValue, barAreaWidth)
-			fmt.Printf("\u001b[2K%*s | %s %d\n", len(longestLabel), point.label, strings.Repeat("=", barWidth), point.quantity)
+		for _, row := range barChartRows(labelOrder, data, screenWidth) {
+			fmt.Printf("\u001b[2K%s\n", row)
 		}
 	}, nil)
 }
 
+// barChartRows draws a bar for each label, in labelOrder, scaled to fit in width, e.g.
+//
+//	snake | ======== 4
+//	  bat | ====== 3
+func barChartRows(labelOrder []string, data dataSet, width int) []string {
+	longestLabel := data.longestLabel()
+	largestValue := data.largestValue()
+	// the area you have to draw a bar (and the maximum bar width you'll have)
+	// is the total width of the screen
+	// minus the length of the longest label,
+	// minus the length of the largest value (printed at the end of the bar),
+	// minus the length of " | " which is between the label and the chart
+	// minus the length of " " printed between the bar and the number
+	barAreaWidth := width - len(longestLabel) - len(strconv.Itoa(largestValue)) - len(" | ") - len(" ")
+
+	rows := make([]string, 0, len(labelOrder))
+	for _, label := range labelOrder {
+		point := data[label]
+		barWidth := 0
+		if largestValue > 0 {
+			barWidth = scaledBarWidth(point.quantity, largestValue, barAreaWidth)
+		}
+		rows = append(rows, fmt.Sprintf("%*s | %s %d", len(longestLabel), point.label, strings.Repeat("=", barWidth), point.quantity))
+	}
+	return rows
+}
+
 // scaledBarWidth maps dataValue to maxBarWidth by comparing it to maxBarValue and returns the new length of the bar
 // Examples:
 //
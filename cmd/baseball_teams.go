@@ -14,15 +14,15 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var baseballCmd = &cobra.Command{
-	Use:   "baseball-teams",
-	Short: "Quiz baseball teams",
-	Run:   quizBaseballTeams,
-}
+var baseballCmd = registerQuizArea(quizArea{
+	name:       "baseball-teams",
+	aliases:    []string{"baseball"},
+	tags:       []string{"sports"},
+	short:      "Quiz baseball teams",
+	generators: baseballTeamQuestions,
+	dataset:    &baseballTeams,
+	entity:     "baseball team",
+})
 
 type baseballTeam struct {
 	index int    `crossquery:"all"`
@@ -67,21 +67,15 @@ var baseballTeams = []baseballTeam{
 
 type baseballQuestion func([]baseballTeam) promptAndResponse
 
-func quizBaseballTeams(cmd *cobra.Command, args []string) {
+func baseballTeamQuestions() []questionGenerator {
 
 	var promptFuncs = []baseballQuestion{
 		crossQueryBaseballTeamInfo,
 	}
 
-	function := randomItemFromSlice(promptFuncs)
-	promptAndCheckResponse(function(baseballTeams))
+	return generatorsFor(promptFuncs, baseballTeams)
 }
 
 func crossQueryBaseballTeamInfo(teams []baseballTeam) promptAndResponse {
-	foundTeam := randomItemFromSlice(teams)
-	return constructCrossQuery("baseball team", foundTeam)
-}
-
-func init() {
-	memoryquizCmd.AddCommand(baseballCmd)
+	return constructCrossQueryFromSlice("baseball team", teams)
 }
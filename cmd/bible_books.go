@@ -17,17 +17,18 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
-
-	"github.com/spf13/cobra"
 )
 
 // greekCmd represents the greek command
-var bibleCmd = &cobra.Command{
-	Use:   "bible",
-	Short: "Test memory of the books of the King James Bible",
-	Run:   quizKingJames,
-}
+var bibleCmd = registerQuizArea(quizArea{
+	name:       "bible",
+	aliases:    []string{"bible-books"},
+	tags:       []string{"religion", "literature"},
+	short:      "Test memory of the books of the King James Bible",
+	generators: bibleBookQuestions,
+	dataset:    &bibleBooks,
+	ordinal:    "book of the Bible",
+})
 
 var bibleBooks = []string{
 	"Genesis",
@@ -100,16 +101,20 @@ var bibleBooks = []string{
 
 type quizBibleFunc func([]string) promptAndResponse
 
-func quizKingJames(cmd *cobra.Command, args []string) {
+func bibleBookQuestions() []questionGenerator {
 	funcs := []quizGreekFunc{
 		quizBibleBookFromPosition,
 		quizPositionFromBibleBook,
 		quizBibleBookBefore,
 		quizBibleBookAfter,
+		quizReciteBibleBooks,
 	}
 
-	function := randomItemFromSlice(funcs)
-	promptAndCheckResponse(function(bibleBooks))
+	return generatorsFor(funcs, bibleBooks)
+}
+
+func quizReciteBibleBooks(books []string) promptAndResponse {
+	return quizReciteList("books of the Bible", books)
 }
 
 func quizPositionFromBibleBook(books []string) promptAndResponse {
@@ -123,16 +128,12 @@ func quizBibleBookFromPosition(books []string) promptAndResponse {
 func quizBibleBookBefore(books []string) promptAndResponse {
 	index := 0
 	for index == 0 {
-		index = rand.Intn(len(books))
+		index = quizRand.Intn(len(books))
 	}
-	return promptAndResponse{fmt.Sprintf("What book comes before %s?", books[index]), books[index-1]}
+	return promptAndResponse{prompt: fmt.Sprintf("What book comes before %s?", books[index]), response: books[index-1]}
 }
 
 func quizBibleBookAfter(books []string) promptAndResponse {
-	index := rand.Intn(len(books) - 1) // -1 to ensure we don't get the last item
-	return promptAndResponse{fmt.Sprintf("What book comes after %s?", books[index]), books[index+1]}
-}
-
-func init() {
-	memoryquizCmd.AddCommand(bibleCmd)
+	index := quizRand.Intn(len(books) - 1) // -1 to ensure we don't get the last item
+	return promptAndResponse{prompt: fmt.Sprintf("What book comes after %s?", books[index]), response: books[index+1]}
 }
@@ -0,0 +1,269 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Border questions for any dataset that lists each entity's neighbors, like the countries
+// around Germany. borders:"name" marks the field naming the entity and borders:"neighbors" the
+// list of the names of the ones it borders:
+//
+//	type countryInfo struct {
+//	  name      string   `borders:"name"`
+//	  neighbors []string `borders:"neighbors"`
+//	}
+//
+// The questions are answered from a graph of who borders whom, so the fewest borders between
+// Spain and Poland is worked out, not written down. Borders go both ways, so each neighbor has
+// to list the other (see problems, which memoryquiz validate reports).
+
+const (
+	// borderPathMinimum and borderPathMaximum bound how many places are in between on the
+	// paths asked about, so they're neither trivial nor a trek across Asia
+	borderPathMinimum = 1
+	borderPathMaximum = 4
+	// borderAttempts is how many times to look for places that make a good question
+	borderAttempts = 50
+)
+
+// borderGraph maps each entity's name to its neighbors' names
+type borderGraph map[string][]string
+
+// bordersOf builds the graph from a dataset with borders tags
+func bordersOf[S ~[]E, E any](entities S) borderGraph {
+	entityType := reflect.TypeOf(entities).Elem()
+	nameIndex, neighborsIndex := -1, -1
+	for i := 0; i < entityType.NumField(); i++ {
+		switch borders := entityType.Field(i).Tag.Get("borders"); borders {
+		case "":
+			continue
+		case "name":
+			nameIndex = i
+		case "neighbors":
+			neighborsIndex = i
+		default:
+			panic(fmt.Sprintf("Invalid value for borders: %s", borders))
+		}
+	}
+	if nameIndex < 0 || neighborsIndex < 0 {
+		// this is effectively a syntax error, so kill the program
+		panic(fmt.Sprintf("%s needs borders:\"name\" and borders:\"neighbors\" fields", entityType.Name()))
+	}
+
+	graph := make(borderGraph)
+	for _, entity := range entities {
+		value := reflect.ValueOf(entity)
+		graph[value.Field(nameIndex).String()] = reflectValueToStrings(value.Field(neighborsIndex))
+	}
+	return graph
+}
+
+// names returns the names in the graph, in order, so random picks can be repeated with a seed.
+// With bordered set, it leaves out the islands.
+func (graph borderGraph) names(bordered bool) []string {
+	names := make([]string, 0, len(graph))
+	for name, neighbors := range graph {
+		if !bordered || len(neighbors) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (graph borderGraph) borders(name string, other string) bool {
+	return isStringInSlice(other, graph[name])
+}
+
+// search goes out from one place a border at a time, finding how many borders away every place
+// that can be reached by land is, how many ways there are to get there crossing that few, and
+// the place just before it on one of those ways
+func (graph borderGraph) search(from string) (map[string]int, map[string]int, map[string]string) {
+	steps := map[string]int{from: 0}
+	ways := map[string]int{from: 1}
+	previous := make(map[string]string)
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, neighbor := range graph[current] {
+			if _, seen := steps[neighbor]; !seen {
+				steps[neighbor] = steps[current] + 1
+				previous[neighbor] = current
+				queue = append(queue, neighbor)
+			}
+			if steps[neighbor] == steps[current]+1 {
+				ways[neighbor] += ways[current]
+			}
+		}
+	}
+	return steps, ways, previous
+}
+
+// shortestPath finds a path from one place to another crossing the fewest borders, including
+// both ends, and how many such paths there are. There's no path (and a count of 0) if you can't
+// get there by land.
+func (graph borderGraph) shortestPath(from string, to string) ([]string, int) {
+	steps, ways, previous := graph.search(from)
+	if _, reached := steps[to]; !reached {
+		return nil, 0
+	}
+	path := []string{to}
+	for path[0] != from {
+		path = append([]string{previous[path[0]]}, path...)
+	}
+	return path, ways[to]
+}
+
+// problems finds borders that only go one way and neighbors that aren't in the dataset
+func (graph borderGraph) problems() []datasetProblem {
+	problems := make([]datasetProblem, 0)
+	for _, name := range graph.names(true) {
+		for _, neighbor := range graph[name] {
+			switch _, known := graph[neighbor]; {
+			case neighbor == name:
+				problems = append(problems, datasetError("%s borders itself", name))
+			case !known:
+				problems = append(problems, datasetError("%s borders %s, which isn't in the dataset", name, neighbor))
+			case !graph.borders(neighbor, name):
+				problems = append(problems, datasetError("%s borders %s, but %s doesn't border %s", name, neighbor, neighbor, name))
+			}
+		}
+	}
+	return problems
+}
+
+// quizNeighbors asks for everything bordering a place, as in "Name every country that borders
+// Germany"
+func quizNeighbors(entityType string, graph borderGraph) promptAndResponse {
+	if len(graph.names(true)) == 0 {
+		return quizShareBorder(graph)
+	}
+	name := randomItemFromSlice(graph.names(true))
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("Name every %s that borders %s (separate them with commas)", entityType, name),
+		response: strings.Join(graph[name], ", "),
+		allOf:    graph[name],
+	}
+}
+
+// quizShareBorder asks whether two places share a border. The ones that don't are two borders
+// apart, so they're close enough to be worth asking about. If nothing borders anything, which
+// can happen with a dataset file, it asks about any two places.
+func quizShareBorder(graph borderGraph) promptAndResponse {
+	if len(graph.names(true)) == 0 {
+		names := graph.names(false)
+		picked := quizRand.Perm(len(names))
+		name, other := names[picked[0]], names[picked[len(picked)-1]]
+		return promptAndResponse{
+			prompt:   fmt.Sprintf("%s and %s share a border, true or false?", name, other),
+			response: strconv.FormatBool(false),
+		}
+	}
+	name := randomItemFromSlice(graph.names(true))
+	other := randomItemFromSlice(graph[name])
+	if quizRand.Intn(2) == 0 {
+		for i := 0; i < borderAttempts; i++ {
+			candidate := randomItemFromSlice(graph[other])
+			if candidate != name && !graph.borders(name, candidate) {
+				other = candidate
+				break
+			}
+		}
+	}
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("%s and %s share a border, true or false?", name, other),
+		response: strconv.FormatBool(graph.borders(name, other)),
+	}
+}
+
+// quizBordersBoth asks what borders two places, as in "Which country borders both Spain and
+// Belgium?" Any place that does is right.
+func quizBordersBoth(entityType string, graph borderGraph) promptAndResponse {
+	candidates := make([]string, 0)
+	for _, name := range graph.names(true) {
+		if len(graph[name]) > 1 {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		// nothing borders two places
+		return quizShareBorder(graph)
+	}
+	answer := randomItemFromSlice(candidates)
+	picked := quizRand.Perm(len(graph[answer]))
+	first, second := graph[answer][picked[0]], graph[answer][picked[1]]
+
+	aliases := make([]string, 0)
+	for _, name := range graph[first] {
+		if name != answer && name != second && graph.borders(second, name) {
+			aliases = append(aliases, name)
+		}
+	}
+	return promptAndResponse{
+		prompt:      fmt.Sprintf("Which %s borders both %s and %s?", entityType, first, second),
+		response:    answer,
+		aliases:     aliases,
+		distractors: append(append([]string{}, graph[first]...), graph[second]...),
+	}
+}
+
+// quizBorderPath asks for the way from one place to another crossing the fewest borders, as in
+// Spain to Poland by way of France and Germany. If there's more than one way, it asks how many
+// borders you have to cross instead. plural names the places, as in "countries".
+func quizBorderPath(plural string, graph borderGraph) promptAndResponse {
+	names := graph.names(true)
+	var from string
+	destinations := make([]string, 0)
+	for i := 0; i < borderAttempts && len(destinations) == 0 && len(names) > 0; i++ {
+		from = randomItemFromSlice(names)
+		steps, _, _ := graph.search(from)
+		for _, name := range names {
+			if inBetween := steps[name] - 1; inBetween >= borderPathMinimum && inBetween <= borderPathMaximum {
+				destinations = append(destinations, name)
+			}
+		}
+	}
+	if len(destinations) == 0 {
+		// everything is too close together (or too far apart) for a path worth asking about
+		return quizShareBorder(graph)
+	}
+
+	to := randomItemFromSlice(destinations)
+	path, ways := graph.shortestPath(from, to)
+	if ways > 1 {
+		return promptAndResponse{
+			prompt:   fmt.Sprintf("What's the fewest borders you cross going from %s to %s, without leaving the %s?", from, to, plural),
+			response: strconv.Itoa(len(path) - 1),
+		}
+	}
+
+	sequence := make([][]string, 0, len(path)-2)
+	for _, name := range path[1 : len(path)-1] {
+		sequence = append(sequence, []string{name})
+	}
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("Crossing the fewest borders from %s to %s, which %s do you go through, in order? (separate them with commas)", from, to, plural),
+		response: strings.Join(path[1:len(path)-1], ", "),
+		sequence: sequence,
+	}
+}
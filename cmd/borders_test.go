@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// bordersTestGraph is a square, A-B-D and A-C-D, with E hanging off D and an island F
+var bordersTestGraph = borderGraph{
+	"A": {"B", "C"},
+	"B": {"A", "D"},
+	"C": {"A", "D"},
+	"D": {"B", "C", "E"},
+	"E": {"D"},
+	"F": nil,
+}
+
+func TestBorderAdjacencyIsSymmetric(t *testing.T) {
+	graphs := map[string]borderGraph{
+		"countries": bordersOf(countries),
+		"states":    bordersOf(states),
+		"canada":    bordersOf(canadianRegions),
+	}
+	for name, graph := range graphs {
+		for _, problem := range graph.problems() {
+			t.Errorf("%s: %s", name, problem.message)
+		}
+	}
+}
+
+func TestBorderProblems(t *testing.T) {
+	graph := borderGraph{"A": {"B", "C"}, "B": nil, "D": {"D"}}
+	errors, _ := problemMessages(graph.problems())
+	expected := []string{"A borders B, but B doesn't border A", "A borders C, which isn't in the dataset", "D borders itself"}
+	if !reflect.DeepEqual(errors, expected) {
+		t.Errorf("Expected %v but got %v", expected, errors)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	if path, ways := bordersTestGraph.shortestPath("A", "E"); len(path) != 4 || path[0] != "A" || path[3] != "E" || ways != 2 {
+		t.Errorf("Expected two ways from A to E crossing three borders but got %v, %d", path, ways)
+	}
+	if path, ways := bordersTestGraph.shortestPath("B", "E"); !reflect.DeepEqual(path, []string{"B", "D", "E"}) || ways != 1 {
+		t.Errorf("Expected one way from B to E but got %v, %d", path, ways)
+	}
+	if path, ways := bordersTestGraph.shortestPath("A", "F"); path != nil || ways != 0 {
+		t.Errorf("Expected no way to the island but got %v, %d", path, ways)
+	}
+
+	path, ways := bordersOf(countries).shortestPath("Spain", "Poland")
+	if !reflect.DeepEqual(path, []string{"Spain", "France", "Germany", "Poland"}) || ways != 1 {
+		t.Errorf("Expected Spain to Poland by way of France and Germany but got %v, %d", path, ways)
+	}
+}
+
+func TestQuizBorderPath(t *testing.T) {
+	seedQuizRand(1)
+	for i := 0; i < 30; i++ {
+		question := quizBorderPath("places", bordersTestGraph)
+		switch {
+		case strings.HasPrefix(question.prompt, "Crossing the fewest borders from B to E,"):
+			if question.response != "D" || matchAnswer("D", question) != answerCorrect {
+				t.Errorf("Expected D between B and E but got %q", question.response)
+			}
+		case question.prompt == "What's the fewest borders you cross going from A to E, without leaving the places?":
+			if question.response != "3" {
+				t.Errorf("Expected 3 borders from A to E but got %q", question.response)
+			}
+		case strings.Contains(question.prompt, "F"):
+			t.Errorf("Expected the island to be left out: %s", question.prompt)
+		}
+	}
+}
+
+func TestQuizBordersBoth(t *testing.T) {
+	seedQuizRand(2)
+	for i := 0; i < 30; i++ {
+		question := quizBordersBoth("place", bordersTestGraph)
+		if question.prompt == "Which place borders both B and C?" {
+			if answers := append([]string{question.response}, question.aliases...); len(answers) != 2 || !isStringInSlice("A", answers) || !isStringInSlice("D", answers) {
+				t.Errorf("Expected A and D to border both B and C but got %v", answers)
+			}
+		}
+	}
+}
+
+func TestQuizNeighbors(t *testing.T) {
+	seedQuizRand(3)
+	question := quizNeighbors("country", bordersOf(countries[62:63]))
+	if question.prompt != "Name every country that borders Germany (separate them with commas)" || len(question.allOf) != 9 {
+		t.Errorf("Unexpected question %q -> %v", question.prompt, question.allOf)
+	}
+}
+
+func TestBorderQuestionsWithoutBorders(t *testing.T) {
+	islands := borderGraph{"F": nil, "G": nil}
+	for _, question := range []promptAndResponse{
+		quizNeighbors("island", islands),
+		quizShareBorder(islands),
+		quizBordersBoth("island", islands),
+		quizBorderPath("islands", islands),
+	} {
+		if question.response != "false" {
+			t.Errorf("Expected islands to share no border but got %q -> %q", question.prompt, question.response)
+		}
+	}
+
+	unbordered := []countryInfo{{name: "Iceland"}, {name: "Japan"}}
+	if question := quizCountryBordersCountry(unbordered); question.response != "false" {
+		t.Errorf("Expected a share border question for countries without neighbors but got %q", question.prompt)
+	}
+}
@@ -15,91 +15,91 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"fmt"
-
-	"github.com/spf13/cobra"
-)
-
 // countriesCmd represents the countries command
-var caCountiesCmd = &cobra.Command{
-	Use:   "ca-counties",
-	Short: "Memory quizzes about California, including county seats",
-	Run:   quizCaCounties,
-}
+var caCountiesCmd = registerQuizArea(quizArea{
+	name:       "ca-counties",
+	tags:       []string{"geography"},
+	short:      "Memory quizzes about California, including county seats",
+	generators: caCountyQuestions,
+	dataset:    &caCounties,
+	entity:     "CA county",
+})
 
 // note that this struct and most of the methods below can be reused if I ever add
 // other counties
 type countyInfo struct {
-	sizeRank   int    `crossquery:"all" crossqueryname:"size rank"`
-	name       string `crossquery:"all"`
-	countySeat string `crossquery:"all" crossqueryname:"county seat"`
+	sizeRank   int    `crossquery:"all" crossqueryname:"size rank" rank:"desc" rankwords:"is bigger,is smaller,is the largest,is the smallest"`
+	name       string `crossquery:"all" rank:"name"`
+	countySeat string `crossquery:"all" crossqueryname:"county seat" geo:"name"`
+	// latitude and longitude are the county seat's
+	latitude  float64 `geo:"latitude"`
+	longitude float64 `geo:"longitude"`
 }
 
 var caCounties = []countyInfo{
-	{1, "San Bernadino", "San Bernadino"},
-	{2, "Inyo", "Independence"},
-	{3, "Kern", "Bakersfield"},
-	{4, "Riverside", "Riverside"},
-	{5, "Siskiyou", "Yreka"},
-	{6, "Fresno", "Fresno"},
-	{7, "Tulare", "Visalia"},
-	{8, "Lassen", "Susanville"},
-	{9, "San Diego", "San Diego"},
-	{10, "Imperial", "El Centro"},
-	{11, "Los Angeles", "Los Angeles"},
-	{12, "Modoc", "Alturas"},
-	{13, "Shasta", "Redding"},
-	{14, "Humboldt", "Eureka"},
-	{15, "Mendocino", "Ukiah"},
-	{16, "Monterey", "Salinas"},
-	{17, "San Luis Obispo", "San Luis Obispo"},
-	{18, "Trinity", "Weavervill"},
-	{19, "Mono", "Bridgeport"},
-	{20, "Tehama", "Red Bluff"},
-	{21, "Santa Barbara", "Santa Barbara"},
-	{22, "Plumas", "Quincy"},
-	{23, "Tuolumne", "Sonora"},
-	{24, "Madera", "Madera"},
-	{25, "Merced", "Merced"},
-	{26, "Ventura", "Ventura"},
-	{27, "El Dorado", "Placerville"},
-	{28, "Butte", "Oroville"},
-	{29, "Sonoma", "Santa Rosa"},
-	{30, "Stanislaus", "Modesto"},
-	{31, "Mariposa", "Mariposa"},
-	{32, "Placer", "Auburn"},
-	{33, "San Joaquin", "Stockton"},
-	{34, "Kings", "Hanford"},
-	{35, "San Benito", "Hollister"},
-	{36, "Glenn", "Willows"},
-	{37, "Santa Clara", "San Jose"},
-	{38, "Lake", "Lakeport"},
-	{39, "Colusa", "Colusa"},
-	{40, "Calaveras", "San Andreas"},
-	{41, "Yolo", "Woodland"},
-	{42, "Del Norte", "Crescent City"},
-	{43, "Sacramento", "Sacramento"},
-	{44, "Nevada", "Nevada City"},
-	{45, "Sierra", "Downieville"},
-	{46, "Orange", "Santa Ana"},
-	{47, "Solano", "Fairfield"},
-	{48, "Napa", "Napa"},
-	{49, "Alpine", "Markleeville"},
-	{50, "Alameda", "Oakland"},
-	{51, "Contra Costa", "Martinez"},
-	{52, "Yuba", "Marysville"},
-	{53, "Amador", "Jackson"},
-	{54, "Sutter", "Yuba City"},
-	{55, "Marin", "San Rafael"},
-	{56, "San Mateo", "Redwood City"},
-	{57, "Santa Cruz", "Santa Cruz"},
-	{58, "San Francisco", "San Francisco"},
+	{1, "San Bernadino", "San Bernadino", 34.11, -117.29},
+	{2, "Inyo", "Independence", 36.8, -118.2},
+	{3, "Kern", "Bakersfield", 35.37, -119.02},
+	{4, "Riverside", "Riverside", 33.95, -117.4},
+	{5, "Siskiyou", "Yreka", 41.74, -122.63},
+	{6, "Fresno", "Fresno", 36.74, -119.79},
+	{7, "Tulare", "Visalia", 36.33, -119.29},
+	{8, "Lassen", "Susanville", 40.42, -120.65},
+	{9, "San Diego", "San Diego", 32.72, -117.16},
+	{10, "Imperial", "El Centro", 32.79, -115.56},
+	{11, "Los Angeles", "Los Angeles", 34.05, -118.24},
+	{12, "Modoc", "Alturas", 41.49, -120.54},
+	{13, "Shasta", "Redding", 40.59, -122.39},
+	{14, "Humboldt", "Eureka", 40.8, -124.16},
+	{15, "Mendocino", "Ukiah", 39.15, -123.21},
+	{16, "Monterey", "Salinas", 36.68, -121.66},
+	{17, "San Luis Obispo", "San Luis Obispo", 35.28, -120.66},
+	{18, "Trinity", "Weavervill", 40.73, -122.94},
+	{19, "Mono", "Bridgeport", 38.26, -119.23},
+	{20, "Tehama", "Red Bluff", 40.18, -122.24},
+	{21, "Santa Barbara", "Santa Barbara", 34.42, -119.7},
+	{22, "Plumas", "Quincy", 39.94, -120.95},
+	{23, "Tuolumne", "Sonora", 37.98, -120.38},
+	{24, "Madera", "Madera", 36.96, -120.06},
+	{25, "Merced", "Merced", 37.3, -120.48},
+	{26, "Ventura", "Ventura", 34.27, -119.23},
+	{27, "El Dorado", "Placerville", 38.73, -120.8},
+	{28, "Butte", "Oroville", 39.51, -121.56},
+	{29, "Sonoma", "Santa Rosa", 38.44, -122.71},
+	{30, "Stanislaus", "Modesto", 37.64, -120.99},
+	{31, "Mariposa", "Mariposa", 37.48, -119.97},
+	{32, "Placer", "Auburn", 38.9, -121.08},
+	{33, "San Joaquin", "Stockton", 37.96, -121.29},
+	{34, "Kings", "Hanford", 36.33, -119.65},
+	{35, "San Benito", "Hollister", 36.85, -121.4},
+	{36, "Glenn", "Willows", 39.52, -122.19},
+	{37, "Santa Clara", "San Jose", 37.34, -121.89},
+	{38, "Lake", "Lakeport", 39.04, -122.92},
+	{39, "Colusa", "Colusa", 39.21, -122.01},
+	{40, "Calaveras", "San Andreas", 38.2, -120.68},
+	{41, "Yolo", "Woodland", 38.68, -121.77},
+	{42, "Del Norte", "Crescent City", 41.76, -124.2},
+	{43, "Sacramento", "Sacramento", 38.58, -121.49},
+	{44, "Nevada", "Nevada City", 39.26, -121.02},
+	{45, "Sierra", "Downieville", 39.56, -120.83},
+	{46, "Orange", "Santa Ana", 33.75, -117.87},
+	{47, "Solano", "Fairfield", 38.25, -122.04},
+	{48, "Napa", "Napa", 38.3, -122.29},
+	{49, "Alpine", "Markleeville", 38.69, -119.78},
+	{50, "Alameda", "Oakland", 37.8, -122.27},
+	{51, "Contra Costa", "Martinez", 38.02, -122.13},
+	{52, "Yuba", "Marysville", 39.15, -121.59},
+	{53, "Amador", "Jackson", 38.35, -120.77},
+	{54, "Sutter", "Yuba City", 39.14, -121.62},
+	{55, "Marin", "San Rafael", 37.97, -122.53},
+	{56, "San Mateo", "Redwood City", 37.49, -122.24},
+	{57, "Santa Cruz", "Santa Cruz", 36.97, -122.03},
+	{58, "San Francisco", "San Francisco", 37.77, -122.42},
 }
 
 type countyQuery func([]countyInfo) promptAndResponse
 
-func quizCaCounties(cmd *cobra.Command, args []string) {
+func caCountyQuestions() []questionGenerator {
 	quizFuncs := []countyQuery{
 		crossQueryCaCountyInfo,
 		crossQueryCaCountyInfo,
@@ -110,53 +110,40 @@ func quizCaCounties(cmd *cobra.Command, args []string) {
 		crossQueryCaCountyInfo,
 		quizWhichCountyIsBigger,
 		quizWhichCountyIsSmaller,
+		quizRankCounties,
+		quizCountySeatFurther,
+		quizNearestCountySeat,
+		quizCountySeatOnMap,
 	}
-	function := randomItemFromSlice(quizFuncs)
-	promptAndCheckResponse(function(caCounties))
+	return generatorsFor(quizFuncs, caCounties)
 
 }
 
 func crossQueryCaCountyInfo(counties []countyInfo) promptAndResponse {
-	county := randomItemFromSlice(counties)
-	return constructCrossQuery("CA county", county)
+	return constructCrossQueryFromSlice("CA county", counties)
 }
 
 func quizWhichCountyIsBigger(counties []countyInfo) promptAndResponse {
-	county1 := randomItemFromSlice(counties)
-	county2 := randomItemFromSlice(counties)
-
-	for county1.name == county2.name {
-		county2 = randomItemFromSlice(counties)
-	}
-
-	response := promptAndResponse{fmt.Sprintf("Which county is bigger: %s or %s?", county1.name, county2.name), ""}
-	// rank is inverse to size: 1 means the biggest country, and 50 is bigger than 70
-	if county1.sizeRank < county2.sizeRank {
-		response.response = county1.name
-	} else {
-		response.response = county2.name
-	}
-	return response
+	return quizCompareRanked("county", counties, true)
 }
 
 func quizWhichCountyIsSmaller(counties []countyInfo) promptAndResponse {
-	county1 := randomItemFromSlice(counties)
-	county2 := randomItemFromSlice(counties)
+	return quizCompareRanked("county", counties, false)
+}
 
-	for county1.name == county2.name {
-		county2 = randomItemFromSlice(counties)
-	}
+func quizRankCounties(counties []countyInfo) promptAndResponse {
+	return quizRanked("county", counties)
+}
 
-	response := promptAndResponse{fmt.Sprintf("Which county is smaller: %s or %s?", county1.name, county2.name), ""}
-	// rank is inverse to size: 1 means the biggest country, and 50 is bigger than 70
-	if county1.sizeRank > county2.sizeRank {
-		response.response = county1.name
-	} else {
-		response.response = county2.name
-	}
-	return response
+func quizCountySeatFurther(counties []countyInfo) promptAndResponse {
+	return quizFurther("county seat", geoPlaces(counties))
+}
+
+func quizNearestCountySeat(counties []countyInfo) promptAndResponse {
+	seats := geoPlaces(counties)
+	return quizNearest(seats, "county seat", seats)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(caCountiesCmd)
+func quizCountySeatOnMap(counties []countyInfo) promptAndResponse {
+	return quizMarkedOnMap("county seat", geoPlaces(counties), californiaMap)
 }
@@ -15,55 +15,91 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var canadaCmd = &cobra.Command{
-	Use:   "canada",
-	Short: "Quiz territories and provinces of Canada",
-	Run:   quizCanada,
-}
+var canadaCmd = registerQuizArea(quizArea{
+	name:       "canada",
+	tags:       []string{"geography"},
+	short:      "Quiz territories and provinces of Canada",
+	generators: canadaQuestions,
+	dataset:    &canadianRegions,
+	validate:   validateCanadianBorders,
+	entity:     "Canadian region",
+})
 
 type canadaRegion struct {
 	orderBySize int    `crossquery:"all" crossqueryname:"size rank"`
-	name        string `crossquery:"all"`
-	capital     string `crossquery:"all"`
+	name        string `crossquery:"all" borders:"name"`
+	capital     string `crossquery:"all" geo:"name"`
+	// latitude and longitude are the capital's
+	latitude  float64 `geo:"latitude"`
+	longitude float64 `geo:"longitude"`
+	// neighbors share a land border, even one as small as Killiniq Island's
+	neighbors []string `borders:"neighbors"`
 }
 
 var canadianRegions = []canadaRegion{
-	{1, "Nunavut", "Iqaluit"},
-	{2, "Quebec", "Quebec City"},
-	{3, "Northwest Territory", "Yellowknife"},
-	{4, "Ontario", "Toronto"},
-	{5, "British Columbia", "Victoria"},
-	{6, "Alberta", "Edmonton"},
-	{7, "Saskatchewan", "Regina"},
-	{8, "Manitoba", "Winnipeg"},
-	{9, "Yukon", "Whitehorse"},
-	{10, "New Foundland and Labrador", "St. John's"},
-	{11, "New Brunswick", "Fredericton"},
-	{12, "Nova Scotia", "Halifax"},
-	{13, "Prince Edward Island", "Charlottetown"},
+	{1, "Nunavut", "Iqaluit", 63.75, -68.52, []string{"Manitoba", "New Foundland and Labrador", "Northwest Territory"}},
+	{2, "Quebec", "Quebec City", 46.81, -71.21, []string{"New Brunswick", "New Foundland and Labrador", "Ontario"}},
+	{3, "Northwest Territory", "Yellowknife", 62.45, -114.37, []string{"Alberta", "British Columbia", "Nunavut", "Saskatchewan", "Yukon"}},
+	{4, "Ontario", "Toronto", 43.65, -79.38, []string{"Manitoba", "Quebec"}},
+	{5, "British Columbia", "Victoria", 48.43, -123.37, []string{"Alberta", "Northwest Territory", "Yukon"}},
+	{6, "Alberta", "Edmonton", 53.55, -113.49, []string{"British Columbia", "Northwest Territory", "Saskatchewan"}},
+	{7, "Saskatchewan", "Regina", 50.45, -104.61, []string{"Alberta", "Manitoba", "Northwest Territory"}},
+	{8, "Manitoba", "Winnipeg", 49.9, -97.14, []string{"Nunavut", "Ontario", "Saskatchewan"}},
+	{9, "Yukon", "Whitehorse", 60.72, -135.06, []string{"British Columbia", "Northwest Territory"}},
+	{10, "New Foundland and Labrador", "St. John's", 47.56, -52.71, []string{"Nunavut", "Quebec"}},
+	{11, "New Brunswick", "Fredericton", 45.96, -66.64, []string{"Nova Scotia", "Quebec"}},
+	{12, "Nova Scotia", "Halifax", 44.65, -63.58, []string{"New Brunswick"}},
+	{13, "Prince Edward Island", "Charlottetown", 46.24, -63.13, nil},
 }
 
 type canadaQuestion func([]canadaRegion) promptAndResponse
 
-func quizCanada(cmd *cobra.Command, args []string) {
+func canadaQuestions() []questionGenerator {
 
 	var promptFuncs = []canadaQuestion{
 		crossQueryCanadaInfo,
+		crossQueryCanadaInfo,
+		crossQueryCanadaInfo,
+		quizCanadianCapitalFurther,
+		quizNearestCanadianCapital,
+		quizCanadianCapitalOnMap,
+		quizCanadianNeighbors,
+		quizCanadianRegionsShareBorder,
+		quizCanadianBorderPath,
 	}
 
-	function := randomItemFromSlice(promptFuncs)
-	promptAndCheckResponse(function(canadianRegions))
+	return generatorsFor(promptFuncs, canadianRegions)
 }
 
 func crossQueryCanadaInfo(regions []canadaRegion) promptAndResponse {
-	region := randomItemFromSlice(regions)
-	return constructCrossQuery("Canadian region", region)
+	return constructCrossQueryFromSlice("Canadian region", regions)
+}
+
+func quizCanadianCapitalFurther(regions []canadaRegion) promptAndResponse {
+	return quizFurther("Canadian capital", geoPlaces(regions))
+}
+
+func quizNearestCanadianCapital(regions []canadaRegion) promptAndResponse {
+	capitals := geoPlaces(regions)
+	return quizNearest(capitals, "Canadian capital", capitals)
+}
+
+func quizCanadianCapitalOnMap(regions []canadaRegion) promptAndResponse {
+	return quizMarkedOnMap("Canadian capital", geoPlaces(regions), canadaMap)
+}
+
+func validateCanadianBorders() []datasetProblem {
+	return bordersOf(canadianRegions).problems()
+}
+
+func quizCanadianNeighbors(regions []canadaRegion) promptAndResponse {
+	return quizNeighbors("Canadian region", bordersOf(regions))
+}
+
+func quizCanadianRegionsShareBorder(regions []canadaRegion) promptAndResponse {
+	return quizShareBorder(bordersOf(regions))
 }
 
-func init() {
-	memoryquizCmd.AddCommand(canadaCmd)
+func quizCanadianBorderPath(regions []canadaRegion) promptAndResponse {
+	return quizBorderPath("provinces and territories", bordersOf(regions))
 }
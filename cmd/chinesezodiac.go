@@ -17,17 +17,16 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
-
-	"github.com/spf13/cobra"
 )
 
 // shakespeareCmd represents the shakespeare command
-var chineseZodiacCmd = &cobra.Command{
-	Use:   "chinesezodiac",
-	Short: "Test recall of the Chinese zodiac",
-	Run:   quizChineseZodiacs,
-}
+var chineseZodiacCmd = registerQuizArea(quizArea{
+	name:       "chinesezodiac",
+	tags:       []string{"culture"},
+	short:      "Test recall of the Chinese zodiac",
+	generators: chineseZodiacQuestions,
+	dataset:    &chineseZodiac,
+})
 
 type chineseZodiacInfo struct {
 	animal        string
@@ -51,28 +50,23 @@ var chineseZodiac = []chineseZodiacInfo{
 
 type chineseZodiacQuiz func([]chineseZodiacInfo) promptAndResponse
 
-func quizChineseZodiacs(cmd *cobra.Command, args []string) {
+func chineseZodiacQuestions() []questionGenerator {
 	quizzes := []chineseZodiacQuiz{
 		quizChineseZodiacAnimalByIndex,
 		quizChineseZodiacByYear,
 	}
 
-	quiz := randomItemFromSlice(quizzes)
-	promptAndCheckResponse(quiz(chineseZodiac))
+	return generatorsFor(quizzes, chineseZodiac)
 }
 
 func quizChineseZodiacAnimalByIndex(zodiac []chineseZodiacInfo) promptAndResponse {
-	index := rand.Intn(len(zodiac))
-	return promptAndResponse{fmt.Sprintf("What Chinese zodiac animal is at position %d", index+1), zodiac[index].animal}
+	index := quizRand.Intn(len(zodiac))
+	return promptAndResponse{prompt: fmt.Sprintf("What Chinese zodiac animal is at position %d", index+1), response: zodiac[index].animal}
 }
 
 func quizChineseZodiacByYear(zodiac []chineseZodiacInfo) promptAndResponse {
-	yearOffset := rand.Intn(100)
+	yearOffset := quizRand.Intn(100)
 	targetYear := zodiac[0].referenceYear + yearOffset
 	animal := zodiac[(targetYear-zodiac[0].referenceYear)%12]
-	return promptAndResponse{fmt.Sprintf("What is the chinese zodiac animal for %d?", targetYear), animal.animal}
-}
-
-func init() {
-	memoryquizCmd.AddCommand(chineseZodiacCmd)
+	return promptAndResponse{prompt: fmt.Sprintf("What is the chinese zodiac animal for %d?", targetYear), response: animal.animal}
 }
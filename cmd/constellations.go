@@ -17,19 +17,19 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"strconv"
 	"strings"
-
-	"github.com/spf13/cobra"
 )
 
 // shakespeareCmd represents the shakespeare command
-var constellationCmd = &cobra.Command{
-	Use:   "constellations",
-	Short: "Test recall of officially recognized constellations in alphabetical order",
-	Run:   quizConstellations,
-}
+var constellationCmd = registerQuizArea(quizArea{
+	name:       "constellations",
+	tags:       []string{"science"},
+	short:      "Test recall of officially recognized constellations in alphabetical order",
+	generators: constellationQuestions,
+	dataset:    &constellations,
+	entity:     "constellation",
+})
 
 type constellation struct {
 	alphabeticalOrder int    `crossquery:"all" crossqueryname:"order"`
@@ -132,7 +132,7 @@ var constellations = []constellation{
 
 type constellationQuiz func([]constellation) promptAndResponse
 
-func quizConstellations(cmd *cobra.Command, args []string) {
+func constellationQuestions() []questionGenerator {
 	quizzes := []constellationQuiz{
 		quizConstellationByOrder,
 		crossQueryConstellationInfo,
@@ -142,23 +142,21 @@ func quizConstellations(cmd *cobra.Command, args []string) {
 		quizConstellationByStar,
 	}
 
-	quiz := randomItemFromSlice(quizzes)
-	promptAndCheckResponse(quiz(constellations))
+	return generatorsFor(quizzes, constellations)
 }
 
 func crossQueryConstellationInfo(constellations []constellation) promptAndResponse {
-	constellation := randomItemFromSlice(constellations)
-	return constructCrossQuery("constellation", constellation)
+	return constructCrossQueryFromSlice("constellation", constellations)
 }
 
 func quizConstellationByOrder(constellations []constellation) promptAndResponse {
 	constellation := randomItemFromSlice(constellations)
-	return promptAndResponse{fmt.Sprintf("Which constellation is position %d?", constellation.alphabeticalOrder), constellation.name}
+	return promptAndResponse{prompt: fmt.Sprintf("Which constellation is position %d?", constellation.alphabeticalOrder), response: constellation.name}
 }
 
 func quizConstellationCountByLetter(constellations []constellation) promptAndResponse {
 	// pick a letter
-	letterAscii := rune(65 + rand.Intn(26))
+	letterAscii := rune(65 + quizRand.Intn(26))
 	letter := string(letterAscii)
 	count := 0
 	for _, constellation := range constellations {
@@ -166,23 +164,23 @@ func quizConstellationCountByLetter(constellations []constellation) promptAndRes
 			count++
 		}
 	}
-	return promptAndResponse{fmt.Sprintf("How many constellations start with %s?", letter), strconv.Itoa(count)}
+	return promptAndResponse{prompt: fmt.Sprintf("How many constellations start with %s?", letter), response: strconv.Itoa(count)}
 }
 
 func quizStarInConstellation(constellations []constellation) promptAndResponse {
 	// winnow down to just constellations that have stars
 	withStars := constellationsWithStars(constellations)
 	constellation := randomItemFromSlice(withStars)
-	starIndex := rand.Intn(len(constellation.stars))
+	starIndex := quizRand.Intn(len(constellation.stars))
 	star := constellation.stars[starIndex]
-	return promptAndResponse{fmt.Sprintf("What is named star number %d in %s?", starIndex+1, constellation.name), star}
+	return promptAndResponse{prompt: fmt.Sprintf("What is named star number %d in %s?", starIndex+1, constellation.name), response: star}
 }
 
 func quizConstellationByStar(constellations []constellation) promptAndResponse {
 	withStars := constellationsWithStars(constellations)
 	constellation := randomItemFromSlice(withStars)
 	star := randomItemFromSlice(constellation.stars)
-	return promptAndResponse{fmt.Sprintf("Which constellation contains %s?", star), constellation.name}
+	return promptAndResponse{prompt: fmt.Sprintf("Which constellation contains %s?", star), response: constellation.name}
 }
 
 // return a subset of constellations that have stars listed for them
@@ -196,7 +194,3 @@ func constellationsWithStars(constellations []constellation) []constellation {
 	}
 	return withStars
 }
-
-func init() {
-	memoryquizCmd.AddCommand(constellationCmd)
-}
@@ -18,27 +18,34 @@ package cmd
 import (
 	"fmt"
 	"strconv"
-
-	"github.com/spf13/cobra"
 )
 
 // countriesCmd represents the countries command
-var countriesCmd = &cobra.Command{
-	Use:   "countries",
-	Short: "Memory quizzes about countries, including capitals and rank in area",
-	Run:   quizCountries,
-}
+var countriesCmd = registerQuizArea(quizArea{
+	name:       "countries",
+	tags:       []string{"geography"},
+	short:      "Memory quizzes about countries, including capitals and rank in area",
+	generators: countryQuestions,
+	dataset:    &countries,
+	validate:   validateCountryBorders,
+	entity:     "country",
+})
 
 type countryInfo struct {
-	rankInArea         int    `crossquery:"all" crossqueryname:"size rank"`
-	name               string `crossquery:"all"`
-	capital            string `crossquery:"all"`
-	region             []string
-	currency           string `crossquery:"guess"`
+	rankInArea         int      `crossquery:"all" crossqueryname:"size rank" rank:"desc" rankwords:"is bigger,is smaller,is the largest,is the smallest"`
+	name               string   `crossquery:"all" rank:"name" nameall:"name" borders:"name"`
+	capital            string   `crossquery:"all" geo:"name"`
+	region             []string `nameall:"in %v"`
+	currency           string   `crossquery:"guess"`
 	countryCode        string
 	ivrCode            string `crossquery:"all" crossqueryname:"IVR code"`
 	landlocked         bool
-	fractionalCurrency string `crossquery:"guess" crossqueryname:"fractional currency"`
+	fractionalCurrency string `crossquery:"guess" crossqueryname:"fractional currency" crossqueryoptional:"true"`
+	// latitude and longitude are the capital's
+	latitude  float64 `geo:"latitude"`
+	longitude float64 `geo:"longitude"`
+	// neighbors share a land border with the country itself, not its overseas territories like French Guiana
+	neighbors []string `borders:"neighbors"`
 }
 
 const (
@@ -61,205 +68,205 @@ func (c countryInfo) flagEmoji() string {
 }
 
 var countries = []countryInfo{
-	{1, "Russia", "Moscow", []string{asia, europe}, "ruble", "RU", "RUS", false, "kopeck"},
-	{2, "Canada", "Ottawa", []string{north_america}, "dollar", "CA", "CDN", false, "cent"},
-	{3, "United States", "Washington, D.C.", []string{north_america}, "US dollar", "US", "USA", false, "cent"},
-	{4, "China", "Beijing", []string{asia}, "renminbi", "CN", "RC", false, "jiao"},
-	{5, "Brazil", "Brasilia", []string{south_america}, "real", "BR", "BR", false, "centavo"},
-	{6, "Australia", "Canberra", []string{oceania}, "Australian dollar", "AU", "AUS", false, ""},
-	{7, "India", "New Delhi", []string{south_asia}, "rupee", "IN", "IND", false, "paisa"},
-	{8, "Argentina", "Buenos Aires", []string{south_america}, "peso", "AR", "RA", false, "centavo"},
-	{9, "Kazakhstan", "Nur-Sultan", []string{asia, europe}, "tenge", "KZ", "KZ", true, "tiyin"},
-	{10, "Algeria", "Algiers", []string{africa}, "dinar", "DZ", "DZ", false, "centime"},
-	{11, "Democratic Republic of Congo", "Kinshasa", []string{africa}, "franc", "CD", "CGO", false, "centime"},
-	{12, "Saudi Arabia", "Riyadh", []string{middle_east}, "riyal", "SA", "KSA", false, "halala"},
-	{13, "Mexico", "Mexico City", []string{central_america, north_america}, "peso", "MX", "MEX", false, "centavo"},
-	{14, "Indonesia", "Jakarta", []string{southeast_asia}, "rupiah", "ID", "RI", false, "sen"},
-	{15, "Sudan", "Khartoum", []string{africa}, "pound", "SD", "SUD", false, "piastre"},
-	{16, "Libya", "Tripoli", []string{africa}, "dinar", "LY", "LAR", false, "dirham"},
-	{17, "Iran", "Tehran", []string{middle_east}, "rial", "IR", "IR", false, "rial"},
-	{18, "Mongolia", "Ulaanbataar", []string{asia}, "tugrik", "MN", "MGL", true, "mongo"},
-	{19, "Peru", "Lima", []string{south_america}, "sol", "PE", "PE", false, "centimo"},
-	{20, "Chad", "N'Djamena", []string{africa}, "franc", "TD", "TCH", false, "centime"},
-	{21, "Niger", "Niamey", []string{africa}, "franc", "NE", "RN", true, "centime"},
-	{22, "Angola", "Luanda", []string{africa}, "kwanza", "AO", "ANG", true, "centimo"},
-	{23, "Mali", "Bamako", []string{africa}, "franc", "ML", "RMM", false, "centime"},
-	{24, "South Africa", "Pretoria", []string{africa}, "rand", "ZA", "ZA", false, "cent"},
-	{25, "Colombia", "Bogota", []string{south_america}, "peso", "CO", "CO", false, "centavo"},
-	{26, "Ethiopia", "Addis Ababa", []string{africa, middle_east}, "birr", "ET", "ETH", true, "santim"},
-	{27, "Bolivia", "Sucre", []string{south_america}, "boliviano", "BO", "BOL", true, "centavo"},
-	{28, "Mauritania", "Nouakchott", []string{africa}, "ouguiya", "MR", "RIM", false, "khoums"},
-	{29, "Egypt", "Cairo", []string{africa}, "pound", "EG", "ET", false, "piastre"},
-	{30, "Tanzania", "Dodoma", []string{africa}, "shilling", "TZ", "EAT", false, "cent"},
-	{31, "Nigeria", "Abuja", []string{africa}, "naira", "NG", "WAN", false, "kobo"},
-	{32, "Venezuela", "Caracas", []string{south_america}, "bolivar", "VE", "YV", false, "cent"},
-	{33, "Pakistan", "Islamabad", []string{asia, south_asia}, "rupee", "PK", "PK", false, "paisa"},
-	{34, "Namibia", "Windhoek", []string{africa}, "dollar", "NA", "NAM", false, "cent"},
-	{35, "Mozambique", "Maputo", []string{africa}, "metical", "MZ", "MOC", false, "centavo"},
-	{36, "Turkey", "Ankara", []string{asia, europe, middle_east}, "lyra", "TR", "TR", false, "kurush"},
-	{37, "Chile", "Santiago", []string{south_america}, "peso", "CL", "RCH", false, "centavo"},
-	{38, "Zambia", "Lusaka", []string{africa}, "kwacha", "ZM", "Z", true, "ngwee"},
-	{39, "Myanmar", "Naypyidaw", []string{southeast_asia}, "kyat", "MM", "BUR", false, "pya"},
-	{40, "Afghanistan", "Kabul", []string{south_asia}, "afghani", "AF", "AFG", true, "pul"},
-	{41, "South Sudan", "Juba", []string{africa}, "pound", "SS", "", true, "piaster"},
-	{42, "France", "Paris", []string{europe}, "euro", "FR", "F", false, "cent"},
-	{43, "Somalia", "Mogadishu", []string{africa}, "shilling", "SO", "SO", false, "cent"},
-	{44, "Central African Republic", "Bangui", []string{africa}, "franc", "CF", "RCA", true, "centime"},
-	{45, "Ukraine", "Kyiv", []string{europe}, "hryvnia", "UA", "UA", false, "kopek"},
-	{46, "Madagascar", "Antananarivo", []string{africa}, "ariary", "MG", "RM", false, "iraimbilanja"},
-	{47, "Botswana", "Gaborone", []string{africa}, "pula", "BW", "BW", true, "thebe"},
-	{48, "Kenya", "Nairobi", []string{africa}, "shilling", "KE", "EAK", false, "cent"},
-	{49, "Yemen", "Sana'a", []string{middle_east}, "rial", "YE", "YAR", false, "fils"},
-	{50, "Thailand", "Bangkok", []string{southeast_asia}, "baht", "TH", "T", false, "satang"},
-	{51, "Spain", "Madrid", []string{europe}, "euro", "ES", "E", false, "cent"},
-	{52, "Turkmenistan", "Ashgabat", []string{asia}, "manat", "TM", "TM", true, "tenge"},
-	{53, "Cameroon", "Yaounde", []string{africa}, "franc", "CM", "CAM", false, "centime"},
-	{54, "Papua New Guinea", "Port Moresby", []string{oceania}, "kina", "PG", "PNG", false, "toea"},
-	{55, "Sweden", "Stockholm", []string{europe}, "krona", "SE", "S", false, "ore"},
-	{56, "Uzbekistan", "Tashkent", []string{asia}, "som", "UZ", "UZ", true, "tiyin"},
-	{57, "Morocco", "Rabat", []string{africa}, "dirham", "MA", "MA", false, "centime"},
-	{58, "Iraq", "Baghdad", []string{middle_east}, "dinar", "IQ", "IRQ", false, "fils"},
-	{59, "Paraguay", "Asuncion", []string{south_america}, "guarani", "PY", "PY", true, "centimo"},
-	{60, "Zimbabwe", "Harare", []string{africa}, "US dollar", "ZW", "ZW", true, "cent"},
-	{61, "Norway", "Oslo", []string{europe}, "krone", "NO", "N", false, "ore"},
-	{62, "Japan", "Tokyo", []string{asia}, "yen", "JP", "J", false, "sen"},
-	{63, "Germany", "Berlin", []string{europe}, "euro", "DE", "D", false, "cent"},
-	{64, "Republic of the Congo", "Brazzaville", []string{africa}, "franc", "CG", "RCB", false, "centime"},
-	{65, "Finland", "Helsinki", []string{europe}, "euro", "FI", "FIN", false, "cent"},
-	{66, "Viet Nam", "Hanoi", []string{southeast_asia}, "dong", "VN", "VN", false, "hao"},
-	{67, "Malaysia", "Kuala Lumpur", []string{southeast_asia}, "ringgit", "MY", "MAL", false, "sen"},
-	{68, "Ivory Coast", "Yamoussoukro", []string{africa}, "franc", "CI", "CN", false, "centime"},
-	{69, "Poland", "Warsaw", []string{europe}, "zloty", "PL", "PL", false, "grosz"},
-	{70, "Oman", "Muscat", []string{middle_east}, "rial", "OM", "OM", false, "baisa"},
-	{71, "Italy", "Rome", []string{europe}, "euro", "IT", "I", false, "cent"},
-	{72, "Phillipines", "Manila", []string{southeast_asia}, "peso", "PH", "RP", false, "sentimo"},
-	{73, "Ecuador", "Quito", []string{south_america}, "US dollar", "EC", "EC", false, "centavo"},
-	{74, "Burkina Faso", "Ouagadougou", []string{africa}, "franc", "BF", "BF", true, "centime"},
-	{75, "New Zealand", "Wellington", []string{oceania}, "dollar", "NZ", "NZ", false, "cent"},
-	{76, "Gabon", "Libreville", []string{africa}, "franc", "GA", "G", false, "centime"},
-	{77, "Guinea", "Conakry", []string{africa}, "franc", "GN", "RG", false, "centime"},
-	{78, "United Kingdom", "London", []string{europe}, "pound", "GB", "UK", false, "penny"},
-	{79, "Uganda", "Kampala", []string{africa}, "shilling", "UG", "EAU", true, ""},
-	{80, "Ghana", "Accra", []string{africa}, "cedi", "GH", "GH", false, "pesewa"},
-	{81, "Romania", "Bucharest", []string{europe}, "leu", "RO", "RO", false, ""},
-	{82, "Laos", "Vientiane", []string{southeast_asia}, "kip", "LA", "LAO", true, ""},
-	{83, "Guyana", "Georgetown", []string{south_america}, "dollar", "GY", "GUY", false, ""},
-	{84, "Belarus", "Minsk", []string{europe}, "ruble", "BY", "BY", true, ""},
-	{85, "Kyrgyzstan", "Bishkek", []string{asia}, "som", "KG", "KG", true, ""},
-	{86, "Senegal", "Dakar", []string{africa}, "franc", "SN", "SN", false, ""},
-	{87, "Syria", "Damascus", []string{middle_east}, "pound", "SY", "SYR", false, ""},
-	{88, "Cambodia", "Phnom Penh", []string{southeast_asia}, "riel", "KH", "K", false, ""},
-	{89, "Uruguay", "Montevideo", []string{south_america}, "peso", "UY", "UY", false, ""},
-	{90, "Suriname", "Paramaribo", []string{south_america}, "dollar", "SR", "SME", false, ""},
-	{91, "Tunisia", "Tunis", []string{africa}, "dinar", "TN", "TN", false, ""},
-	{92, "Bangladesh", "Dhaka", []string{south_asia}, "taka", "BD", "BD", false, ""},
-	{93, "Nepal", "Kathmandu", []string{south_asia}, "rupee", "NP", "NEP", true, ""},
-	{94, "Tajikistan", "Dusharbe", []string{asia}, "somoni", "TJ", "TJ", true, ""},
-	{95, "Greece", "Athens", []string{europe}, "euro", "GR", "GR", false, ""},
-	{96, "Nicaragua", "Managua", []string{central_america}, "cordoba", "NI", "NIC", false, ""},
-	{97, "North Korea", "Pyongyang", []string{asia}, "won", "KP", "", false, ""},
-	{98, "Malawi", "Lilongwe", []string{africa}, "kwacha", "MW", "MW", true, ""},
-	{99, "Eritrea", "Asmara", []string{africa}, "nakfa", "ER", "ER", false, ""},
-	{100, "Benin", "Porto-Novo", []string{africa}, "franc", "BJ", "DY", false, ""},
-	{101, "Honduras", "Tegucigalpa", []string{central_america}, "lempira", "HN", "HN", false, ""},
-	{102, "Liberia", "Monrovia", []string{africa}, "dollar", "LR", "LB", false, ""},
-	{103, "Bulgaria", "Sofia", []string{europe}, "lev", "BG", "BG", false, ""},
-	{104, "Cuba", "Havana", []string{caribbean}, "peso", "CU", "CU", false, ""},
-	{105, "Guatemala", "Guatemala City", []string{central_america}, "quetzal", "GT", "GCA", false, ""},
-	{106, "Iceland", "Reykjavik", []string{europe}, "krona", "IS", "IS", false, ""},
-	{107, "South Korea", "Seoul", []string{asia}, "won", "KR", "ROK", false, ""},
-	{108, "Hungary", "Budapest", []string{europe}, "forint", "HU", "H", true, ""},
-	{109, "Portugal", "Lisbon", []string{europe}, "euro", "PT", "P", false, ""},
-	{110, "Jordan", "Amman", []string{middle_east}, "dinar", "JO", "HKJ", false, ""},
-	{111, "Serbia", "Belgrade", []string{europe}, "dinar", "RS", "SRB", true, ""},
-	{112, "Azerbaijan", "Baku", []string{asia, europe}, "manat", "AZ", "AZ", true, ""},
-	{113, "Austria", "Vienna", []string{europe}, "euro", "AT", "A", true, ""},
-	{114, "United Arab Emirates", "Abu Dhabi", []string{middle_east}, "dirham", "AE", "UAE", false, ""},
-	{115, "Czech Republic", "Prague", []string{europe}, "koruna", "CZ", "CZ", true, ""},
-	{116, "Panama", "Panama City", []string{central_america}, "US dollar", "PA", "PA", false, ""},
-	{117, "Sierra Leone", "Freetown", []string{africa}, "leone", "SL", "WAL", false, ""},
-	{118, "Ireland", "Dublin", []string{europe}, "euro", "IE", "IRL", false, ""},
-	{119, "Georgia", "Tbilisi", []string{asia, europe}, "lari", "GE", "GE", false, ""},
-	{120, "Sri Lanka", "Sri Jayawardenepura Kotte", []string{southeast_asia}, "rupee", "LK", "CL", false, ""},
-	{121, "Lithuania", "Vilnius", []string{europe}, "euro", "LT", "LT", false, ""},
-	{122, "Latvia", "Riga", []string{europe}, "euro", "LV", "LV", false, ""},
-	{123, "Togo", "Lome", []string{africa}, "franc", "TG", "TG", false, ""},
-	{124, "Croatia", "Zagreb", []string{europe}, "kona", "HR", "HR", false, ""},
-	{125, "Bosnia and Herzegovina", "Sarajevo", []string{europe}, "mark", "BA", "BIH", false, ""},
-	{126, "Costa Rica", "San Jose", []string{central_america}, "colon", "CR", "CR", false, ""},
-	{127, "Slovakia", "Bratislava", []string{europe}, "euro", "SK", "SK", true, ""},
-	{128, "Dominican Republic", "Santo Domingo", []string{caribbean}, "peso", "DO", "DOM", false, ""},
-	{129, "Estonia", "Tallinn", []string{europe}, "kroon", "EE", "EST", false, ""},
-	{130, "Denmark", "Copenhagen", []string{europe}, "krone", "DK", "DK", false, ""},
-	{131, "Netherlands", "Amsterdam", []string{europe}, "euro", "NL", "NL", false, ""},
-	{132, "Switzerland", "Bern", []string{europe}, "Swiss franc", "CH", "CH", true, ""},
-	{133, "Bhutan", "Thimphu", []string{south_asia}, "ngultrum", "BT", "BHT", true, ""},
-	{134, "Guinea-Bissau", "Bissau", []string{africa}, "franc", "GW", "RGB", false, ""},
-	{135, "Moldova", "Kishinev", []string{europe}, "leu", "MD", "MD", true, ""},
-	{136, "Belgium", "Brussels", []string{europe}, "euro", "BE", "B", false, ""},
-	{137, "Lesotho", "Maseru", []string{africa}, "loti", "LS", "LS", true, ""},
-	{138, "Armenia", "Yerevan", []string{asia}, "dram", "AM", "AM", true, ""},
-	{139, "Solomon Islands", "Honiara", []string{oceania}, "dollar", "SB", "SOL", false, ""},
-	{140, "Albania", "Tirana", []string{europe}, "lek", "AL", "AL", false, ""},
-	{141, "Equatorial Guinea", "Malabo", []string{africa}, "franc", "GQ", "", false, ""},
-	{142, "Burundi", "Gitega", []string{africa}, "franc", "BI", "RU", true, ""},
-	{143, "Haiti", "Port-au-Prince", []string{caribbean}, "gourde", "HT", "RH", false, ""},
-	{144, "Rwanda", "Kigali", []string{africa}, "franc", "RW", "RWA", true, ""},
-	{145, "North Macedonia", "Skopje", []string{europe}, "denar", "MK", "NMK", true, ""},
-	{146, "Djibouti", "Djibouti", []string{africa}, "franc", "DJ", "", false, ""},
-	{147, "Belize", "Belmopan", []string{central_america}, "dollar", "BZ", "BH", false, ""},
-	{148, "El Salvador", "San Salvador", []string{central_america}, "US dollar", "SV", "ES", false, ""},
-	{149, "Israel", "Jerusalem", []string{middle_east}, "shekel", "IL", "IL", false, ""},
-	{150, "Slovenia", "Ljubljana", []string{europe}, "euro", "SI", "SLO", false, ""},
-	{151, "Fiji", "Suva", []string{oceania}, "dollar", "FJ", "FJI", false, ""},
-	{152, "Kuwait", "Kuwait City", []string{middle_east}, "dinar", "KW", "KWT", false, ""},
-	{153, "Eswatini", "Mbabane", []string{africa}, "rand", "SZ", "SD", true, ""},
-	{154, "East Timor", "Dili", []string{southeast_asia}, "timor-leste", "TL", "TL", false, ""},
-	{155, "The Bahamas", "Nassau", []string{caribbean}, "dollar", "BS", "BS", false, ""},
-	{156, "Montenegro", "Podgorica", []string{europe}, "euro", "ME", "MNE", false, ""},
-	{157, "Vanuatu", "Port Vila", []string{oceania}, "vatu", "VU", "VU", false, ""},
-	{158, "Qatar", "Doha", []string{middle_east}, "rial", "QA", "Q", false, ""},
-	{159, "The Gambia", "Banjul", []string{africa}, "dalasi", "GM", "WAG", false, ""},
-	{160, "Jamaica", "Kingston", []string{caribbean}, "dollar", "JM", "JA", false, ""},
-	{161, "Lebanon", "Beirut", []string{middle_east}, "pound", "LB", "RL", false, ""},
-	{162, "Cyprus", "Nicosia", []string{europe, middle_east}, "euro", "CY", "CY", false, ""},
-	{163, "Brunei", "Bandar Seri Begawan", []string{south_asia}, "dollar", "BN", "BRU", false, ""},
-	{164, "Trinidad and Tobago", "Port of Spain", []string{caribbean}, "dollar", "TT", "TT", false, ""},
-	{165, "Cape Verde", "Praia", []string{africa}, "escudo", "CV", "CV", false, ""},
-	{166, "Samoa", "Apia", []string{oceania}, "tala", "WS", "WS", false, ""},
-	{167, "Luxembourg", "Luxembourg City", []string{europe}, "euro", "LU", "L", true, ""},
-	{168, "Mauritius", "Port Louis", []string{africa}, "rupee", "MU", "MS", false, ""},
-	{169, "Comoros", "Moroni", []string{africa}, "franc", "KM", "COM", false, ""},
-	{170, "Sao Tome and Principe", "Sao Tome", []string{africa}, "dobra", "ST", "STP", false, ""},
-	{171, "Kiribati", "South Tarawa", []string{oceania}, "Australian dollar", "KI", "KIR", false, ""},
-	{172, "Bahrain", "Manama", []string{middle_east}, "dinar", "BH", "BRN", false, ""},
-	{173, "Dominica", "Roseau", []string{caribbean}, "Eastern Caribbean dollar", "DM", "WD", false, ""},
-	{174, "Tonga", "Nuku'alofa", []string{oceania}, "pa'anga", "TO", "TO", false, ""},
-	{175, "Singapore", "Singapore", []string{southeast_asia}, "dollar", "SG", "SGP", false, ""},
-	{176, "Federated States of Micronesia", "Palikir", []string{oceania}, "US dollar", "FM", "FSM", false, ""},
-	{177, "Saint Lucia", "Castries", []string{caribbean}, "Eastern Caribbean dollar", "LC", "WL", false, ""},
-	{178, "Andorra", "Andorra la Vella", []string{europe}, "euro", "AD", "AND", true, ""},
-	{179, "Palau", "Ngerulmud", []string{oceania}, "US dollar", "PW", "PAL", false, ""},
-	{180, "Seychelles", "Victoria", []string{africa}, "rupee", "SC", "SY", false, ""},
-	{181, "Antigua and Barbuda", "St. John's", []string{caribbean}, "Eastern Caribbean dollar", "AG", "AG", false, ""},
-	{182, "Barbados", "Bridgetown", []string{caribbean}, "dollar", "BB", "BDS", false, ""},
-	{183, "Saint Vincent and the Grenadines", "Kingstown", []string{caribbean}, "Eastern Caribbean dollar", "VC", "WV", false, ""},
-	{184, "Grenada", "St. George's", []string{caribbean}, "Eastern Caribbean dollar", "GD", "WG", false, ""},
-	{185, "Malta", "Valletta", []string{europe}, "euro", "MT", "M", false, ""},
-	{186, "Maldives", "Male", []string{south_asia}, "rufiyaa", "MV", "MV", false, ""},
-	{187, "Saint Kitts and Nevis", "Basseterre", []string{caribbean}, "Eastern Caribbean dollar", "KN", "KAN", false, ""},
-	{188, "Marshall Islands", "Majuro", []string{oceania}, "US dollar", "MH", "MH", false, ""},
-	{189, "Liechtenstein", "Vaduz", []string{europe}, "Swiss franc", "LI", "FL", true, ""},
-	{190, "San Marino", "San Marino", []string{europe}, "euro", "SM", "RSM", true, ""},
-	{191, "Tuvalu", "Funafuti", []string{oceania}, "US dollar", "TV", "TUV", false, ""},
-	{192, "Nauru", "Yaren", []string{oceania}, "Australian dollar", "NR", "NAU", false, ""},
-	{193, "Monaco", "Monaco", []string{europe}, "euro", "MC", "MC", false, ""},
-	{194, "Vatican City", "Vatican City", []string{europe}, "euro", "VA", "V", true, ""},
+	{1, "Russia", "Moscow", []string{asia, europe}, "ruble", "RU", "RUS", false, "kopeck", 55.76, 37.62, []string{"Azerbaijan", "Belarus", "China", "Estonia", "Finland", "Georgia", "Kazakhstan", "Latvia", "Lithuania", "Mongolia", "North Korea", "Norway", "Poland", "Ukraine"}},
+	{2, "Canada", "Ottawa", []string{north_america}, "dollar", "CA", "CDN", false, "cent", 45.42, -75.7, []string{"United States"}},
+	{3, "United States", "Washington, D.C.", []string{north_america}, "US dollar", "US", "USA", false, "cent", 38.9, -77.04, []string{"Canada", "Mexico"}},
+	{4, "China", "Beijing", []string{asia}, "renminbi", "CN", "RC", false, "jiao", 39.9, 116.41, []string{"Afghanistan", "Bhutan", "India", "Kazakhstan", "Kyrgyzstan", "Laos", "Mongolia", "Myanmar", "Nepal", "North Korea", "Pakistan", "Russia", "Tajikistan", "Viet Nam"}},
+	{5, "Brazil", "Brasilia", []string{south_america}, "real", "BR", "BR", false, "centavo", -15.79, -47.88, []string{"Argentina", "Bolivia", "Colombia", "Guyana", "Paraguay", "Peru", "Suriname", "Uruguay", "Venezuela"}},
+	{6, "Australia", "Canberra", []string{oceania}, "Australian dollar", "AU", "AUS", false, "", -35.28, 149.13, nil},
+	{7, "India", "New Delhi", []string{south_asia}, "rupee", "IN", "IND", false, "paisa", 28.61, 77.21, []string{"Bangladesh", "Bhutan", "China", "Myanmar", "Nepal", "Pakistan"}},
+	{8, "Argentina", "Buenos Aires", []string{south_america}, "peso", "AR", "RA", false, "centavo", -34.6, -58.38, []string{"Bolivia", "Brazil", "Chile", "Paraguay", "Uruguay"}},
+	{9, "Kazakhstan", "Nur-Sultan", []string{asia, europe}, "tenge", "KZ", "KZ", true, "tiyin", 51.17, 71.45, []string{"China", "Kyrgyzstan", "Russia", "Turkmenistan", "Uzbekistan"}},
+	{10, "Algeria", "Algiers", []string{africa}, "dinar", "DZ", "DZ", false, "centime", 36.75, 3.06, []string{"Libya", "Mali", "Mauritania", "Morocco", "Niger", "Tunisia"}},
+	{11, "Democratic Republic of Congo", "Kinshasa", []string{africa}, "franc", "CD", "CGO", false, "centime", -4.44, 15.27, []string{"Angola", "Burundi", "Central African Republic", "Republic of the Congo", "Rwanda", "South Sudan", "Tanzania", "Uganda", "Zambia"}},
+	{12, "Saudi Arabia", "Riyadh", []string{middle_east}, "riyal", "SA", "KSA", false, "halala", 24.71, 46.68, []string{"Iraq", "Jordan", "Kuwait", "Oman", "Qatar", "United Arab Emirates", "Yemen"}},
+	{13, "Mexico", "Mexico City", []string{central_america, north_america}, "peso", "MX", "MEX", false, "centavo", 19.43, -99.13, []string{"Belize", "Guatemala", "United States"}},
+	{14, "Indonesia", "Jakarta", []string{southeast_asia}, "rupiah", "ID", "RI", false, "sen", -6.21, 106.85, []string{"East Timor", "Malaysia", "Papua New Guinea"}},
+	{15, "Sudan", "Khartoum", []string{africa}, "pound", "SD", "SUD", false, "piastre", 15.5, 32.56, []string{"Central African Republic", "Chad", "Egypt", "Eritrea", "Ethiopia", "Libya", "South Sudan"}},
+	{16, "Libya", "Tripoli", []string{africa}, "dinar", "LY", "LAR", false, "dirham", 32.89, 13.19, []string{"Algeria", "Chad", "Egypt", "Niger", "Sudan", "Tunisia"}},
+	{17, "Iran", "Tehran", []string{middle_east}, "rial", "IR", "IR", false, "rial", 35.69, 51.39, []string{"Afghanistan", "Armenia", "Azerbaijan", "Iraq", "Pakistan", "Turkey", "Turkmenistan"}},
+	{18, "Mongolia", "Ulaanbataar", []string{asia}, "tugrik", "MN", "MGL", true, "mongo", 47.89, 106.91, []string{"China", "Russia"}},
+	{19, "Peru", "Lima", []string{south_america}, "sol", "PE", "PE", false, "centimo", -12.05, -77.04, []string{"Bolivia", "Brazil", "Chile", "Colombia", "Ecuador"}},
+	{20, "Chad", "N'Djamena", []string{africa}, "franc", "TD", "TCH", false, "centime", 12.13, 15.06, []string{"Cameroon", "Central African Republic", "Libya", "Niger", "Nigeria", "Sudan"}},
+	{21, "Niger", "Niamey", []string{africa}, "franc", "NE", "RN", true, "centime", 13.51, 2.11, []string{"Algeria", "Benin", "Burkina Faso", "Chad", "Libya", "Mali", "Nigeria"}},
+	{22, "Angola", "Luanda", []string{africa}, "kwanza", "AO", "ANG", true, "centimo", -8.84, 13.23, []string{"Democratic Republic of Congo", "Namibia", "Republic of the Congo", "Zambia"}},
+	{23, "Mali", "Bamako", []string{africa}, "franc", "ML", "RMM", false, "centime", 12.64, -8.0, []string{"Algeria", "Burkina Faso", "Guinea", "Ivory Coast", "Mauritania", "Niger", "Senegal"}},
+	{24, "South Africa", "Pretoria", []string{africa}, "rand", "ZA", "ZA", false, "cent", -25.75, 28.19, []string{"Botswana", "Eswatini", "Lesotho", "Mozambique", "Namibia", "Zimbabwe"}},
+	{25, "Colombia", "Bogota", []string{south_america}, "peso", "CO", "CO", false, "centavo", 4.71, -74.07, []string{"Brazil", "Ecuador", "Panama", "Peru", "Venezuela"}},
+	{26, "Ethiopia", "Addis Ababa", []string{africa, middle_east}, "birr", "ET", "ETH", true, "santim", 9.03, 38.74, []string{"Djibouti", "Eritrea", "Kenya", "Somalia", "South Sudan", "Sudan"}},
+	{27, "Bolivia", "Sucre", []string{south_america}, "boliviano", "BO", "BOL", true, "centavo", -19.04, -65.26, []string{"Argentina", "Brazil", "Chile", "Paraguay", "Peru"}},
+	{28, "Mauritania", "Nouakchott", []string{africa}, "ouguiya", "MR", "RIM", false, "khoums", 18.08, -15.98, []string{"Algeria", "Mali", "Senegal"}},
+	{29, "Egypt", "Cairo", []string{africa}, "pound", "EG", "ET", false, "piastre", 30.04, 31.24, []string{"Israel", "Libya", "Sudan"}},
+	{30, "Tanzania", "Dodoma", []string{africa}, "shilling", "TZ", "EAT", false, "cent", -6.16, 35.75, []string{"Burundi", "Democratic Republic of Congo", "Kenya", "Malawi", "Mozambique", "Rwanda", "Uganda", "Zambia"}},
+	{31, "Nigeria", "Abuja", []string{africa}, "naira", "NG", "WAN", false, "kobo", 9.08, 7.4, []string{"Benin", "Cameroon", "Chad", "Niger"}},
+	{32, "Venezuela", "Caracas", []string{south_america}, "bolivar", "VE", "YV", false, "cent", 10.48, -66.9, []string{"Brazil", "Colombia", "Guyana"}},
+	{33, "Pakistan", "Islamabad", []string{asia, south_asia}, "rupee", "PK", "PK", false, "paisa", 33.68, 73.05, []string{"Afghanistan", "China", "India", "Iran"}},
+	{34, "Namibia", "Windhoek", []string{africa}, "dollar", "NA", "NAM", false, "cent", -22.56, 17.08, []string{"Angola", "Botswana", "South Africa", "Zambia"}},
+	{35, "Mozambique", "Maputo", []string{africa}, "metical", "MZ", "MOC", false, "centavo", -25.97, 32.57, []string{"Eswatini", "Malawi", "South Africa", "Tanzania", "Zambia", "Zimbabwe"}},
+	{36, "Turkey", "Ankara", []string{asia, europe, middle_east}, "lyra", "TR", "TR", false, "kurush", 39.93, 32.86, []string{"Armenia", "Azerbaijan", "Bulgaria", "Georgia", "Greece", "Iran", "Iraq", "Syria"}},
+	{37, "Chile", "Santiago", []string{south_america}, "peso", "CL", "RCH", false, "centavo", -33.45, -70.67, []string{"Argentina", "Bolivia", "Peru"}},
+	{38, "Zambia", "Lusaka", []string{africa}, "kwacha", "ZM", "Z", true, "ngwee", -15.39, 28.32, []string{"Angola", "Botswana", "Democratic Republic of Congo", "Malawi", "Mozambique", "Namibia", "Tanzania", "Zimbabwe"}},
+	{39, "Myanmar", "Naypyidaw", []string{southeast_asia}, "kyat", "MM", "BUR", false, "pya", 19.76, 96.08, []string{"Bangladesh", "China", "India", "Laos", "Thailand"}},
+	{40, "Afghanistan", "Kabul", []string{south_asia}, "afghani", "AF", "AFG", true, "pul", 34.56, 69.21, []string{"China", "Iran", "Pakistan", "Tajikistan", "Turkmenistan", "Uzbekistan"}},
+	{41, "South Sudan", "Juba", []string{africa}, "pound", "SS", "", true, "piaster", 4.85, 31.58, []string{"Central African Republic", "Democratic Republic of Congo", "Ethiopia", "Kenya", "Sudan", "Uganda"}},
+	{42, "France", "Paris", []string{europe}, "euro", "FR", "F", false, "cent", 48.86, 2.35, []string{"Andorra", "Belgium", "Germany", "Italy", "Luxembourg", "Monaco", "Spain", "Switzerland"}},
+	{43, "Somalia", "Mogadishu", []string{africa}, "shilling", "SO", "SO", false, "cent", 2.05, 45.32, []string{"Djibouti", "Ethiopia", "Kenya"}},
+	{44, "Central African Republic", "Bangui", []string{africa}, "franc", "CF", "RCA", true, "centime", 4.39, 18.56, []string{"Cameroon", "Chad", "Democratic Republic of Congo", "Republic of the Congo", "South Sudan", "Sudan"}},
+	{45, "Ukraine", "Kyiv", []string{europe}, "hryvnia", "UA", "UA", false, "kopek", 50.45, 30.52, []string{"Belarus", "Hungary", "Moldova", "Poland", "Romania", "Russia", "Slovakia"}},
+	{46, "Madagascar", "Antananarivo", []string{africa}, "ariary", "MG", "RM", false, "iraimbilanja", -18.88, 47.51, nil},
+	{47, "Botswana", "Gaborone", []string{africa}, "pula", "BW", "BW", true, "thebe", -24.63, 25.92, []string{"Namibia", "South Africa", "Zambia", "Zimbabwe"}},
+	{48, "Kenya", "Nairobi", []string{africa}, "shilling", "KE", "EAK", false, "cent", -1.29, 36.82, []string{"Ethiopia", "Somalia", "South Sudan", "Tanzania", "Uganda"}},
+	{49, "Yemen", "Sana'a", []string{middle_east}, "rial", "YE", "YAR", false, "fils", 15.37, 44.19, []string{"Oman", "Saudi Arabia"}},
+	{50, "Thailand", "Bangkok", []string{southeast_asia}, "baht", "TH", "T", false, "satang", 13.76, 100.5, []string{"Cambodia", "Laos", "Malaysia", "Myanmar"}},
+	{51, "Spain", "Madrid", []string{europe}, "euro", "ES", "E", false, "cent", 40.42, -3.7, []string{"Andorra", "France", "Morocco", "Portugal"}},
+	{52, "Turkmenistan", "Ashgabat", []string{asia}, "manat", "TM", "TM", true, "tenge", 37.96, 58.33, []string{"Afghanistan", "Iran", "Kazakhstan", "Uzbekistan"}},
+	{53, "Cameroon", "Yaounde", []string{africa}, "franc", "CM", "CAM", false, "centime", 3.85, 11.5, []string{"Central African Republic", "Chad", "Equatorial Guinea", "Gabon", "Nigeria", "Republic of the Congo"}},
+	{54, "Papua New Guinea", "Port Moresby", []string{oceania}, "kina", "PG", "PNG", false, "toea", -9.44, 147.18, []string{"Indonesia"}},
+	{55, "Sweden", "Stockholm", []string{europe}, "krona", "SE", "S", false, "ore", 59.33, 18.07, []string{"Finland", "Norway"}},
+	{56, "Uzbekistan", "Tashkent", []string{asia}, "som", "UZ", "UZ", true, "tiyin", 41.3, 69.24, []string{"Afghanistan", "Kazakhstan", "Kyrgyzstan", "Tajikistan", "Turkmenistan"}},
+	{57, "Morocco", "Rabat", []string{africa}, "dirham", "MA", "MA", false, "centime", 34.02, -6.83, []string{"Algeria", "Spain"}},
+	{58, "Iraq", "Baghdad", []string{middle_east}, "dinar", "IQ", "IRQ", false, "fils", 33.31, 44.36, []string{"Iran", "Jordan", "Kuwait", "Saudi Arabia", "Syria", "Turkey"}},
+	{59, "Paraguay", "Asuncion", []string{south_america}, "guarani", "PY", "PY", true, "centimo", -25.26, -57.58, []string{"Argentina", "Bolivia", "Brazil"}},
+	{60, "Zimbabwe", "Harare", []string{africa}, "US dollar", "ZW", "ZW", true, "cent", -17.83, 31.05, []string{"Botswana", "Mozambique", "South Africa", "Zambia"}},
+	{61, "Norway", "Oslo", []string{europe}, "krone", "NO", "N", false, "ore", 59.91, 10.75, []string{"Finland", "Russia", "Sweden"}},
+	{62, "Japan", "Tokyo", []string{asia}, "yen", "JP", "J", false, "sen", 35.68, 139.69, nil},
+	{63, "Germany", "Berlin", []string{europe}, "euro", "DE", "D", false, "cent", 52.52, 13.4, []string{"Austria", "Belgium", "Czech Republic", "Denmark", "France", "Luxembourg", "Netherlands", "Poland", "Switzerland"}},
+	{64, "Republic of the Congo", "Brazzaville", []string{africa}, "franc", "CG", "RCB", false, "centime", -4.26, 15.24, []string{"Angola", "Cameroon", "Central African Republic", "Democratic Republic of Congo", "Gabon"}},
+	{65, "Finland", "Helsinki", []string{europe}, "euro", "FI", "FIN", false, "cent", 60.17, 24.94, []string{"Norway", "Russia", "Sweden"}},
+	{66, "Viet Nam", "Hanoi", []string{southeast_asia}, "dong", "VN", "VN", false, "hao", 21.03, 105.85, []string{"Cambodia", "China", "Laos"}},
+	{67, "Malaysia", "Kuala Lumpur", []string{southeast_asia}, "ringgit", "MY", "MAL", false, "sen", 3.14, 101.69, []string{"Brunei", "Indonesia", "Thailand"}},
+	{68, "Ivory Coast", "Yamoussoukro", []string{africa}, "franc", "CI", "CN", false, "centime", 6.83, -5.29, []string{"Burkina Faso", "Ghana", "Guinea", "Liberia", "Mali"}},
+	{69, "Poland", "Warsaw", []string{europe}, "zloty", "PL", "PL", false, "grosz", 52.23, 21.01, []string{"Belarus", "Czech Republic", "Germany", "Lithuania", "Russia", "Slovakia", "Ukraine"}},
+	{70, "Oman", "Muscat", []string{middle_east}, "rial", "OM", "OM", false, "baisa", 23.59, 58.41, []string{"Saudi Arabia", "United Arab Emirates", "Yemen"}},
+	{71, "Italy", "Rome", []string{europe}, "euro", "IT", "I", false, "cent", 41.9, 12.5, []string{"Austria", "France", "San Marino", "Slovenia", "Switzerland", "Vatican City"}},
+	{72, "Phillipines", "Manila", []string{southeast_asia}, "peso", "PH", "RP", false, "sentimo", 14.6, 120.98, nil},
+	{73, "Ecuador", "Quito", []string{south_america}, "US dollar", "EC", "EC", false, "centavo", -0.18, -78.47, []string{"Colombia", "Peru"}},
+	{74, "Burkina Faso", "Ouagadougou", []string{africa}, "franc", "BF", "BF", true, "centime", 12.37, -1.52, []string{"Benin", "Ghana", "Ivory Coast", "Mali", "Niger", "Togo"}},
+	{75, "New Zealand", "Wellington", []string{oceania}, "dollar", "NZ", "NZ", false, "cent", -41.29, 174.78, nil},
+	{76, "Gabon", "Libreville", []string{africa}, "franc", "GA", "G", false, "centime", 0.42, 9.47, []string{"Cameroon", "Equatorial Guinea", "Republic of the Congo"}},
+	{77, "Guinea", "Conakry", []string{africa}, "franc", "GN", "RG", false, "centime", 9.64, -13.58, []string{"Guinea-Bissau", "Ivory Coast", "Liberia", "Mali", "Senegal", "Sierra Leone"}},
+	{78, "United Kingdom", "London", []string{europe}, "pound", "GB", "UK", false, "penny", 51.51, -0.13, []string{"Ireland"}},
+	{79, "Uganda", "Kampala", []string{africa}, "shilling", "UG", "EAU", true, "", 0.35, 32.58, []string{"Democratic Republic of Congo", "Kenya", "Rwanda", "South Sudan", "Tanzania"}},
+	{80, "Ghana", "Accra", []string{africa}, "cedi", "GH", "GH", false, "pesewa", 5.6, -0.19, []string{"Burkina Faso", "Ivory Coast", "Togo"}},
+	{81, "Romania", "Bucharest", []string{europe}, "leu", "RO", "RO", false, "", 44.43, 26.1, []string{"Bulgaria", "Hungary", "Moldova", "Serbia", "Ukraine"}},
+	{82, "Laos", "Vientiane", []string{southeast_asia}, "kip", "LA", "LAO", true, "", 17.98, 102.63, []string{"Cambodia", "China", "Myanmar", "Thailand", "Viet Nam"}},
+	{83, "Guyana", "Georgetown", []string{south_america}, "dollar", "GY", "GUY", false, "", 6.8, -58.16, []string{"Brazil", "Suriname", "Venezuela"}},
+	{84, "Belarus", "Minsk", []string{europe}, "ruble", "BY", "BY", true, "", 53.9, 27.57, []string{"Latvia", "Lithuania", "Poland", "Russia", "Ukraine"}},
+	{85, "Kyrgyzstan", "Bishkek", []string{asia}, "som", "KG", "KG", true, "", 42.87, 74.59, []string{"China", "Kazakhstan", "Tajikistan", "Uzbekistan"}},
+	{86, "Senegal", "Dakar", []string{africa}, "franc", "SN", "SN", false, "", 14.72, -17.47, []string{"Guinea", "Guinea-Bissau", "Mali", "Mauritania", "The Gambia"}},
+	{87, "Syria", "Damascus", []string{middle_east}, "pound", "SY", "SYR", false, "", 33.51, 36.29, []string{"Iraq", "Israel", "Jordan", "Lebanon", "Turkey"}},
+	{88, "Cambodia", "Phnom Penh", []string{southeast_asia}, "riel", "KH", "K", false, "", 11.56, 104.93, []string{"Laos", "Thailand", "Viet Nam"}},
+	{89, "Uruguay", "Montevideo", []string{south_america}, "peso", "UY", "UY", false, "", -34.9, -56.16, []string{"Argentina", "Brazil"}},
+	{90, "Suriname", "Paramaribo", []string{south_america}, "dollar", "SR", "SME", false, "", 5.85, -55.2, []string{"Brazil", "Guyana"}},
+	{91, "Tunisia", "Tunis", []string{africa}, "dinar", "TN", "TN", false, "", 36.81, 10.18, []string{"Algeria", "Libya"}},
+	{92, "Bangladesh", "Dhaka", []string{south_asia}, "taka", "BD", "BD", false, "", 23.81, 90.41, []string{"India", "Myanmar"}},
+	{93, "Nepal", "Kathmandu", []string{south_asia}, "rupee", "NP", "NEP", true, "", 27.72, 85.32, []string{"China", "India"}},
+	{94, "Tajikistan", "Dusharbe", []string{asia}, "somoni", "TJ", "TJ", true, "", 38.56, 68.77, []string{"Afghanistan", "China", "Kyrgyzstan", "Uzbekistan"}},
+	{95, "Greece", "Athens", []string{europe}, "euro", "GR", "GR", false, "", 37.98, 23.73, []string{"Albania", "Bulgaria", "North Macedonia", "Turkey"}},
+	{96, "Nicaragua", "Managua", []string{central_america}, "cordoba", "NI", "NIC", false, "", 12.11, -86.24, []string{"Costa Rica", "Honduras"}},
+	{97, "North Korea", "Pyongyang", []string{asia}, "won", "KP", "", false, "", 39.04, 125.76, []string{"China", "Russia", "South Korea"}},
+	{98, "Malawi", "Lilongwe", []string{africa}, "kwacha", "MW", "MW", true, "", -13.96, 33.79, []string{"Mozambique", "Tanzania", "Zambia"}},
+	{99, "Eritrea", "Asmara", []string{africa}, "nakfa", "ER", "ER", false, "", 15.32, 38.93, []string{"Djibouti", "Ethiopia", "Sudan"}},
+	{100, "Benin", "Porto-Novo", []string{africa}, "franc", "BJ", "DY", false, "", 6.5, 2.6, []string{"Burkina Faso", "Niger", "Nigeria", "Togo"}},
+	{101, "Honduras", "Tegucigalpa", []string{central_america}, "lempira", "HN", "HN", false, "", 14.07, -87.19, []string{"El Salvador", "Guatemala", "Nicaragua"}},
+	{102, "Liberia", "Monrovia", []string{africa}, "dollar", "LR", "LB", false, "", 6.3, -10.8, []string{"Guinea", "Ivory Coast", "Sierra Leone"}},
+	{103, "Bulgaria", "Sofia", []string{europe}, "lev", "BG", "BG", false, "", 42.7, 23.32, []string{"Greece", "North Macedonia", "Romania", "Serbia", "Turkey"}},
+	{104, "Cuba", "Havana", []string{caribbean}, "peso", "CU", "CU", false, "", 23.11, -82.37, nil},
+	{105, "Guatemala", "Guatemala City", []string{central_america}, "quetzal", "GT", "GCA", false, "", 14.63, -90.51, []string{"Belize", "El Salvador", "Honduras", "Mexico"}},
+	{106, "Iceland", "Reykjavik", []string{europe}, "krona", "IS", "IS", false, "", 64.15, -21.94, nil},
+	{107, "South Korea", "Seoul", []string{asia}, "won", "KR", "ROK", false, "", 37.57, 126.98, []string{"North Korea"}},
+	{108, "Hungary", "Budapest", []string{europe}, "forint", "HU", "H", true, "", 47.5, 19.04, []string{"Austria", "Croatia", "Romania", "Serbia", "Slovakia", "Slovenia", "Ukraine"}},
+	{109, "Portugal", "Lisbon", []string{europe}, "euro", "PT", "P", false, "", 38.72, -9.14, []string{"Spain"}},
+	{110, "Jordan", "Amman", []string{middle_east}, "dinar", "JO", "HKJ", false, "", 31.95, 35.93, []string{"Iraq", "Israel", "Saudi Arabia", "Syria"}},
+	{111, "Serbia", "Belgrade", []string{europe}, "dinar", "RS", "SRB", true, "", 44.79, 20.45, []string{"Bosnia and Herzegovina", "Bulgaria", "Croatia", "Hungary", "Montenegro", "North Macedonia", "Romania"}},
+	{112, "Azerbaijan", "Baku", []string{asia, europe}, "manat", "AZ", "AZ", true, "", 40.41, 49.87, []string{"Armenia", "Georgia", "Iran", "Russia", "Turkey"}},
+	{113, "Austria", "Vienna", []string{europe}, "euro", "AT", "A", true, "", 48.21, 16.37, []string{"Czech Republic", "Germany", "Hungary", "Italy", "Liechtenstein", "Slovakia", "Slovenia", "Switzerland"}},
+	{114, "United Arab Emirates", "Abu Dhabi", []string{middle_east}, "dirham", "AE", "UAE", false, "", 24.45, 54.38, []string{"Oman", "Saudi Arabia"}},
+	{115, "Czech Republic", "Prague", []string{europe}, "koruna", "CZ", "CZ", true, "", 50.08, 14.44, []string{"Austria", "Germany", "Poland", "Slovakia"}},
+	{116, "Panama", "Panama City", []string{central_america}, "US dollar", "PA", "PA", false, "", 8.98, -79.52, []string{"Colombia", "Costa Rica"}},
+	{117, "Sierra Leone", "Freetown", []string{africa}, "leone", "SL", "WAL", false, "", 8.48, -13.23, []string{"Guinea", "Liberia"}},
+	{118, "Ireland", "Dublin", []string{europe}, "euro", "IE", "IRL", false, "", 53.35, -6.26, []string{"United Kingdom"}},
+	{119, "Georgia", "Tbilisi", []string{asia, europe}, "lari", "GE", "GE", false, "", 41.72, 44.79, []string{"Armenia", "Azerbaijan", "Russia", "Turkey"}},
+	{120, "Sri Lanka", "Sri Jayawardenepura Kotte", []string{southeast_asia}, "rupee", "LK", "CL", false, "", 6.89, 79.9, nil},
+	{121, "Lithuania", "Vilnius", []string{europe}, "euro", "LT", "LT", false, "", 54.69, 25.28, []string{"Belarus", "Latvia", "Poland", "Russia"}},
+	{122, "Latvia", "Riga", []string{europe}, "euro", "LV", "LV", false, "", 56.95, 24.11, []string{"Belarus", "Estonia", "Lithuania", "Russia"}},
+	{123, "Togo", "Lome", []string{africa}, "franc", "TG", "TG", false, "", 6.13, 1.22, []string{"Benin", "Burkina Faso", "Ghana"}},
+	{124, "Croatia", "Zagreb", []string{europe}, "kona", "HR", "HR", false, "", 45.81, 15.98, []string{"Bosnia and Herzegovina", "Hungary", "Montenegro", "Serbia", "Slovenia"}},
+	{125, "Bosnia and Herzegovina", "Sarajevo", []string{europe}, "mark", "BA", "BIH", false, "", 43.86, 18.41, []string{"Croatia", "Montenegro", "Serbia"}},
+	{126, "Costa Rica", "San Jose", []string{central_america}, "colon", "CR", "CR", false, "", 9.93, -84.08, []string{"Nicaragua", "Panama"}},
+	{127, "Slovakia", "Bratislava", []string{europe}, "euro", "SK", "SK", true, "", 48.15, 17.11, []string{"Austria", "Czech Republic", "Hungary", "Poland", "Ukraine"}},
+	{128, "Dominican Republic", "Santo Domingo", []string{caribbean}, "peso", "DO", "DOM", false, "", 18.49, -69.93, []string{"Haiti"}},
+	{129, "Estonia", "Tallinn", []string{europe}, "kroon", "EE", "EST", false, "", 59.44, 24.75, []string{"Latvia", "Russia"}},
+	{130, "Denmark", "Copenhagen", []string{europe}, "krone", "DK", "DK", false, "", 55.68, 12.57, []string{"Germany"}},
+	{131, "Netherlands", "Amsterdam", []string{europe}, "euro", "NL", "NL", false, "", 52.37, 4.9, []string{"Belgium", "Germany"}},
+	{132, "Switzerland", "Bern", []string{europe}, "Swiss franc", "CH", "CH", true, "", 46.95, 7.45, []string{"Austria", "France", "Germany", "Italy", "Liechtenstein"}},
+	{133, "Bhutan", "Thimphu", []string{south_asia}, "ngultrum", "BT", "BHT", true, "", 27.47, 89.64, []string{"China", "India"}},
+	{134, "Guinea-Bissau", "Bissau", []string{africa}, "franc", "GW", "RGB", false, "", 11.86, -15.6, []string{"Guinea", "Senegal"}},
+	{135, "Moldova", "Kishinev", []string{europe}, "leu", "MD", "MD", true, "", 47.01, 28.86, []string{"Romania", "Ukraine"}},
+	{136, "Belgium", "Brussels", []string{europe}, "euro", "BE", "B", false, "", 50.85, 4.35, []string{"France", "Germany", "Luxembourg", "Netherlands"}},
+	{137, "Lesotho", "Maseru", []string{africa}, "loti", "LS", "LS", true, "", -29.31, 27.48, []string{"South Africa"}},
+	{138, "Armenia", "Yerevan", []string{asia}, "dram", "AM", "AM", true, "", 40.18, 44.51, []string{"Azerbaijan", "Georgia", "Iran", "Turkey"}},
+	{139, "Solomon Islands", "Honiara", []string{oceania}, "dollar", "SB", "SOL", false, "", -9.43, 159.95, nil},
+	{140, "Albania", "Tirana", []string{europe}, "lek", "AL", "AL", false, "", 41.33, 19.82, []string{"Greece", "Montenegro", "North Macedonia"}},
+	{141, "Equatorial Guinea", "Malabo", []string{africa}, "franc", "GQ", "", false, "", 3.75, 8.78, []string{"Cameroon", "Gabon"}},
+	{142, "Burundi", "Gitega", []string{africa}, "franc", "BI", "RU", true, "", -3.43, 29.93, []string{"Democratic Republic of Congo", "Rwanda", "Tanzania"}},
+	{143, "Haiti", "Port-au-Prince", []string{caribbean}, "gourde", "HT", "RH", false, "", 18.59, -72.31, []string{"Dominican Republic"}},
+	{144, "Rwanda", "Kigali", []string{africa}, "franc", "RW", "RWA", true, "", -1.94, 30.06, []string{"Burundi", "Democratic Republic of Congo", "Tanzania", "Uganda"}},
+	{145, "North Macedonia", "Skopje", []string{europe}, "denar", "MK", "NMK", true, "", 42.0, 21.43, []string{"Albania", "Bulgaria", "Greece", "Serbia"}},
+	{146, "Djibouti", "Djibouti", []string{africa}, "franc", "DJ", "", false, "", 11.59, 43.15, []string{"Eritrea", "Ethiopia", "Somalia"}},
+	{147, "Belize", "Belmopan", []string{central_america}, "dollar", "BZ", "BH", false, "", 17.25, -88.77, []string{"Guatemala", "Mexico"}},
+	{148, "El Salvador", "San Salvador", []string{central_america}, "US dollar", "SV", "ES", false, "", 13.69, -89.22, []string{"Guatemala", "Honduras"}},
+	{149, "Israel", "Jerusalem", []string{middle_east}, "shekel", "IL", "IL", false, "", 31.77, 35.21, []string{"Egypt", "Jordan", "Lebanon", "Syria"}},
+	{150, "Slovenia", "Ljubljana", []string{europe}, "euro", "SI", "SLO", false, "", 46.06, 14.51, []string{"Austria", "Croatia", "Hungary", "Italy"}},
+	{151, "Fiji", "Suva", []string{oceania}, "dollar", "FJ", "FJI", false, "", -18.14, 178.44, nil},
+	{152, "Kuwait", "Kuwait City", []string{middle_east}, "dinar", "KW", "KWT", false, "", 29.38, 47.98, []string{"Iraq", "Saudi Arabia"}},
+	{153, "Eswatini", "Mbabane", []string{africa}, "rand", "SZ", "SD", true, "", -26.31, 31.14, []string{"Mozambique", "South Africa"}},
+	{154, "East Timor", "Dili", []string{southeast_asia}, "timor-leste", "TL", "TL", false, "", -8.56, 125.56, []string{"Indonesia"}},
+	{155, "The Bahamas", "Nassau", []string{caribbean}, "dollar", "BS", "BS", false, "", 25.05, -77.34, nil},
+	{156, "Montenegro", "Podgorica", []string{europe}, "euro", "ME", "MNE", false, "", 42.43, 19.26, []string{"Albania", "Bosnia and Herzegovina", "Croatia", "Serbia"}},
+	{157, "Vanuatu", "Port Vila", []string{oceania}, "vatu", "VU", "VU", false, "", -17.73, 168.32, nil},
+	{158, "Qatar", "Doha", []string{middle_east}, "rial", "QA", "Q", false, "", 25.29, 51.53, []string{"Saudi Arabia"}},
+	{159, "The Gambia", "Banjul", []string{africa}, "dalasi", "GM", "WAG", false, "", 13.45, -16.58, []string{"Senegal"}},
+	{160, "Jamaica", "Kingston", []string{caribbean}, "dollar", "JM", "JA", false, "", 18.02, -76.8, nil},
+	{161, "Lebanon", "Beirut", []string{middle_east}, "pound", "LB", "RL", false, "", 33.89, 35.5, []string{"Israel", "Syria"}},
+	{162, "Cyprus", "Nicosia", []string{europe, middle_east}, "euro", "CY", "CY", false, "", 35.19, 33.38, nil},
+	{163, "Brunei", "Bandar Seri Begawan", []string{south_asia}, "dollar", "BN", "BRU", false, "", 4.9, 114.94, []string{"Malaysia"}},
+	{164, "Trinidad and Tobago", "Port of Spain", []string{caribbean}, "dollar", "TT", "TT", false, "", 10.66, -61.51, nil},
+	{165, "Cape Verde", "Praia", []string{africa}, "escudo", "CV", "CV", false, "", 14.93, -23.51, nil},
+	{166, "Samoa", "Apia", []string{oceania}, "tala", "WS", "WS", false, "", -13.83, -171.77, nil},
+	{167, "Luxembourg", "Luxembourg City", []string{europe}, "euro", "LU", "L", true, "", 49.61, 6.13, []string{"Belgium", "France", "Germany"}},
+	{168, "Mauritius", "Port Louis", []string{africa}, "rupee", "MU", "MS", false, "", -20.16, 57.5, nil},
+	{169, "Comoros", "Moroni", []string{africa}, "franc", "KM", "COM", false, "", -11.7, 43.26, nil},
+	{170, "Sao Tome and Principe", "Sao Tome", []string{africa}, "dobra", "ST", "STP", false, "", 0.34, 6.73, nil},
+	{171, "Kiribati", "South Tarawa", []string{oceania}, "Australian dollar", "KI", "KIR", false, "", 1.33, 172.98, nil},
+	{172, "Bahrain", "Manama", []string{middle_east}, "dinar", "BH", "BRN", false, "", 26.23, 50.59, nil},
+	{173, "Dominica", "Roseau", []string{caribbean}, "Eastern Caribbean dollar", "DM", "WD", false, "", 15.3, -61.39, nil},
+	{174, "Tonga", "Nuku'alofa", []string{oceania}, "pa'anga", "TO", "TO", false, "", -21.14, -175.2, nil},
+	{175, "Singapore", "Singapore", []string{southeast_asia}, "dollar", "SG", "SGP", false, "", 1.35, 103.82, nil},
+	{176, "Federated States of Micronesia", "Palikir", []string{oceania}, "US dollar", "FM", "FSM", false, "", 6.92, 158.16, nil},
+	{177, "Saint Lucia", "Castries", []string{caribbean}, "Eastern Caribbean dollar", "LC", "WL", false, "", 14.01, -60.99, nil},
+	{178, "Andorra", "Andorra la Vella", []string{europe}, "euro", "AD", "AND", true, "", 42.51, 1.52, []string{"France", "Spain"}},
+	{179, "Palau", "Ngerulmud", []string{oceania}, "US dollar", "PW", "PAL", false, "", 7.5, 134.62, nil},
+	{180, "Seychelles", "Victoria", []string{africa}, "rupee", "SC", "SY", false, "", -4.62, 55.45, nil},
+	{181, "Antigua and Barbuda", "St. John's", []string{caribbean}, "Eastern Caribbean dollar", "AG", "AG", false, "", 17.12, -61.85, nil},
+	{182, "Barbados", "Bridgetown", []string{caribbean}, "dollar", "BB", "BDS", false, "", 13.1, -59.62, nil},
+	{183, "Saint Vincent and the Grenadines", "Kingstown", []string{caribbean}, "Eastern Caribbean dollar", "VC", "WV", false, "", 13.16, -61.22, nil},
+	{184, "Grenada", "St. George's", []string{caribbean}, "Eastern Caribbean dollar", "GD", "WG", false, "", 12.06, -61.75, nil},
+	{185, "Malta", "Valletta", []string{europe}, "euro", "MT", "M", false, "", 35.9, 14.51, nil},
+	{186, "Maldives", "Male", []string{south_asia}, "rufiyaa", "MV", "MV", false, "", 4.18, 73.51, nil},
+	{187, "Saint Kitts and Nevis", "Basseterre", []string{caribbean}, "Eastern Caribbean dollar", "KN", "KAN", false, "", 17.3, -62.72, nil},
+	{188, "Marshall Islands", "Majuro", []string{oceania}, "US dollar", "MH", "MH", false, "", 7.09, 171.38, nil},
+	{189, "Liechtenstein", "Vaduz", []string{europe}, "Swiss franc", "LI", "FL", true, "", 47.14, 9.52, []string{"Austria", "Switzerland"}},
+	{190, "San Marino", "San Marino", []string{europe}, "euro", "SM", "RSM", true, "", 43.94, 12.45, []string{"Italy"}},
+	{191, "Tuvalu", "Funafuti", []string{oceania}, "US dollar", "TV", "TUV", false, "", -8.52, 179.2, nil},
+	{192, "Nauru", "Yaren", []string{oceania}, "Australian dollar", "NR", "NAU", false, "", -0.55, 166.92, nil},
+	{193, "Monaco", "Monaco", []string{europe}, "euro", "MC", "MC", false, "", 43.74, 7.42, []string{"France"}},
+	{194, "Vatican City", "Vatican City", []string{europe}, "euro", "VA", "V", true, "", 41.9, 12.45, []string{"Italy"}},
 }
 
 type countryQuery func([]countryInfo) promptAndResponse
 
-func quizCountries(cmd *cobra.Command, args []string) {
+func countryQuestions() []questionGenerator {
 	quizFuncs := []countryQuery{
 		// add a bunch of these to bias the randomizer
 		crossQueryCountryInfo,
@@ -271,76 +278,106 @@ func quizCountries(cmd *cobra.Command, args []string) {
 		crossQueryCountryInfo,
 		quizWhichIsBigger,
 		quizWhichIsSmaller,
+		quizRankCountries,
+		quizNameAllCountries,
 		quizCountryFromFlag,
 		quizCountryLandlocked,
+		quizCapitalFurther,
+		quizNearestCapital,
+		quizCapitalOnMap,
+		quizCountryNeighbors,
+		quizCountryBordersCountry,
+		quizCountriesShareBorder,
+		quizCountryBordersBoth,
+		quizCountryBorderPath,
 	}
-	function := randomItemFromSlice(quizFuncs)
-	promptAndCheckResponse(function(countries))
-
+	return generatorsFor(quizFuncs, countries)
 }
 
 func crossQueryCountryInfo(countries []countryInfo) promptAndResponse {
-	country := randomItemFromSlice(countries)
-	return constructCrossQuery("country", country)
+	return constructCrossQueryFromSlice("country", countries)
 }
 
 func quizWhichIsBigger(countries []countryInfo) promptAndResponse {
-	country1 := randomItemFromSlice(countries)
-	country2 := randomItemFromSlice(countries)
-
-	for country1.name == country2.name {
-		country2 = randomItemFromSlice(countries)
-	}
-
-	response := promptAndResponse{fmt.Sprintf("Which country is bigger: %s or %s?", country1.name, country2.name), ""}
-	// rank is inverse to size: 1 means the biggest country, and 50 is bigger than 70
-	if country1.rankInArea < country2.rankInArea {
-		response.response = country1.name
-	} else {
-		response.response = country2.name
-	}
-	return response
+	return quizCompareRanked("country", countries, true)
 }
 
 func quizWhichIsSmaller(countries []countryInfo) promptAndResponse {
-	country1 := randomItemFromSlice(countries)
-	country2 := randomItemFromSlice(countries)
-
-	for country1.name == country2.name {
-		country2 = randomItemFromSlice(countries)
-	}
+	return quizCompareRanked("country", countries, false)
+}
 
-	response := promptAndResponse{fmt.Sprintf("Which country is smaller: %s or %s?", country1.name, country2.name), ""}
-	// rank is inverse to size: 1 means the biggest country, and 50 is bigger than 70
-	if country1.rankInArea > country2.rankInArea {
-		response.response = country1.name
-	} else {
-		response.response = country2.name
-	}
-	return response
+func quizRankCountries(countries []countryInfo) promptAndResponse {
+	return quizRanked("country", countries)
 }
 
 func quizCountryFromFlag(countries []countryInfo) promptAndResponse {
 	country := randomItemFromSlice(countries)
-	return promptAndResponse{fmt.Sprintf("Which country has this flag: %s", country.flagEmoji()), country.name}
+	return promptAndResponse{prompt: fmt.Sprintf("Which country has this flag: %s", country.flagEmoji()), response: country.name}
 }
 
 func quizCountryLandlocked(countries []countryInfo) promptAndResponse {
 	country := randomItemFromSlice(countries)
-	return promptAndResponse{fmt.Sprintf("%s is landlocked: true or false?", country.name),
-		strconv.FormatBool(country.landlocked)}
+	return promptAndResponse{prompt: fmt.Sprintf("%s is landlocked: true or false?", country.name), response: strconv.FormatBool(country.landlocked)}
+
 }
 
-func init() {
-	memoryquizCmd.AddCommand(countriesCmd)
+func quizNameAllCountries(countries []countryInfo) promptAndResponse {
+	return quizNameAll("country", countries)
+}
+
+func quizCapitalFurther(countries []countryInfo) promptAndResponse {
+	return quizFurther("capital", geoPlaces(countries))
+}
 
-	// Here you will define your flags and configuration settings.
+func quizNearestCapital(countries []countryInfo) promptAndResponse {
+	capitals := geoPlaces(countries)
+	return quizNearest(capitals, "capital", capitals)
+}
 
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// countriesCmd.PersistentFlags().String("foo", "", "A help for foo")
+func quizCapitalOnMap(countries []countryInfo) promptAndResponse {
+	return quizMarkedOnMap("capital", geoPlaces(countries), worldMap)
+}
+
+// countryPlaces are the countries, by name, at their capitals
+func countryPlaces(countries []countryInfo) []geoPlace {
+	places := geoPlaces(countries)
+	for index, country := range countries {
+		places[index].names = []string{country.name}
+	}
+	return places
+}
+
+func validateCountryBorders() []datasetProblem {
+	return bordersOf(countries).problems()
+}
+
+func quizCountryNeighbors(countries []countryInfo) promptAndResponse {
+	return quizNeighbors("country", bordersOf(countries))
+}
+
+// quizCountryBordersCountry offers the countries nearest to one as the ones that don't border it
+func quizCountryBordersCountry(countries []countryInfo) promptAndResponse {
+	bordered := make([]countryInfo, 0, len(countries))
+	for _, country := range countries {
+		if len(country.neighbors) > 0 {
+			bordered = append(bordered, country)
+		}
+	}
+	if len(bordered) == 0 {
+		return quizCountriesShareBorder(countries)
+	}
+	country := randomItemFromSlice(bordered)
+	return quizWhichBorders("country", country.name, geoPoint{country.latitude, country.longitude}, country.neighbors, countryPlaces(countries))
+}
+
+func quizCountriesShareBorder(countries []countryInfo) promptAndResponse {
+	return quizShareBorder(bordersOf(countries))
+}
+
+func quizCountryBordersBoth(countries []countryInfo) promptAndResponse {
+	return quizBordersBoth("country", bordersOf(countries))
+}
 
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// countriesCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+func quizCountryBorderPath(countries []countryInfo) promptAndResponse {
+	return quizBorderPath("countries", bordersOf(countries))
 }
@@ -15,16 +15,16 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
 // cranialnervesCmd represents the cranialnerves command
-var cranialnervesCmd = &cobra.Command{
-	Use:   "cranialnerves",
-	Short: "Quiz about the cranial nerves",
-	Run:   quizCranialNerves,
-}
+var cranialnervesCmd = registerQuizArea(quizArea{
+	name:       "cranialnerves",
+	aliases:    []string{"cranial-nerves"},
+	tags:       []string{"science"},
+	short:      "Quiz about the cranial nerves",
+	generators: cranialNerveQuestions,
+	dataset:    &cranialNerves,
+	ordinal:    "cranial nerve",
+})
 
 var cranialNerves = []string{
 	"olfactory",
@@ -43,14 +43,13 @@ var cranialNerves = []string{
 
 type quizNerveFunc func([]string) promptAndResponse
 
-func quizCranialNerves(cmd *cobra.Command, args []string) {
+func cranialNerveQuestions() []questionGenerator {
 	nerveQuizzes := []quizNerveFunc{
 		quizCranialNerveByIndex,
 		quizCranialNerveIndexFromName,
 	}
 
-	quizFunction := randomItemFromSlice(nerveQuizzes)
-	promptAndCheckResponse(quizFunction(cranialNerves))
+	return generatorsFor(nerveQuizzes, cranialNerves)
 }
 
 func quizCranialNerveByIndex(nerves []string) promptAndResponse {
@@ -60,7 +59,3 @@ func quizCranialNerveByIndex(nerves []string) promptAndResponse {
 func quizCranialNerveIndexFromName(nerves []string) promptAndResponse {
 	return quizIndexOfStringInList(nerves)
 }
-
-func init() {
-	memoryquizCmd.AddCommand(cranialnervesCmd)
-}
@@ -18,9 +18,9 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 // Tools for making "cross queries" on objects used by memory quiz.
@@ -32,64 +32,306 @@ import (
 //   name string `crossquery:"all"`
 //   currency string `crossquery:"guess"` -- because so many countries use the same currency name, this can't be a given "What country has a currency of franc"
 //   capital string `crossquery:""`
+//   fractionalCurrency string `crossquery:"guess" crossqueryoptional:"true"` -- blank for countries without one, which validate accepts
 // }
 // currently only supports strings and ints
 
+// crossQueryField is one field of an entity as the cross query logic sees it, whether it
+// came from a struct or from somewhere else, such as a user's deck file
+type crossQueryField struct {
+	// name is the human readable name used in the prompt
+	name string
+	// role is the crossquery value: "" or "all", "given", or "guess"
+	role string
+	// values holds one value, or several for a multi-valued field like firstLadies
+	values []string
+	// multiValued is set for fields that can hold several values, even if this one has only one
+	multiValued bool
+	// ambiguous holds values that other entities share, which can't be used as the given
+	ambiguous []string
+	// aliases are other answers accepted when this field is the one to guess
+	aliases []string
+	// optional is set for fields that are blank on purpose for some entities, which validate
+	// doesn't warn about
+	optional bool
+}
+
+// givenValues returns the values that identify the entity, and so can be used as the given
+func (field crossQueryField) givenValues() []string {
+	values := make([]string, 0, len(field.values))
+	for _, value := range field.values {
+		if !isStringInSlice(value, field.ambiguous) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// allOfChance is how often (1 in n) a question about a multi-valued field asks for all the values
+const allOfChance = 3
+
+type crossQueryAmbiguity string
+
+const (
+	// skipAmbiguous avoids givens that other entities share, unless there's no other way to ask about the entity
+	skipAmbiguous crossQueryAmbiguity = "skip"
+	// acceptAnyMatch asks about a shared given as "a country with ..." and accepts the answer for any of them
+	acceptAnyMatch crossQueryAmbiguity = "any"
+)
+
+// ambiguityPolicy says what constructCrossQueryFromSlice does with givens that don't identify one entity
+var ambiguityPolicy = skipAmbiguous
+
+// String, Set, and Type let the policy be a flag
+func (policy *crossQueryAmbiguity) String() string {
+	return string(*policy)
+}
+
+func (policy *crossQueryAmbiguity) Set(value string) error {
+	switch crossQueryAmbiguity(value) {
+	case skipAmbiguous, acceptAnyMatch:
+		*policy = crossQueryAmbiguity(value)
+		return nil
+	}
+	return fmt.Errorf("must be %s or %s", skipAmbiguous, acceptAnyMatch)
+}
+
+func (policy *crossQueryAmbiguity) Type() string {
+	return "policy"
+}
+
+// answerAliaser is implemented by entities with other acceptable answers for a field,
+// like a royal's name with their sobriquet. fieldName is the struct field's name.
+type answerAliaser interface {
+	answerAliases(fieldName string) []string
+}
+
 func constructCrossQuery(entityType string, entity interface{}) promptAndResponse {
-	// the fields we could use as the "given" in the prompt. e.g., the country's name is Algeria
-	// these are fields annotated with crossquery:all or crossquery:given
-	givens := []reflect.StructField{}
-	// the fields we could use as things to guess in the prompt. e.g., the currency
-	// these are fields annotated with crossquery:all or crossquery:given
-	guesses := []reflect.StructField{}
+	return constructCrossQueryFromFields(entityType, crossQueryFields(entity))
+}
+
+// constructCrossQueryFromSlice asks about a random entity from entities. Unlike constructCrossQuery,
+// it can see the other entities, so it can tell when a given value doesn't identify one entity
+// (asking for the currency of the country with fractional currency of cent, for instance).
+// See ambiguityPolicy for what it does about that.
+func constructCrossQueryFromSlice[S ~[]E, E any](entityType string, entities S) promptAndResponse {
+	entityFields := make([][]crossQueryField, 0, len(entities))
+	for _, entity := range entities {
+		entityFields = append(entityFields, crossQueryFields(entity))
+	}
+	return constructCrossQueryAmong(entityType, entityFields)
+}
+
+// constructCrossQueryAmong asks about a random entity, given the fields of every entity.
+// The same field of the other entities supplies the distractors for multiple choice.
+func constructCrossQueryAmong(entityType string, entityFields [][]crossQueryField) promptAndResponse {
+	chosen := quizRand.Intn(len(entityFields))
+	fields := askableFields(entityFields, chosen)
+	pick := pickCrossQuery(entityType, fields)
+	matches := make([][]crossQueryField, 0)
+	distractors := make([]string, 0, len(entityFields))
+	for entityIndex, other := range entityFields {
+		if entityIndex == chosen {
+			continue
+		}
+		if isStringInSlice(pick.givenValue, other[pick.given].values) {
+			matches = append(matches, other)
+		} else {
+			distractors = append(distractors, other[pick.guess].values...)
+		}
+	}
+
+	question := pick.question(entityType, fields, matches)
+	question.distractors = distractors
+	return question
+}
+
+// askableFields returns the fields of the chosen entity with the values that can't be used as
+// givens marked as ambiguous, following the ambiguity policy
+func askableFields(entityFields [][]crossQueryField, chosen int) []crossQueryField {
+	fields := markAmbiguousValues(entityFields, chosen)
+	if ambiguityPolicy == acceptAnyMatch || !canCrossQuery(fields) {
+		// ask about a shared value and accept the answer for any entity that has it. Shared values of
+		// multi-valued fields are still out, since they're one of many ways to identify the entity.
+		for index := range fields {
+			if !fields[index].multiValued {
+				fields[index].ambiguous = nil
+			}
+		}
+	}
+	return fields
+}
+
+// markAmbiguousValues returns the fields of the chosen entity with the values that other
+// entities share marked as ambiguous. Every entity must have the same fields.
+func markAmbiguousValues(entityFields [][]crossQueryField, chosen int) []crossQueryField {
+	fields := make([]crossQueryField, len(entityFields[chosen]))
+	copy(fields, entityFields[chosen])
+	for fieldIndex := range fields {
+		field := &fields[fieldIndex]
+		field.ambiguous = nil
+		for _, value := range field.values {
+			for entityIndex, other := range entityFields {
+				if entityIndex != chosen && isStringInSlice(value, other[fieldIndex].values) {
+					field.ambiguous = append(field.ambiguous, value)
+					break
+				}
+			}
+		}
+	}
+	return fields
+}
+
+// canCrossQuery reports whether there's a given and a different guess with values to ask about
+func canCrossQuery(fields []crossQueryField) bool {
+	for _, given := range fields {
+		if len(given.givenValues()) == 0 || given.role == "guess" {
+			continue
+		}
+		for _, guess := range fields {
+			if len(guess.values) > 0 && guess.role != "given" && guess.name != given.name {
+				return true
+			}
+		}
+	}
+	return false
+}
 
+// crossQueryFields returns the fields of entity that have a crossquery tag
+func crossQueryFields(entity interface{}) []crossQueryField {
+	fields := []crossQueryField{}
 	reflectEntity := reflect.ValueOf(entity)
 	reflectStruct := reflect.TypeOf(entity)
+	aliaser, hasAliases := entity.(answerAliaser)
 	for i := 0; i < reflectStruct.NumField(); i++ {
 		field := reflectStruct.Field(i)
 		if crossQuery, ok := field.Tag.Lookup("crossquery"); ok {
-			// if this is a crossquery field but the value of the field is empty, skip
-			// this will usually be because not all the values for the given category's field have
-			// been set up yet, as when we add something like "ivr code" to countries and don't
-			// fill them all in right away.
-			if reflectValueToString(reflectEntity.FieldByName(field.Name)) == "" {
-				continue
+			queryField := crossQueryField{
+				name:        humanReadableFieldName(field),
+				role:        crossQuery,
+				values:      reflectValueToStrings(reflectEntity.Field(i)),
+				multiValued: field.Type.Kind() == reflect.Slice,
+				optional:    field.Tag.Get("crossqueryoptional") == "true",
 			}
+			if hasAliases {
+				queryField.aliases = aliaser.answerAliases(field.Name)
+			}
+			fields = append(fields, queryField)
+		}
+	}
+	return fields
+}
+
+func constructCrossQueryFromFields(entityType string, fields []crossQueryField) promptAndResponse {
+	return pickCrossQuery(entityType, fields).question(entityType, fields, nil)
+}
+
+// crossQueryPick is the field to give, its value, and the field to guess
+type crossQueryPick struct {
+	given      int
+	givenValue string
+	guess      int
+}
+
+// pickCrossQuery chooses the given and guess for a question, indexes into fields
+func pickCrossQuery(entityType string, fields []crossQueryField) crossQueryPick {
+	// the fields we could use as the "given" in the prompt. e.g., the country's name is Algeria
+	// these are fields annotated with crossquery:all or crossquery:given
+	givens := []int{}
+	// the fields we could use as things to guess in the prompt. e.g., the currency
+	// these are fields annotated with crossquery:all or crossquery:guess
+	guesses := []int{}
 
-			if crossQuery == "" || crossQuery == "all" {
-				givens = append(givens, field)
-				guesses = append(guesses, field)
-			} else if crossQuery == "given" {
-				givens = append(givens, field)
-			} else if crossQuery == "guess" {
-				guesses = append(guesses, field)
-			} else {
-				// this is effectively a syntax error, so kill the program
-				panic(fmt.Sprintf("Invalid value for crossquery: %s", crossQuery))
+	for index, field := range fields {
+		// if this is a crossquery field but the value of the field is empty, skip
+		// this will usually be because not all the values for the given category's field have
+		// been set up yet, as when we add something like "ivr code" to countries and don't
+		// fill them all in right away.
+		if len(field.values) == 0 {
+			continue
+		}
+
+		canBeGiven := len(field.givenValues()) > 0
+		if field.role == "" || field.role == "all" {
+			if canBeGiven {
+				givens = append(givens, index)
+			}
+			guesses = append(guesses, index)
+		} else if field.role == "given" {
+			if canBeGiven {
+				givens = append(givens, index)
 			}
+		} else if field.role == "guess" {
+			guesses = append(guesses, index)
+		} else {
+			// this is effectively a syntax error, so kill the program
+			panic(fmt.Sprintf("Invalid value for crossquery: %s", field.role))
 		}
 	}
 
-	if len(givens) == 0 || len(guesses) == 0 {
-		panic(fmt.Sprintf("No givens or guesses for %v", entity))
+	if len(givens) == 0 || len(guesses) == 0 || (len(givens) == 1 && len(guesses) == 1 && givens[0] == guesses[0]) {
+		panic(fmt.Sprintf("No givens or guesses for %s %v", entityType, fields))
 	}
 
 	//get a given and figure out a non-equal guess
-	given := givens[rand.Intn(len(givens))]
-	guess := guesses[rand.Intn(len(guesses))]
-	for given.Name == guess.Name {
-		guess = guesses[rand.Intn(len(guesses))]
+	given := givens[quizRand.Intn(len(givens))]
+	guess := guesses[quizRand.Intn(len(guesses))]
+	for given == guess {
+		given = givens[quizRand.Intn(len(givens))]
+		guess = guesses[quizRand.Intn(len(guesses))]
+	}
+
+	// multi-valued fields use one of their values as the given
+	return crossQueryPick{given, randomItemFromSlice(fields[given].givenValues()), guess}
+}
+
+// question builds the prompt for the pick. matches are the fields of other entities that share
+// the given value, whose answers are also right.
+func (pick crossQueryPick) question(entityType string, fields []crossQueryField, matches [][]crossQueryField) promptAndResponse {
+	given := fields[pick.given]
+	guess := fields[pick.guess]
+
+	// when the guess has several values, any of them is right, or sometimes the question asks for all of them
+	if len(guess.values) > 1 && len(matches) == 0 && quizRand.Intn(allOfChance) == 0 {
+		return promptAndResponse{
+			prompt:   fmt.Sprintf("Name every %s of the %s with %s of %v (separate them with commas)", guess.name, entityType, given.name, pick.givenValue),
+			response: strings.Join(guess.values, ", "),
+			allOf:    guess.values,
+		}
 	}
-	givenHumanReadable := humanReadableFieldName(given)
-	guessHumanReadable := humanReadableFieldName(guess)
 
-	givenValueRaw := reflectEntity.FieldByName(given.Name)
-	guessValueRaw := reflectEntity.FieldByName(guess.Name)
+	aliases := append([]string{}, guess.values[1:]...)
+	aliases = append(aliases, guess.aliases...)
+	article := "the"
+	if len(matches) > 0 {
+		article = "a"
+		for _, match := range matches {
+			for _, value := range append(match[pick.guess].values, match[pick.guess].aliases...) {
+				if value != guess.values[0] && !isStringInSlice(value, aliases) {
+					aliases = append(aliases, value)
+				}
+			}
+		}
+	}
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("What is the %s of %s %s with %s of %v?", guess.name, article, entityType, given.name, pick.givenValue),
+		response: guess.values[0],
+		aliases:  aliases,
+	}
+}
 
-	// convert to strings if need be
-	givenValue := reflectValueToString(givenValueRaw)
-	guessValue := reflectValueToString(guessValueRaw)
-	return promptAndResponse{fmt.Sprintf("What is the %s of the %s with %s of %v?", guessHumanReadable, entityType, givenHumanReadable, givenValue), guessValue}
+// reflectValueToStrings returns the non-empty values of a field: the one value of a
+// single-valued field or each value of a slice
+func reflectValueToStrings(v reflect.Value) []string {
+	values := []string{}
+	if v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, reflectValueToStrings(v.Index(i))...)
+		}
+	} else if value := reflectValueToString(v); value != "" {
+		values = append(values, value)
+	}
+	return values
 }
 
 func reflectValueToString(v reflect.Value) string {
@@ -98,12 +340,14 @@ func reflectValueToString(v reflect.Value) string {
 		return v.String()
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return strconv.Itoa(int(v.Int()))
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
 	case reflect.Slice:
 		// grab an item at random from the slice. Note that what you get back from Index is a value
 		if v.Len() == 0 {
 			return ""
 		}
-		return reflectValueToString(v.Index(rand.Intn(v.Len())))
+		return reflectValueToString(v.Index(quizRand.Intn(v.Len())))
 	default:
 		return v.String()
 	}
@@ -117,3 +361,7 @@ func humanReadableFieldName(f reflect.StructField) string {
 	}
 	return f.Name
 }
+
+func init() {
+	memoryquizCmd.PersistentFlags().Var(&ambiguityPolicy, "ambiguous", "What to do with questions whose given is shared by several items: skip them, or ask them and accept any match (skip|any)")
+}
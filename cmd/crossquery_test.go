@@ -16,9 +16,8 @@ limitations under the License.
 package cmd
 
 import (
-	"math/rand"
+	"strings"
 	"testing"
-	"time"
 )
 
 // this can only produce one response string
@@ -28,7 +27,7 @@ type crossQuery1 struct {
 }
 
 func TestCrossQueryOneGivenOneGuess(test *testing.T) {
-	rand.Seed(time.Now().UnixNano())
+	seedQuizRand(1)
 	s := crossQuery1{"abc", "def"}
 	result := constructCrossQuery("test1", s)
 	if result.prompt != "What is the value2 of the test1 with value1 of abc?" {
@@ -46,7 +45,7 @@ type crossQuery2 struct {
 }
 
 func TestCrossQueryAll(test *testing.T) {
-	rand.Seed(time.Now().UnixNano())
+	seedQuizRand(1)
 	s := crossQuery2{"xyz", 34}
 	result := constructCrossQuery("test2", s)
 
@@ -84,7 +83,7 @@ type crossQuery4 struct {
 }
 
 func TestCrossQueryHumanReadable(test *testing.T) {
-	rand.Seed(time.Now().UnixNano())
+	seedQuizRand(1)
 	s := crossQuery4{"abc", "def"}
 	result := constructCrossQuery("test1", s)
 	if result.prompt != "What is the new value 2 of the test1 with new value 1 of abc?" {
@@ -110,3 +109,135 @@ func TestSkipEmptyFields(t *testing.T) {
 		t.Errorf("Incorrect prompt. Was \"%s\"", result.prompt)
 	}
 }
+
+type crossQueryMultiValued struct {
+	name    string   `crossquery:"given"`
+	flowers []string `crossquery:"all"`
+}
+
+func TestCrossQueryAcceptsAnyValue(t *testing.T) {
+	entity := crossQueryMultiValued{"Ohio", []string{"Carnation", "Trillium"}}
+	for i := 0; i < 50; i++ {
+		result := constructCrossQueryFromSlice("state", []crossQueryMultiValued{entity})
+		if len(result.allOf) > 0 {
+			if result.prompt != "Name every flowers of the state with name of Ohio (separate them with commas)" || result.response != "Carnation, Trillium" {
+				t.Errorf("Unexpected question for all the values: %s -> %s", result.prompt, result.response)
+			}
+			if !matchAnswer("trillium, carnation", result).correct() {
+				t.Errorf("Expected both flowers in any order to be correct")
+			}
+			continue
+		}
+
+		if result.prompt == "What is the flowers of the state with name of Ohio?" && (!matchAnswer("Carnation", result).correct() || !matchAnswer("Trillium", result).correct()) {
+			t.Errorf("Expected either flower to be correct for %+v", result)
+		}
+	}
+}
+
+func TestCrossQuerySkipsAmbiguousGivens(t *testing.T) {
+	entities := []crossQueryMultiValued{
+		{"Ohio", []string{"Carnation", "Violet"}},
+		{"Illinois", []string{"Violet"}},
+		{"Rhode Island", []string{"Violet"}},
+	}
+	for i := 0; i < 100; i++ {
+		result := constructCrossQueryFromSlice("state", entities)
+		if strings.Contains(result.prompt, "of Violet") {
+			t.Fatalf("Violet is shared by several states but was used as a given: %s", result.prompt)
+		}
+	}
+}
+
+func TestMatchAllAnswers(t *testing.T) {
+	expected := []string{"Hannibal Hamlin", "Andrew Johnson"}
+	cases := map[string]answerMatch{
+		"Andrew Johnson, Hannibal Hamlin":             answerCorrect,
+		"hannibal hamlin,andrew johnson":              answerCorrect,
+		"Hannibal Hamlin":                             answerClose,
+		"Hannibal Hamlin, Andrew Johnson, John Tyler": answerClose,
+		"John Tyler": answerIncorrect,
+		"":           answerIncorrect,
+	}
+	for answer, match := range cases {
+		if actual := matchAllAnswers(answer, expected); actual != match {
+			t.Errorf("Expected %q to match as %v but got %v", answer, match, actual)
+		}
+	}
+}
+
+type crossQueryRegion struct {
+	name    string `crossquery:"all"`
+	region  string `crossquery:"given"`
+	capital string `crossquery:"guess"`
+}
+
+var crossQueryRegions = []crossQueryRegion{
+	{"France", "Europe", "Paris"},
+	{"Germany", "Europe", "Berlin"},
+	{"Japan", "Asia", "Tokyo"},
+}
+
+func TestCrossQuerySkipsSharedGivens(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		result := constructCrossQueryFromSlice("country", crossQueryRegions)
+		if strings.Contains(result.prompt, "of Europe") {
+			t.Fatalf("Europe is shared by two countries but was used as a given: %s", result.prompt)
+		}
+		if strings.Contains(result.prompt, " of a ") {
+			t.Fatalf("Expected no ambiguous questions but got %s", result.prompt)
+		}
+	}
+}
+
+func TestCrossQueryAcceptsAnyMatch(t *testing.T) {
+	defer func(policy crossQueryAmbiguity) { ambiguityPolicy = policy }(ambiguityPolicy)
+	ambiguityPolicy = acceptAnyMatch
+
+	askedAboutEurope := false
+	for i := 0; i < 200; i++ {
+		result := constructCrossQueryFromSlice("country", crossQueryRegions)
+		if result.prompt == "What is the capital of a country with region of Europe?" {
+			askedAboutEurope = true
+			if !matchAnswer("Paris", result).correct() || !matchAnswer("Berlin", result).correct() {
+				t.Errorf("Expected either European capital to be right but got %s and %v", result.response, result.aliases)
+			}
+			if isStringInSlice("Paris", result.distractors) || isStringInSlice("Berlin", result.distractors) {
+				t.Errorf("A right answer was used as a distractor: %v", result.distractors)
+			}
+		}
+	}
+	if !askedAboutEurope {
+		t.Errorf("Expected a question about the shared region of Europe")
+	}
+}
+
+func TestCrossQueryFallsBackToSharedGivens(t *testing.T) {
+	twins := []crossQueryRegion{{"", "Europe", "Paris"}, {"", "Europe", "Berlin"}}
+	result := constructCrossQueryFromSlice("country", twins)
+	if result.prompt != "What is the capital of a country with region of Europe?" {
+		t.Errorf("Expected the shared region to be asked about but got %s", result.prompt)
+	}
+}
+
+func TestAmbiguityPolicyFlag(t *testing.T) {
+	var policy crossQueryAmbiguity
+	if err := policy.Set("any"); err != nil || policy != acceptAnyMatch {
+		t.Errorf("Expected any to set the policy but got %v, %v", policy, err)
+	}
+	if err := policy.Set("sometimes"); err == nil {
+		t.Errorf("Expected an error for an unknown policy")
+	}
+}
+
+type crossQueryFloat struct {
+	name string  `crossquery:"given"`
+	mass float64 `crossquery:"guess"`
+}
+
+func TestCrossQueryFloat(t *testing.T) {
+	result := constructCrossQuery("test", crossQueryFloat{"Iron", 55.845})
+	if result.prompt != "What is the mass of the test with name of Iron?" || result.response != "55.845" {
+		t.Errorf("Unexpected question %q -> %q", result.prompt, result.response)
+	}
+}
@@ -0,0 +1,389 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Quiz data is compiled in, but any dataset can be added to or corrected from files
+// in the datasets directory (--datasets, the "datasets" config key, or $HOME/.derrick_tools/datasets).
+// A file is named after the dataset it changes, e.g. countries.yaml, countries.json, or countries.csv.
+//
+// YAML and JSON files hold either a list of records or a mapping like
+//
+//	replace: false   # true throws away the built-in records
+//	records:
+//	  - name: Kazakhstan
+//	    capital: Astana
+//	  - name: Atlantis
+//	    capital: Poseidonia
+//	    region: [Ocean]
+//
+// Keys are the struct field names or their crossqueryname, in any case. A record whose name matches
+// a built-in record only changes the fields it lists; any other record is added. Datasets that are plain
+// lists, such as shakespeare, take strings (or lists of strings, for spellingbee) as records.
+//
+// CSV files have a header row of field names. Empty cells are skipped, and list fields separate
+// their values with |.
+
+var datasetDir string
+
+// csvListSeparator separates the values of list fields in CSV files
+const csvListSeparator = "|"
+
+// quizDatasets maps a dataset name to a pointer to the slice holding it
+var quizDatasets = make(map[string]interface{})
+
+// registerDataset makes a slice (passed as a pointer) available for loading from files
+func registerDataset(name string, records interface{}) {
+	if reflect.TypeOf(records).Kind() != reflect.Pointer || reflect.TypeOf(records).Elem().Kind() != reflect.Slice {
+		panic(fmt.Sprintf("Dataset %s must be a pointer to a slice", name))
+	}
+	quizDatasets[name] = records
+}
+
+func datasetDirectory() (string, error) {
+	if datasetDir != "" {
+		return datasetDir, nil
+	}
+	if configured := viper.GetString("datasets"); configured != "" {
+		return homedir.Expand(configured)
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".derrick_tools", "datasets"), nil
+}
+
+// datasetLoadProblems are the dataset files that couldn't be loaded. They're kept rather than
+// reported when the program starts, so a bad file only stops the quizzes (see
+// exitOnDatasetLoadProblems) and memoryquiz validate can list them with everything else.
+var datasetLoadProblems []datasetProblem
+
+// loadDatasets applies every dataset file in the datasets directory to the registered datasets
+func loadDatasets() {
+	dir, err := datasetDirectory()
+	if err != nil {
+		datasetLoadProblems = []datasetProblem{{dataset: "datasets", message: err.Error()}}
+		return
+	}
+	datasetLoadProblems = loadDatasetsFromDirectory(dir)
+}
+
+// exitOnDatasetLoadProblems stops a quiz that would otherwise ask about data missing the
+// changes from a dataset file
+func exitOnDatasetLoadProblems() {
+	if len(datasetLoadProblems) == 0 {
+		return
+	}
+	for _, problem := range datasetLoadProblems {
+		fmt.Println(problem.message)
+	}
+	fmt.Println("Run memoryquiz validate to check the datasets")
+	os.Exit(1)
+}
+
+// loadDatasetsFromDirectory applies the files it can and returns a problem for each one it can't
+func loadDatasetsFromDirectory(dir string) []datasetProblem {
+	problems := make([]datasetProblem, 0)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return problems
+	} else if err != nil {
+		return append(problems, datasetProblem{dataset: "datasets", message: err.Error()})
+	}
+
+	// ReadDir sorts by name, so countries.csv is applied before countries.yaml
+	for _, entry := range entries {
+		extension := filepath.Ext(entry.Name())
+		name := strings.TrimSuffix(entry.Name(), extension)
+		target, exists := quizDatasets[name]
+		if entry.IsDir() || !exists {
+			continue
+		}
+
+		if err := loadDatasetFile(filepath.Join(dir, entry.Name()), target); err != nil {
+			problems = append(problems, datasetProblem{dataset: name, message: fmt.Sprintf("Could not load dataset %s: %v", entry.Name(), err)})
+		}
+	}
+	return problems
+}
+
+// loadDatasetFile reads one file and merges it into target, a pointer to a slice
+func loadDatasetFile(fileName string, target interface{}) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var records []interface{}
+	replace := false
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		records, err = readCsvRecords(file)
+	case ".yaml", ".yml", ".json":
+		// JSON is a subset of YAML, so one decoder handles both
+		records, replace, err = readYamlRecords(file)
+	default:
+		return fmt.Errorf("Unsupported file type")
+	}
+	if err != nil {
+		return err
+	}
+	return mergeRecords(target, records, replace)
+}
+
+func readYamlRecords(reader io.Reader) ([]interface{}, bool, error) {
+	var contents interface{}
+	if err := yaml.NewDecoder(reader).Decode(&contents); err != nil && err != io.EOF {
+		return nil, false, err
+	}
+
+	switch typed := contents.(type) {
+	case nil:
+		return nil, false, nil
+	case []interface{}:
+		return typed, false, nil
+	case map[string]interface{}:
+		replace, _ := typed["replace"].(bool)
+		records, ok := typed["records"].([]interface{})
+		if !ok && typed["records"] != nil {
+			return nil, false, fmt.Errorf("records must be a list")
+		}
+		return records, replace, nil
+	default:
+		return nil, false, fmt.Errorf("Expected a list of records or a mapping with records")
+	}
+}
+
+func readCsvRecords(reader io.Reader) ([]interface{}, error) {
+	rows, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	records := make([]interface{}, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := make(map[string]interface{})
+		for index, cell := range row {
+			if index < len(header) && cell != "" {
+				record[header[index]] = cell
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// mergeRecords adds records to the slice target points to. Struct records with the same
+// key as an existing record update it instead.
+func mergeRecords(target interface{}, records []interface{}, replace bool) error {
+	slice := reflect.ValueOf(target).Elem()
+	if replace {
+		slice.Set(reflect.MakeSlice(slice.Type(), 0, len(records)))
+	}
+	elementType := slice.Type().Elem()
+	keyIndex := datasetKeyField(elementType)
+
+	for recordNumber, record := range records {
+		if elementType.Kind() != reflect.Struct {
+			value := reflect.New(elementType).Elem()
+			if err := setReflectValue(value, record); err != nil {
+				return fmt.Errorf("record %d: %v", recordNumber+1, err)
+			}
+			if !sliceContains(slice, value) {
+				slice.Set(reflect.Append(slice, value))
+			}
+			continue
+		}
+
+		fields, ok := record.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("record %d: expected field names and values", recordNumber+1)
+		}
+
+		existing := -1
+		if keyIndex >= 0 {
+			existing = findRecordByKey(slice, keyIndex, fields)
+		}
+		if existing < 0 {
+			slice.Set(reflect.Append(slice, reflect.New(elementType).Elem()))
+			existing = slice.Len() - 1
+		}
+		if err := setStructFields(slice.Index(existing), fields); err != nil {
+			return fmt.Errorf("record %d: %v", recordNumber+1, err)
+		}
+	}
+	return nil
+}
+
+// datasetKeyField finds the field that identifies a record: the name field if there is one,
+// otherwise the first string field
+func datasetKeyField(t reflect.Type) int {
+	if t.Kind() != reflect.Struct {
+		return -1
+	}
+	if field, ok := t.FieldByName("name"); ok {
+		return field.Index[0]
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Type.Kind() == reflect.String {
+			return i
+		}
+	}
+	return -1
+}
+
+func findRecordByKey(slice reflect.Value, keyIndex int, fields map[string]interface{}) int {
+	keyField := slice.Type().Elem().Field(keyIndex)
+	for name, value := range fields {
+		if !fieldMatchesName(keyField, name) {
+			continue
+		}
+		for i := 0; i < slice.Len(); i++ {
+			if slice.Index(i).Field(keyIndex).String() == fmt.Sprint(value) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func sliceContains(slice reflect.Value, value reflect.Value) bool {
+	for i := 0; i < slice.Len(); i++ {
+		if reflect.DeepEqual(slice.Index(i).Interface(), value.Interface()) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldMatchesName reports whether a file's key refers to the field, by field name or crossqueryname
+func fieldMatchesName(field reflect.StructField, name string) bool {
+	return strings.EqualFold(field.Name, name) || strings.EqualFold(humanReadableFieldName(field), name)
+}
+
+func setStructFields(record reflect.Value, fields map[string]interface{}) error {
+	// sort the names so errors come out in a consistent order
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		index := -1
+		for i := 0; i < record.NumField(); i++ {
+			if fieldMatchesName(record.Type().Field(i), name) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("no field named %s", name)
+		}
+
+		if err := setReflectValue(settableField(record.Field(index)), fields[name]); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// settableField works around reflect's refusal to set unexported fields, which is what the quiz
+// structs all have. The field comes from an addressable slice element (see mergeRecords), so its
+// address is good for as long as the record is.
+func settableField(field reflect.Value) reflect.Value {
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
+
+// setReflectValue converts a value read from a file into the field's type
+func setReflectValue(target reflect.Value, raw interface{}) error {
+	switch target.Kind() {
+	case reflect.String:
+		switch raw.(type) {
+		case []interface{}, map[string]interface{}:
+			return fmt.Errorf("expected a single value but got %v", raw)
+		}
+		target.SetString(fmt.Sprint(raw))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(fmt.Sprint(raw), 10, 64)
+		if err != nil {
+			return err
+		}
+		target.SetInt(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(fmt.Sprint(raw), 64)
+		if err != nil {
+			return err
+		}
+		target.SetFloat(value)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(fmt.Sprint(raw))
+		if err != nil {
+			return err
+		}
+		target.SetBool(value)
+	case reflect.Slice:
+		var items []interface{}
+		switch typed := raw.(type) {
+		case []interface{}:
+			items = typed
+		case string:
+			for _, item := range strings.Split(typed, csvListSeparator) {
+				items = append(items, strings.TrimSpace(item))
+			}
+		default:
+			items = []interface{}{typed}
+		}
+
+		values := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for index, item := range items {
+			if err := setReflectValue(values.Index(index), item); err != nil {
+				return err
+			}
+		}
+		target.Set(values)
+	default:
+		return fmt.Errorf("unsupported field type %v", target.Type())
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&datasetDir, "datasets", "", "directory of files that add to or correct quiz data (default is $HOME/.derrick_tools/datasets)")
+}
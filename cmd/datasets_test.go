@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type testDatasetRecord struct {
+	name    string   `crossquery:"all"`
+	capital string   `crossquery:"all" crossqueryname:"capital city"`
+	rank    int      `crossquery:"given"`
+	regions []string `crossquery:"guess"`
+}
+
+func writeDatasetFile(t *testing.T, dir string, name string, contents string) string {
+	fileName := filepath.Join(dir, name)
+	if err := os.WriteFile(fileName, []byte(contents), 0644); err != nil {
+		t.Fatalf("Could not write %s: %v", fileName, err)
+	}
+	return fileName
+}
+
+func TestLoadYamlDatasetMergesRecords(t *testing.T) {
+	records := []testDatasetRecord{
+		{"Kazakhstan", "Almaty", 9, []string{"Asia"}},
+		{"France", "Paris", 42, []string{"Europe"}},
+	}
+	fileName := writeDatasetFile(t, t.TempDir(), "test.yaml", `
+- name: Kazakhstan
+  capital city: Astana
+- name: Atlantis
+  capital: Poseidonia
+  rank: 200
+  regions: [Ocean, Legend]
+`)
+
+	if err := loadDatasetFile(fileName, &records); err != nil {
+		t.Fatalf("Could not load dataset: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records but got %d", len(records))
+	}
+	if records[0].capital != "Astana" || records[0].rank != 9 || records[0].regions[0] != "Asia" {
+		t.Errorf("Expected only the capital of the existing record to change but got %+v", records[0])
+	}
+	added := records[2]
+	if added.name != "Atlantis" || added.capital != "Poseidonia" || added.rank != 200 || len(added.regions) != 2 {
+		t.Errorf("New record was not loaded correctly: %+v", added)
+	}
+}
+
+func TestLoadJsonDatasetReplacesRecords(t *testing.T) {
+	records := []testDatasetRecord{{"France", "Paris", 42, []string{"Europe"}}}
+	fileName := writeDatasetFile(t, t.TempDir(), "test.json",
+		`{"replace": true, "records": [{"name": "Atlantis", "capital": "Poseidonia"}]}`)
+
+	if err := loadDatasetFile(fileName, &records); err != nil {
+		t.Fatalf("Could not load dataset: %v", err)
+	}
+	if len(records) != 1 || records[0].name != "Atlantis" {
+		t.Errorf("Expected the built-in records to be replaced but got %+v", records)
+	}
+}
+
+func TestLoadCsvDataset(t *testing.T) {
+	records := []testDatasetRecord{{"France", "Paris", 42, []string{"Europe"}}}
+	fileName := writeDatasetFile(t, t.TempDir(), "test.csv",
+		"name,capital,rank,regions\nFrance,,43,\nAtlantis,Poseidonia,200,Ocean|Legend\n")
+
+	if err := loadDatasetFile(fileName, &records); err != nil {
+		t.Fatalf("Could not load dataset: %v", err)
+	}
+	if records[0].capital != "Paris" || records[0].rank != 43 {
+		t.Errorf("Expected empty cells to leave fields alone but got %+v", records[0])
+	}
+	if len(records) != 2 || len(records[1].regions) != 2 || records[1].regions[1] != "Legend" {
+		t.Errorf("Expected a new record with two regions but got %+v", records)
+	}
+}
+
+func TestLoadStringDataset(t *testing.T) {
+	plays := []string{"Hamlet"}
+	fileName := writeDatasetFile(t, t.TempDir(), "plays.yaml", "- Hamlet\n- Cardenio\n")
+
+	if err := loadDatasetFile(fileName, &plays); err != nil {
+		t.Fatalf("Could not load dataset: %v", err)
+	}
+	if len(plays) != 2 || plays[1] != "Cardenio" {
+		t.Errorf("Expected Cardenio to be added once but got %v", plays)
+	}
+}
+
+func TestLoadDatasetErrors(t *testing.T) {
+	dir := t.TempDir()
+	records := []testDatasetRecord{}
+
+	badField := writeDatasetFile(t, dir, "field.yaml", "- name: Atlantis\n  population: 0\n")
+	if err := loadDatasetFile(badField, &records); err == nil {
+		t.Errorf("Expected an error for an unknown field")
+	}
+
+	badNumber := writeDatasetFile(t, dir, "number.yaml", "- name: Atlantis\n  rank: first\n")
+	if err := loadDatasetFile(badNumber, &records); err == nil {
+		t.Errorf("Expected an error for a rank that isn't a number")
+	}
+}
+
+func TestLoadDatasetsFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	records := []testDatasetRecord{}
+	quizDatasets["testdataset"] = &records
+	defer delete(quizDatasets, "testdataset")
+
+	writeDatasetFile(t, dir, "testdataset.yaml", "- name: Atlantis\n")
+	writeDatasetFile(t, dir, "unrelated.yaml", "not: a dataset\n")
+
+	if problems := loadDatasetsFromDirectory(dir); len(problems) > 0 {
+		t.Fatalf("Could not load datasets: %v", problems)
+	}
+	if len(records) != 1 {
+		t.Errorf("Expected one record from the directory but got %d", len(records))
+	}
+
+	if problems := loadDatasetsFromDirectory(filepath.Join(dir, "missing")); len(problems) > 0 {
+		t.Errorf("A missing directory should not be a problem: %v", problems)
+	}
+}
+
+func TestBadDatasetFileIsAProblem(t *testing.T) {
+	dir := t.TempDir()
+	records := []testDatasetRecord{}
+	quizDatasets["testdataset"] = &records
+	defer delete(quizDatasets, "testdataset")
+
+	writeDatasetFile(t, dir, "testdataset.yaml", "- name: Atlantis\n  rank: first\n")
+	problems := loadDatasetsFromDirectory(dir)
+	if len(problems) != 1 || problems[0].dataset != "testdataset" || !strings.Contains(problems[0].message, "testdataset.yaml") {
+		t.Fatalf("Expected a problem with testdataset.yaml but got %v", problems)
+	}
+
+	defer func() { datasetLoadProblems = nil }()
+	datasetLoadProblems = problems
+	if errors, _ := problemMessages(validateQuizAreas()); len(errors) == 0 || errors[0] != problems[0].message {
+		t.Errorf("Expected validate to report the bad file but got %v", errors)
+	}
+}
+
+func TestQuizAreasRegisterDatasets(t *testing.T) {
+	for _, name := range []string{"countries", "presidents", "shakespeare", "spellingbee"} {
+		if _, exists := quizDatasets[name]; !exists {
+			t.Errorf("Expected a dataset named %s", name)
+		}
+	}
+}
@@ -17,17 +17,15 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"time"
-
-	"github.com/spf13/cobra"
 )
 
 // dayofweekCmd represents the dayofweek command
-var dayofweekCmd = &cobra.Command{
-	Use:   "dayofweek",
-	Short: "Tests the ability to figure out the day of week for a given date",
-	Long: `
+var dayofweekCmd = registerQuizArea(quizArea{
+	name:  "dayofweek",
+	tags:  []string{"math", "calendar"},
+	short: "Tests the ability to figure out the day of week for a given date",
+	long: `
 Conway's method for finding the day of week for any given year
 
 1. Figure out the modifier for the given century (the four centuries repeat, so 1700-1799 = 2100-2199):
@@ -63,22 +61,24 @@ Conway's method for finding the day of week for any given year
  	- October 10th
  	- November 7th
  	- December 12th`,
-	Run: quizDayOfWeekCalculation,
+	generators: dayOfWeekQuestions,
+	parent:     speedmathCmd,
+})
+
+func dayOfWeekQuestions() []questionGenerator {
+	promptFuncs := []speedMathFunc{quizDayOfWeekForDate}
+	return namedGenerators(promptFuncs)
 }
 
-func quizDayOfWeekCalculation(cmd *cobra.Command, args []string) {
-	century := rand.Intn(4) + 18
-	twoDigitYear := rand.Intn(100)
+func quizDayOfWeekForDate() promptAndResponse {
+	century := quizRand.Intn(4) + 18
+	twoDigitYear := quizRand.Intn(100)
 	year := (century * 100) + twoDigitYear
-	month := rand.Intn(12) + 1
-	day := rand.Intn(31) + 1
+	month := quizRand.Intn(12) + 1
+	day := quizRand.Intn(31) + 1
 
 	// note Date will do the right thing if, for instance, you pass September 31; it will set it to October 1.
 	// so we can just give it the date and let it figure it out
 	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)
-	promptAndCheckResponse(promptAndResponse{fmt.Sprintf("What day of the week does %s fall on?", fmt.Sprintf("%d/%d/%d", date.Month(), date.Day(), date.Year())), fmt.Sprintf("%v", date.Weekday())})
-}
-
-func init() {
-	speedmathCmd.AddCommand(dayofweekCmd)
+	return promptAndResponse{prompt: fmt.Sprintf("What day of the week does %s fall on?", fmt.Sprintf("%d/%d/%d", date.Month(), date.Day(), date.Year())), response: fmt.Sprintf("%v", date.Weekday()), oneOff: true}
 }
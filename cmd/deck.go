@@ -0,0 +1,192 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Decks are memory quizzes defined in a file instead of Go (see deckCmd for the format).
+// A deck's columns play the part of a quiz struct's fields and its crossquery and crossqueryname
+// tags, so cards are asked about with the same cross query logic as the built-in quizzes.
+// A card can leave a column out, and a column can have several values, like a president's first ladies.
+
+type deckColumn struct {
+	Name       string `yaml:"name"`
+	CrossQuery string `yaml:"crossquery"`
+	Label      string `yaml:"label"`
+}
+
+type deck struct {
+	Name    string                   `yaml:"name"`
+	Entity  string                   `yaml:"entity"`
+	Columns []deckColumn             `yaml:"columns"`
+	Cards   []map[string]interface{} `yaml:"cards"`
+}
+
+// loadDeck reads a deck file and checks that every card can be asked about
+func loadDeck(fileName string) (*deck, error) {
+	contents, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := &deck{}
+	if err := yaml.Unmarshal(contents, loaded); err != nil {
+		return nil, fmt.Errorf("Could not parse deck %s: %v", fileName, err)
+	}
+	if loaded.Name == "" {
+		loaded.Name = strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	}
+	if loaded.Entity == "" {
+		loaded.Entity = "card"
+	}
+	if err := loaded.validate(); err != nil {
+		return nil, fmt.Errorf("Invalid deck %s: %v", fileName, err)
+	}
+	return loaded, nil
+}
+
+func (d *deck) validate() error {
+	if len(d.Columns) < 2 {
+		return fmt.Errorf("a deck needs at least two columns")
+	}
+	if len(d.Cards) == 0 {
+		return fmt.Errorf("the deck has no cards")
+	}
+
+	columns := make(map[string]bool)
+	for _, column := range d.Columns {
+		if column.Name == "" {
+			return fmt.Errorf("every column needs a name")
+		}
+		if columns[column.Name] {
+			return fmt.Errorf("column %s is declared twice", column.Name)
+		}
+		columns[column.Name] = true
+
+		switch column.CrossQuery {
+		case "", "all", "given", "guess":
+		default:
+			return fmt.Errorf("column %s has an invalid crossquery of %s", column.Name, column.CrossQuery)
+		}
+	}
+
+	cardFields := make([][]crossQueryField, 0, len(d.Cards))
+	for index, card := range d.Cards {
+		for name := range card {
+			if !columns[name] {
+				return fmt.Errorf("card %d has undeclared column %s", index+1, name)
+			}
+		}
+		cardFields = append(cardFields, d.cardFields(card))
+	}
+
+	for index := range d.Cards {
+		if !canCrossQuery(askableFields(cardFields, index)) {
+			return fmt.Errorf("card %d needs a value for a given column and a different guess column", index+1)
+		}
+	}
+	return nil
+}
+
+// cardFields turns a card into the fields the cross query logic works with
+func (d *deck) cardFields(card map[string]interface{}) []crossQueryField {
+	fields := make([]crossQueryField, 0, len(d.Columns))
+	for _, column := range d.Columns {
+		name := column.Label
+		if name == "" {
+			name = column.Name
+		}
+		_, multiValued := card[column.Name].([]interface{})
+		fields = append(fields, crossQueryField{name: name, role: column.CrossQuery, values: deckValues(card[column.Name]), multiValued: multiValued})
+	}
+	return fields
+}
+
+func deckValues(raw interface{}) []string {
+	values := []string{}
+	switch typed := raw.(type) {
+	case nil:
+	case []interface{}:
+		for _, item := range typed {
+			values = append(values, deckValues(item)...)
+		}
+	default:
+		if value := strings.TrimSpace(fmt.Sprint(typed)); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (d *deck) crossQueryDeck() promptAndResponse {
+	cardFields := make([][]crossQueryField, 0, len(d.Cards))
+	for _, card := range d.Cards {
+		cardFields = append(cardFields, d.cardFields(card))
+	}
+	return constructCrossQueryAmong(d.Entity, cardFields)
+}
+
+func (d *deck) questions() []questionGenerator {
+	return []questionGenerator{{name: "crossQueryDeck", generate: d.crossQueryDeck}}
+}
+
+var deckCmd = &cobra.Command{
+	Use:   "deck FILE",
+	Short: "Quiz yourself on a deck of your own",
+	Long: `Quiz yourself on a deck file of your own, such as on-call rotations, region codes,
+or port numbers. A deck is a YAML or JSON file like:
+
+  name: aws-regions
+  entity: AWS region
+  columns:
+    - name: code
+      crossquery: all
+      label: region code
+    - name: city
+      crossquery: guess
+  cards:
+    - code: us-east-1
+      city: [Ashburn, Virginia]
+    - code: us-west-2
+      city: Oregon
+
+A column's crossquery says whether it can be the given in a question ("given"),
+the thing to guess ("guess"), or either ("all", the default).`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		loaded, err := loadDeck(args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		runQuizSession(func(cmd *cobra.Command, args []string) {
+			askScheduledQuestion("deck:"+loaded.Name, loaded.questions())
+		})(cmd, args)
+	},
+}
+
+func init() {
+	memoryquizCmd.AddCommand(deckCmd)
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDeck = `
+entity: service
+columns:
+  - name: service
+    crossquery: all
+  - name: port
+    crossquery: given
+    label: port number
+  - name: owners
+    crossquery: guess
+    label: owning team
+cards:
+  - service: billing
+    port: 8443
+    owners: [payments, finance]
+  - service: search
+    port: 9200
+`
+
+func TestLoadDeck(t *testing.T) {
+	fileName := writeDatasetFile(t, t.TempDir(), "services.yaml", testDeck)
+	loaded, err := loadDeck(fileName)
+	if err != nil {
+		t.Fatalf("Could not load deck: %v", err)
+	}
+	if loaded.Name != "services" {
+		t.Errorf("Expected the deck to be named after its file but got %s", loaded.Name)
+	}
+
+	for i := 0; i < 50; i++ {
+		question := loaded.crossQueryDeck()
+		if strings.HasPrefix(question.prompt, "Name every owning team of the service with ") {
+			if len(question.allOf) != 2 || question.response != "payments, finance" {
+				t.Errorf("Expected both owning teams but got %v", question.allOf)
+			}
+			continue
+		}
+		if !strings.HasPrefix(question.prompt, "What is the ") || !strings.Contains(question.prompt, " of the service with ") {
+			t.Errorf("Unexpected prompt %s", question.prompt)
+		}
+		if strings.Contains(question.prompt, "What is the port number") {
+			t.Errorf("Port is only a given but was asked for: %s", question.prompt)
+		}
+		if strings.Contains(question.prompt, "with owning team") {
+			t.Errorf("Owning team is only a guess but was given: %s", question.prompt)
+		}
+		if strings.Contains(question.prompt, "owning team") && (question.response != "payments" || len(question.aliases) != 1 || question.aliases[0] != "finance") {
+			t.Errorf("Expected either owning team to be accepted but got %s and %v", question.response, question.aliases)
+		}
+	}
+}
+
+func TestInvalidDecks(t *testing.T) {
+	decks := map[string]string{
+		"bad crossquery":    "columns: [{name: a, crossquery: sometimes}, {name: b}]\ncards: [{a: 1, b: 2}]",
+		"undeclared column": "columns: [{name: a}, {name: b}]\ncards: [{a: 1, c: 2}]",
+		"nothing to guess":  "columns: [{name: a, crossquery: given}, {name: b, crossquery: given}]\ncards: [{a: 1, b: 2}]",
+		"one value":         "columns: [{name: a}, {name: b}]\ncards: [{a: 1}]",
+		"no cards":          "columns: [{name: a}, {name: b}]",
+	}
+
+	dir := t.TempDir()
+	for name, contents := range decks {
+		fileName := writeDatasetFile(t, dir, strings.ReplaceAll(name, " ", "_")+".yaml", contents)
+		if _, err := loadDeck(fileName); err == nil {
+			t.Errorf("Expected an error for a deck with %s", name)
+		}
+	}
+}
@@ -17,17 +17,15 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"time"
-
-	"github.com/spf13/cobra"
 )
 
 // powersoftwoCmd represents the powersoftwo command
-var doomsdayCmd = &cobra.Command{
-	Use:   "doomsday",
-	Short: "Memory quiz on doomsdays for given years",
-	Long: `
+var doomsdayCmd = registerQuizArea(quizArea{
+	name:  "doomsday",
+	tags:  []string{"math", "calendar"},
+	short: "Memory quiz on doomsdays for given years",
+	long: `
 	Conway's method for finding the day of week for any given year
 
 	1. Figure out the modifier for the given century (the four centuries repeat, so 1700-1799 = 2100-2199):
@@ -50,17 +48,18 @@ var doomsdayCmd = &cobra.Command{
 		- 5 = Friday
 		- 6 = Saturday
 `,
-	Run: quizDoomsday,
+	generators: doomsdayQuestions,
+})
+
+func doomsdayQuestions() []questionGenerator {
+	promptFuncs := []func() promptAndResponse{quizDoomsdayForYear}
+	return namedGenerators(promptFuncs)
 }
 
-func quizDoomsday(cmd *cobra.Command, args []string) {
-	yearsAfter1800 := rand.Intn(400)
+func quizDoomsdayForYear() promptAndResponse {
+	yearsAfter1800 := quizRand.Intn(400)
 	year := 1800 + yearsAfter1800
 	doomsdayDate := time.Date(year, 12, 12, 0, 0, 0, 0, time.UTC)
 	dayOfWeek := doomsdayDate.Weekday().String()
-	promptAndCheckResponse(promptAndResponse{fmt.Sprintf("What day of the week is the doomsday for %d?", year), dayOfWeek})
-}
-
-func init() {
-	memoryquizCmd.AddCommand(doomsdayCmd)
+	return promptAndResponse{prompt: fmt.Sprintf("What day of the week is the doomsday for %d?", year), response: dayOfWeek}
 }
@@ -17,160 +17,181 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"strconv"
 	"strings"
-
-	"github.com/spf13/cobra"
 )
 
 // elementsCmd represents the elements command
-var elementsCmd = &cobra.Command{
-	Use:   "elements",
-	Short: "Test recall of periodic table of elements information",
-	Run:   quizElements,
-}
+var elementsCmd = registerQuizArea(quizArea{
+	name:       "elements",
+	tags:       []string{"science"},
+	short:      "Test recall of periodic table of elements information",
+	generators: elementQuestions,
+	dataset:    &elements,
+	entity:     "atomic element",
+})
 
 type elementInfo struct {
-	atomicNumber int    `crossquery:"all" crossqueryname:"atomic number"`
-	name         string `crossquery:"all"`
+	atomicNumber int    `crossquery:"all" crossqueryname:"atomic number" rank:"asc" rankwords:"has a higher atomic number,has a lower atomic number,has the highest atomic number,has the lowest atomic number"`
+	name         string `crossquery:"all" rank:"name"`
 	symbol       string `crossquery:"all"`
+	// group is the column of the periodic table, from 1 to 18. The lanthanides and actinides in
+	// the f-block rows below it don't have one (lutetium and lawrencium sit in group 3).
+	group      string  `crossquery:"guess" crossqueryoptional:"true"`
+	period     int     `crossquery:"all"`
+	block      string  `crossquery:"all"`
+	category   string  `crossquery:"all"`
+	atomicMass float64 `crossquery:"all" crossqueryname:"atomic mass"`
+	// discovered is the year the element was discovered (or first isolated), or "ancient" for the
+	// ones known since antiquity
+	discovered string `crossquery:"all" crossqueryname:"year of discovery"`
 }
 
 var elements = []elementInfo{
-	{1, "Hydrogen", "H"},
-	{2, "Helium", "He"},
-	{3, "Lithium", "Li"},
-	{4, "Beryllium", "Be"},
-	{5, "Boron", "B"},
-	{6, "Carbon", "C"},
-	{7, "Nitrogen", "N"},
-	{8, "Oxygen", "O"},
-	{9, "Fluorine", "F"},
-	{10, "Neon", "Ne"},
-	{11, "Sodium", "Na"},
-	{12, "Magnesium", "Mg"},
-	{13, "Aluminum", "Al"},
-	{14, "Silicon", "Si"},
-	{15, "Phosphorous", "P"},
-	{16, "Sulfur", "S"},
-	{17, "Chlorine", "Cl"},
-	{18, "Argon", "Ar"},
-	{19, "Potassium", "K"},
-	{20, "Calcium", "Ca"},
-	{21, "Scandium", "Sc"},
-	{22, "Titanium", "Ti"},
-	{23, "Vanadium", "V"},
-	{24, "Chromium", "Cr"},
-	{25, "Manganese", "Mn"},
-	{26, "Iron", "Fe"},
-	{27, "Cobalt", "Co"},
-	{28, "Nickel", "Ni"},
-	{29, "Copper", "Cu"},
-	{30, "Zinc", "Zn"},
-	{31, "Gallium", "Ga"},
-	{32, "Germanium", "Ge"},
-	{33, "Arsenic", "As"},
-	{34, "Selenium", "Se"},
-	{35, "Bromine", "Br"},
-	{36, "Krypton", "Kr"},
-	{37, "Rubidium", "Rb"},
-	{38, "Strontium", "Sr"},
-	{39, "Yttrium", "Y"},
-	{40, "Zirconium", "Zr"},
-	{41, "Niobium", "Nb"},
-	{42, "Molybdenum", "Mo"},
-	{43, "Technetium", "Tc"},
-	{44, "Ruthenium", "Ru"},
-	{45, "Rhodium", "Rh"},
-	{46, "Palladium", "Pd"},
-	{47, "Silver", "Ag"},
-	{48, "Cadmium", "Cd"},
-	{49, "Indium", "In"},
-	{50, "Tin", "Sn"},
-	{51, "Antimony", "Sb"},
-	{52, "Tellurium", "Te"},
-	{53, "Iodine", "I"},
-	{54, "Xenon", "Xe"},
-	{55, "Cesium", "Cs"},
-	{56, "Barium", "Ba"},
-	{57, "Lanthanum", "La"},
-	{58, "Cerium", "Ce"},
-	{59, "Praseodymium", "Pr"},
-	{60, "Neodymium", "Nd"},
-	{61, "Promethium", "Pm"},
-	{62, "Samarium", "Sm"},
-	{63, "Europium", "Eu"},
-	{64, "Gadolinium", "Gd"},
-	{65, "Terbium", "Tb"},
-	{66, "Dysprosium", "Dy"},
-	{67, "Holmium", "Ho"},
-	{68, "Erbium", "Er"},
-	{69, "Thulium", "Tm"},
-	{70, "Ytterbium", "Yb"},
-	{71, "Lutetium", "Lu"},
-	{72, "Hafnium", "Hf"},
-	{73, "Tantalum", "Ta"},
-	{74, "Tungsten", "W"},
-	{75, "Rhenium", "Re"},
-	{76, "Osmium", "Os"},
-	{77, "Iridium", "Ir"},
-	{78, "Platinum", "Pt"},
-	{79, "Gold", "Au"},
-	{80, "Mercury", "Hg"},
-	{81, "Thalium", "Tl"},
-	{82, "Lead", "Pb"},
-	{83, "Bismuth", "Bi"},
-	{84, "Polonium", "Po"},
-	{85, "Astatine", "At"},
-	{86, "Radon", "Rn"},
-	{87, "Francium", "Fr"},
-	{88, "Radium", "Ra"},
-	{89, "Actinium", "Ac"},
-	{90, "Thorium", "Th"},
-	{91, "Protactinium", "Pa"},
-	{92, "Uranium", "U"},
-	{93, "Neptunium", "Np"},
-	{94, "Plutonium", "Pu"},
-	{95, "Americium", "Am"},
-	{96, "Curium", "Cm"},
-	{97, "Berkelium", "Bk"},
-	{98, "Californium", "Cf"},
-	{99, "Einsteinium", "Es"},
-	{100, "Fermium", "Fm"},
-	{101, "Mendelevium", "Md"},
-	{102, "Nobelium", "No"},
-	{103, "Lawrencium", "Lr"},
-	{104, "Rutherfordium", "Rf"},
-	{105, "Dubnium", "Db"},
-	{106, "Seaborgium", "Sg"},
-	{107, "Bohrium", "Bh"},
-	{108, "Hassium", "Hs"},
-	{109, "Meitnerium", "Mt"},
-	{110, "Darmstadtium", "Ds"},
-	{111, "Roentgenium", "Rg"},
-	{112, "Copernicium", "Cn"},
-	{113, "Nihonium", "Nh"},
-	{114, "Flerovium", "Fl"},
-	{115, "Moscovium", "Mc"},
-	{116, "Livermorium", "Lv"},
-	{117, "Tennessine", "Ts"},
-	{118, "Oganesson", "Og"},
+	{1, "Hydrogen", "H", "1", 1, "s", "nonmetal", 1.008, "1766"},
+	{2, "Helium", "He", "18", 1, "s", "noble gas", 4.0026, "1868"},
+	{3, "Lithium", "Li", "1", 2, "s", "alkali metal", 6.94, "1817"},
+	{4, "Beryllium", "Be", "2", 2, "s", "alkaline earth metal", 9.0122, "1798"},
+	{5, "Boron", "B", "13", 2, "p", "metalloid", 10.81, "1808"},
+	{6, "Carbon", "C", "14", 2, "p", "nonmetal", 12.011, "ancient"},
+	{7, "Nitrogen", "N", "15", 2, "p", "nonmetal", 14.007, "1772"},
+	{8, "Oxygen", "O", "16", 2, "p", "nonmetal", 15.999, "1774"},
+	{9, "Fluorine", "F", "17", 2, "p", "halogen", 18.998, "1886"},
+	{10, "Neon", "Ne", "18", 2, "p", "noble gas", 20.180, "1898"},
+	{11, "Sodium", "Na", "1", 3, "s", "alkali metal", 22.990, "1807"},
+	{12, "Magnesium", "Mg", "2", 3, "s", "alkaline earth metal", 24.305, "1808"},
+	{13, "Aluminum", "Al", "13", 3, "p", "post-transition metal", 26.982, "1825"},
+	{14, "Silicon", "Si", "14", 3, "p", "metalloid", 28.085, "1824"},
+	{15, "Phosphorous", "P", "15", 3, "p", "nonmetal", 30.974, "1669"},
+	{16, "Sulfur", "S", "16", 3, "p", "nonmetal", 32.06, "ancient"},
+	{17, "Chlorine", "Cl", "17", 3, "p", "halogen", 35.45, "1774"},
+	{18, "Argon", "Ar", "18", 3, "p", "noble gas", 39.948, "1894"},
+	{19, "Potassium", "K", "1", 4, "s", "alkali metal", 39.098, "1807"},
+	{20, "Calcium", "Ca", "2", 4, "s", "alkaline earth metal", 40.078, "1808"},
+	{21, "Scandium", "Sc", "3", 4, "d", "transition metal", 44.956, "1879"},
+	{22, "Titanium", "Ti", "4", 4, "d", "transition metal", 47.867, "1791"},
+	{23, "Vanadium", "V", "5", 4, "d", "transition metal", 50.942, "1801"},
+	{24, "Chromium", "Cr", "6", 4, "d", "transition metal", 51.996, "1797"},
+	{25, "Manganese", "Mn", "7", 4, "d", "transition metal", 54.938, "1774"},
+	{26, "Iron", "Fe", "8", 4, "d", "transition metal", 55.845, "ancient"},
+	{27, "Cobalt", "Co", "9", 4, "d", "transition metal", 58.933, "1735"},
+	{28, "Nickel", "Ni", "10", 4, "d", "transition metal", 58.693, "1751"},
+	{29, "Copper", "Cu", "11", 4, "d", "transition metal", 63.546, "ancient"},
+	{30, "Zinc", "Zn", "12", 4, "d", "transition metal", 65.38, "1746"},
+	{31, "Gallium", "Ga", "13", 4, "p", "post-transition metal", 69.723, "1875"},
+	{32, "Germanium", "Ge", "14", 4, "p", "metalloid", 72.630, "1886"},
+	{33, "Arsenic", "As", "15", 4, "p", "metalloid", 74.922, "1250"},
+	{34, "Selenium", "Se", "16", 4, "p", "nonmetal", 78.971, "1817"},
+	{35, "Bromine", "Br", "17", 4, "p", "halogen", 79.904, "1826"},
+	{36, "Krypton", "Kr", "18", 4, "p", "noble gas", 83.798, "1898"},
+	{37, "Rubidium", "Rb", "1", 5, "s", "alkali metal", 85.468, "1861"},
+	{38, "Strontium", "Sr", "2", 5, "s", "alkaline earth metal", 87.62, "1790"},
+	{39, "Yttrium", "Y", "3", 5, "d", "transition metal", 88.906, "1794"},
+	{40, "Zirconium", "Zr", "4", 5, "d", "transition metal", 91.224, "1789"},
+	{41, "Niobium", "Nb", "5", 5, "d", "transition metal", 92.906, "1801"},
+	{42, "Molybdenum", "Mo", "6", 5, "d", "transition metal", 95.95, "1778"},
+	{43, "Technetium", "Tc", "7", 5, "d", "transition metal", 98, "1937"},
+	{44, "Ruthenium", "Ru", "8", 5, "d", "transition metal", 101.07, "1844"},
+	{45, "Rhodium", "Rh", "9", 5, "d", "transition metal", 102.91, "1803"},
+	{46, "Palladium", "Pd", "10", 5, "d", "transition metal", 106.42, "1803"},
+	{47, "Silver", "Ag", "11", 5, "d", "transition metal", 107.87, "ancient"},
+	{48, "Cadmium", "Cd", "12", 5, "d", "transition metal", 112.41, "1817"},
+	{49, "Indium", "In", "13", 5, "p", "post-transition metal", 114.82, "1863"},
+	{50, "Tin", "Sn", "14", 5, "p", "post-transition metal", 118.71, "ancient"},
+	{51, "Antimony", "Sb", "15", 5, "p", "metalloid", 121.76, "ancient"},
+	{52, "Tellurium", "Te", "16", 5, "p", "metalloid", 127.60, "1782"},
+	{53, "Iodine", "I", "17", 5, "p", "halogen", 126.90, "1811"},
+	{54, "Xenon", "Xe", "18", 5, "p", "noble gas", 131.29, "1898"},
+	{55, "Cesium", "Cs", "1", 6, "s", "alkali metal", 132.91, "1860"},
+	{56, "Barium", "Ba", "2", 6, "s", "alkaline earth metal", 137.33, "1808"},
+	{57, "Lanthanum", "La", "", 6, "f", "lanthanide", 138.91, "1839"},
+	{58, "Cerium", "Ce", "", 6, "f", "lanthanide", 140.12, "1803"},
+	{59, "Praseodymium", "Pr", "", 6, "f", "lanthanide", 140.91, "1885"},
+	{60, "Neodymium", "Nd", "", 6, "f", "lanthanide", 144.24, "1885"},
+	{61, "Promethium", "Pm", "", 6, "f", "lanthanide", 145, "1945"},
+	{62, "Samarium", "Sm", "", 6, "f", "lanthanide", 150.36, "1879"},
+	{63, "Europium", "Eu", "", 6, "f", "lanthanide", 151.96, "1901"},
+	{64, "Gadolinium", "Gd", "", 6, "f", "lanthanide", 157.25, "1880"},
+	{65, "Terbium", "Tb", "", 6, "f", "lanthanide", 158.93, "1843"},
+	{66, "Dysprosium", "Dy", "", 6, "f", "lanthanide", 162.50, "1886"},
+	{67, "Holmium", "Ho", "", 6, "f", "lanthanide", 164.93, "1878"},
+	{68, "Erbium", "Er", "", 6, "f", "lanthanide", 167.26, "1843"},
+	{69, "Thulium", "Tm", "", 6, "f", "lanthanide", 168.93, "1879"},
+	{70, "Ytterbium", "Yb", "", 6, "f", "lanthanide", 173.05, "1878"},
+	{71, "Lutetium", "Lu", "3", 6, "d", "lanthanide", 174.97, "1907"},
+	{72, "Hafnium", "Hf", "4", 6, "d", "transition metal", 178.49, "1923"},
+	{73, "Tantalum", "Ta", "5", 6, "d", "transition metal", 180.95, "1802"},
+	{74, "Tungsten", "W", "6", 6, "d", "transition metal", 183.84, "1783"},
+	{75, "Rhenium", "Re", "7", 6, "d", "transition metal", 186.21, "1925"},
+	{76, "Osmium", "Os", "8", 6, "d", "transition metal", 190.23, "1803"},
+	{77, "Iridium", "Ir", "9", 6, "d", "transition metal", 192.22, "1803"},
+	{78, "Platinum", "Pt", "10", 6, "d", "transition metal", 195.08, "1735"},
+	{79, "Gold", "Au", "11", 6, "d", "transition metal", 196.97, "ancient"},
+	{80, "Mercury", "Hg", "12", 6, "d", "transition metal", 200.59, "ancient"},
+	{81, "Thalium", "Tl", "13", 6, "p", "post-transition metal", 204.38, "1861"},
+	{82, "Lead", "Pb", "14", 6, "p", "post-transition metal", 207.2, "ancient"},
+	{83, "Bismuth", "Bi", "15", 6, "p", "post-transition metal", 208.98, "1753"},
+	{84, "Polonium", "Po", "16", 6, "p", "post-transition metal", 209, "1898"},
+	{85, "Astatine", "At", "17", 6, "p", "halogen", 210, "1940"},
+	{86, "Radon", "Rn", "18", 6, "p", "noble gas", 222, "1899"},
+	{87, "Francium", "Fr", "1", 7, "s", "alkali metal", 223, "1939"},
+	{88, "Radium", "Ra", "2", 7, "s", "alkaline earth metal", 226, "1898"},
+	{89, "Actinium", "Ac", "", 7, "f", "actinide", 227, "1899"},
+	{90, "Thorium", "Th", "", 7, "f", "actinide", 232.04, "1829"},
+	{91, "Protactinium", "Pa", "", 7, "f", "actinide", 231.04, "1913"},
+	{92, "Uranium", "U", "", 7, "f", "actinide", 238.03, "1789"},
+	{93, "Neptunium", "Np", "", 7, "f", "actinide", 237, "1940"},
+	{94, "Plutonium", "Pu", "", 7, "f", "actinide", 244, "1940"},
+	{95, "Americium", "Am", "", 7, "f", "actinide", 243, "1944"},
+	{96, "Curium", "Cm", "", 7, "f", "actinide", 247, "1944"},
+	{97, "Berkelium", "Bk", "", 7, "f", "actinide", 247, "1949"},
+	{98, "Californium", "Cf", "", 7, "f", "actinide", 251, "1950"},
+	{99, "Einsteinium", "Es", "", 7, "f", "actinide", 252, "1952"},
+	{100, "Fermium", "Fm", "", 7, "f", "actinide", 257, "1952"},
+	{101, "Mendelevium", "Md", "", 7, "f", "actinide", 258, "1955"},
+	{102, "Nobelium", "No", "", 7, "f", "actinide", 259, "1966"},
+	{103, "Lawrencium", "Lr", "3", 7, "d", "actinide", 266, "1961"},
+	{104, "Rutherfordium", "Rf", "4", 7, "d", "transition metal", 267, "1964"},
+	{105, "Dubnium", "Db", "5", 7, "d", "transition metal", 268, "1968"},
+	{106, "Seaborgium", "Sg", "6", 7, "d", "transition metal", 269, "1974"},
+	{107, "Bohrium", "Bh", "7", 7, "d", "transition metal", 270, "1981"},
+	{108, "Hassium", "Hs", "8", 7, "d", "transition metal", 269, "1984"},
+	{109, "Meitnerium", "Mt", "9", 7, "d", "transition metal", 278, "1982"},
+	{110, "Darmstadtium", "Ds", "10", 7, "d", "transition metal", 281, "1994"},
+	{111, "Roentgenium", "Rg", "11", 7, "d", "transition metal", 282, "1994"},
+	{112, "Copernicium", "Cn", "12", 7, "d", "transition metal", 285, "1996"},
+	{113, "Nihonium", "Nh", "13", 7, "p", "post-transition metal", 286, "2004"},
+	{114, "Flerovium", "Fl", "14", 7, "p", "post-transition metal", 289, "1999"},
+	{115, "Moscovium", "Mc", "15", 7, "p", "post-transition metal", 290, "2003"},
+	{116, "Livermorium", "Lv", "16", 7, "p", "post-transition metal", 293, "2000"},
+	{117, "Tennessine", "Ts", "17", 7, "p", "halogen", 294, "2010"},
+	{118, "Oganesson", "Og", "18", 7, "p", "noble gas", 294, "2002"},
 }
 
-func quizElements(cmd *cobra.Command, args []string) {
-	element := elements[rand.Intn(len(elements))]
-	var prompt promptAndResponse
-	if rand.Intn(10) < 8 {
-		prompt = constructCrossQuery("atomic element", element)
-	} else {
-		prompt = quizElementsThatStartWithLetter(cmd, args)
+type elementQuestion func([]elementInfo) promptAndResponse
+
+func elementQuestions() []questionGenerator {
+	promptFuncs := []elementQuestion{
+		crossQueryElementInfo,
+		crossQueryElementInfo,
+		crossQueryElementInfo,
+		crossQueryElementInfo,
+		quizElementsThatStartWithLetter,
+		quizRankElements,
+		quizElementGroup,
+		quizElementPeriod,
+		quizElementAtPosition,
+		quizBlankInPeriodicTable,
 	}
-	promptAndCheckResponse(prompt)
+	return generatorsFor(promptFuncs, elements)
+}
+
+func crossQueryElementInfo(elements []elementInfo) promptAndResponse {
+	return constructCrossQueryFromSlice("atomic element", elements)
 }
 
-func quizElementsThatStartWithLetter(cmd *cobra.Command, args []string) promptAndResponse {
-	letterAscii := rune(65 + rand.Intn(26))
+func quizElementsThatStartWithLetter(elements []elementInfo) promptAndResponse {
+	letterAscii := rune(65 + quizRand.Intn(26))
 	letter := string(letterAscii)
 	count := 0
 	for _, element := range elements {
@@ -178,9 +199,43 @@ func quizElementsThatStartWithLetter(cmd *cobra.Command, args []string) promptAn
 			count++
 		}
 	}
-	return promptAndResponse{fmt.Sprintf("How many elements start with %s?", letter), strconv.Itoa(count)}
+	return promptAndResponse{prompt: fmt.Sprintf("How many elements start with %s?", letter), response: strconv.Itoa(count)}
+}
+
+func quizRankElements(elements []elementInfo) promptAndResponse {
+	return quizRanked("atomic element", elements)
+}
+
+// quizElementGroup asks which group an element is in. The lanthanides and actinides aren't in
+// one, so they're left out.
+func quizElementGroup(elements []elementInfo) promptAndResponse {
+	element := randomItemFromSlice(elements)
+	for element.group == "" {
+		element = randomItemFromSlice(elements)
+	}
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("Which group of the periodic table is %s in?", element.name),
+		response: element.group,
+	}
+}
+
+func quizElementPeriod(elements []elementInfo) promptAndResponse {
+	element := randomItemFromSlice(elements)
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("Which period of the periodic table is %s in?", element.name),
+		response: strconv.Itoa(element.period),
+	}
 }
 
-func init() {
-	memoryquizCmd.AddCommand(elementsCmd)
+// quizElementAtPosition goes the other way, asking which element is at a group and period
+func quizElementAtPosition(elements []elementInfo) promptAndResponse {
+	element := randomItemFromSlice(elements)
+	for element.group == "" {
+		element = randomItemFromSlice(elements)
+	}
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("Which element is in group %s, period %d?", element.group, element.period),
+		response: element.name,
+		aliases:  []string{element.symbol},
+	}
 }
@@ -16,16 +16,17 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
-
-	"github.com/spf13/cobra"
 )
 
-var englishRoyaltyCmd = &cobra.Command{
-	Use:   "english-royalty",
-	Short: "Quiz English royalty",
-	Run:   quizEnglishRoyalty,
-}
+var englishRoyaltyCmd = registerQuizArea(quizArea{
+	name:       "english-royalty",
+	aliases:    []string{"english-royals"},
+	tags:       []string{"history"},
+	short:      "Quiz English royalty",
+	generators: englishRoyaltyQuestions,
+	dataset:    &royals,
+	entity:     "English royal",
+})
 
 type englishRoyal struct {
 	order     int    `crossquery:"all"`
@@ -33,6 +34,14 @@ type englishRoyal struct {
 	sobriquet string
 }
 
+// answerAliases accepts a royal's name with their sobriquet, as in Alfred the Great
+func (royal englishRoyal) answerAliases(fieldName string) []string {
+	if fieldName == "name" && royal.sobriquet != "" {
+		return []string{royal.name + " " + royal.sobriquet}
+	}
+	return nil
+}
+
 var royals = []englishRoyal{
 	{1, "Egbert", ""},
 	{2, "Aethelwulf", ""},
@@ -101,7 +110,7 @@ var royals = []englishRoyal{
 
 type englishRoyalQuestion func([]englishRoyal) promptAndResponse
 
-func quizEnglishRoyalty(cmd *cobra.Command, args []string) {
+func englishRoyaltyQuestions() []questionGenerator {
 
 	var promptFuncs = []englishRoyalQuestion{
 		crossQueryEnglishRoyal,
@@ -113,21 +122,20 @@ func quizEnglishRoyalty(cmd *cobra.Command, args []string) {
 		quizRoyalBySobriquet,
 		quizRoyalBeforeAnother,
 		quizRoyalAfterAnother,
+		quizReciteRoyals,
 	}
 
-	function := randomItemFromSlice(promptFuncs)
-	promptAndCheckResponse(function(royals))
+	return generatorsFor(promptFuncs, royals)
 }
 
 func crossQueryEnglishRoyal(royals []englishRoyal) promptAndResponse {
-	royal := randomItemFromSlice(royals)
-	return constructCrossQuery("English royal", royal)
+	return constructCrossQueryFromSlice("English royal", royals)
 
 }
 
 func quizRoyalBySobriquet(royals []englishRoyal) promptAndResponse {
 	// filter out royals without sobriquets
-	sobriquetRoyals := make([]englishRoyal, 13)
+	sobriquetRoyals := make([]englishRoyal, 0)
 	for _, royal := range royals {
 		if royal.sobriquet != "" {
 			sobriquetRoyals = append(sobriquetRoyals, royal)
@@ -135,21 +143,23 @@ func quizRoyalBySobriquet(royals []englishRoyal) promptAndResponse {
 	}
 
 	quizRoyal := randomItemFromSlice(sobriquetRoyals)
-	return promptAndResponse{fmt.Sprintf("Which English royal had the sobriquet %s?", quizRoyal.sobriquet), quizRoyal.name}
+	return promptAndResponse{prompt: fmt.Sprintf("Which English royal had the sobriquet %s?", quizRoyal.sobriquet), response: quizRoyal.name, aliases: quizRoyal.answerAliases("name")}
 }
 
 func quizRoyalBeforeAnother(royals []englishRoyal) promptAndResponse {
 	// exclude the first ruler, who doesn't have a predecessory	royalsWithBefore := royals[1:]
-	index := rand.Intn(len(royals)-1) + 1
-	return promptAndResponse{fmt.Sprintf("Who ruled England before %s?", royals[index].name), royals[index-1].name}
+	index := quizRand.Intn(len(royals)-1) + 1
+	return promptAndResponse{prompt: fmt.Sprintf("Who ruled England before %s?", royals[index].name), response: royals[index-1].name, aliases: royals[index-1].answerAliases("name")}
 }
 
 func quizRoyalAfterAnother(royals []englishRoyal) promptAndResponse {
 	// exclude the last ruler, who doesn't have a successor (yet)
-	index := rand.Intn(len(royals) - 1)
-	return promptAndResponse{fmt.Sprintf("Who ruled England after %s?", royals[index].name), royals[index+1].name}
+	index := quizRand.Intn(len(royals) - 1)
+	return promptAndResponse{prompt: fmt.Sprintf("Who ruled England after %s?", royals[index].name), response: royals[index+1].name, aliases: royals[index+1].answerAliases("name")}
 }
 
-func init() {
-	memoryquizCmd.AddCommand(englishRoyaltyCmd)
+func quizReciteRoyals(royals []englishRoyal) promptAndResponse {
+	return quizReciteOrdered("English monarchs", royals,
+		func(royal englishRoyal) int { return royal.order },
+		func(royal englishRoyal) []string { return append([]string{royal.name}, royal.answerAliases("name")...) })
 }
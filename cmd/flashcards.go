@@ -0,0 +1,201 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// The export command turns the datasets into flashcards for reviewing somewhere else, like Anki on
+// a phone. Rather than picking questions at random, it writes every card the quizzes could ask:
+// one for each entity, given field, and guess field the crossquery tags allow, and both directions
+// of the ordinal questions for lists whose order matters.
+
+var flashcardFormat string
+var flashcardOutput string
+
+// flashcard is one card, with the area it came from and its tags
+type flashcard struct {
+	area  string
+	front string
+	back  string
+	tags  []string
+}
+
+// canGive and canGuess say what a crossquery role allows the field to be used for
+func canGive(role string) bool {
+	return role == "" || role == "all" || role == "given"
+}
+
+func canGuess(role string) bool {
+	return role == "" || role == "all" || role == "guess"
+}
+
+// flashcardsForArea returns all the cards for an area, or none if the area has no entity or
+// ordinal name to put on the cards
+func flashcardsForArea(area *quizArea) []flashcard {
+	if area.dataset == nil {
+		return nil
+	}
+
+	items := reflect.ValueOf(area.dataset).Elem()
+	switch {
+	case area.entity != "" && items.Type().Elem().Kind() == reflect.Struct:
+		entityFields := make([][]crossQueryField, 0, items.Len())
+		for i := 0; i < items.Len(); i++ {
+			entityFields = append(entityFields, crossQueryFields(items.Index(i).Interface()))
+		}
+		return crossQueryFlashcards(area.name, area.entity, entityFields)
+	case area.ordinal != "" && items.Type().Elem().Kind() == reflect.String:
+		return ordinalFlashcards(area.name, area.ordinal, items.Interface().([]string))
+	}
+	return nil
+}
+
+// crossQueryFlashcards makes a card for every given value of every field that can be given,
+// asking for every other field that can be guessed. Givens other entities share are skipped
+// the same way the quizzes skip them.
+func crossQueryFlashcards(areaName string, entityType string, entityFields [][]crossQueryField) []flashcard {
+	cards := make([]flashcard, 0)
+	for chosen := range entityFields {
+		fields := askableFields(entityFields, chosen)
+		for _, given := range fields {
+			if !canGive(given.role) {
+				continue
+			}
+			for _, guess := range fields {
+				if guess.name == given.name || !canGuess(guess.role) || len(guess.values) == 0 {
+					continue
+				}
+				tags := []string{areaName, "crossquery"}
+				for _, givenValue := range given.givenValues() {
+					card := flashcard{area: areaName, back: strings.Join(guess.values, ", "), tags: tags}
+					if len(guess.values) > 1 {
+						card.front = fmt.Sprintf("Name every %s of the %s with %s of %v", guess.name, entityType, given.name, givenValue)
+					} else {
+						card.front = fmt.Sprintf("What is the %s of the %s with %s of %v?", guess.name, entityType, given.name, givenValue)
+					}
+					cards = append(cards, card)
+				}
+			}
+		}
+	}
+	return cards
+}
+
+// ordinalFlashcards makes the cards quizIndexOfStringInList and quizStringAtIndexInList ask,
+// for every item in the list
+func ordinalFlashcards(areaName string, itemName string, items []string) []flashcard {
+	cards := make([]flashcard, 0, 2*len(items))
+	tags := []string{areaName, "ordinal"}
+	for index := range items {
+		for _, question := range []promptAndResponse{indexOfStringQuestion(items, index), stringAtIndexQuestion(itemName, items, index)} {
+			cards = append(cards, flashcard{area: areaName, front: question.prompt, back: question.response, tags: tags})
+		}
+	}
+	return cards
+}
+
+// flashcardAreas returns the areas named in args, or every area with cards if there are none
+func flashcardAreas(args []string) ([]*quizArea, error) {
+	areas := make([]*quizArea, 0)
+	if len(args) == 0 {
+		for _, area := range sortedQuizAreas() {
+			if area.dataset != nil && (area.entity != "" || area.ordinal != "") {
+				areas = append(areas, area)
+			}
+		}
+		return areas, nil
+	}
+
+	for _, name := range args {
+		area, found := findQuizArea(name)
+		if !found {
+			return nil, fmt.Errorf("There's no quiz area called %s", name)
+		}
+		if area.dataset == nil || (area.entity == "" && area.ordinal == "") {
+			return nil, fmt.Errorf("%s doesn't have any flashcards", area.name)
+		}
+		areas = append(areas, area)
+	}
+	return areas, nil
+}
+
+// writeFlashcards writes the cards as Anki-importable tab separated text or as csv
+func writeFlashcards(out io.Writer, cards []flashcard, format string) error {
+	switch format {
+	case "anki":
+		// Anki reads these headers when importing. Each area gets its own deck under derrick_tools.
+		fmt.Fprintln(out, "#separator:tab")
+		fmt.Fprintln(out, "#html:false")
+		fmt.Fprintln(out, "#tags column:3")
+		fmt.Fprintln(out, "#deck column:4")
+		// tabs and line breaks would start a new field or card, so they become spaces
+		clean := strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+		for _, card := range cards {
+			fmt.Fprintf(out, "%s\t%s\t%s\tderrick_tools::%s\n", clean.Replace(card.front), clean.Replace(card.back), strings.Join(card.tags, " "), card.area)
+		}
+		return nil
+	case "csv":
+		writer := csv.NewWriter(out)
+		writer.Write([]string{"area", "front", "back", "tags"})
+		for _, card := range cards {
+			writer.Write([]string{card.area, card.front, card.back, strings.Join(card.tags, " ")})
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+	return fmt.Errorf("Unknown format %s. Use anki or csv", format)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [area...]",
+	Short: "Export the quiz areas as flashcards",
+	Long: `Export every question the given areas (or all of them) can ask as flashcards: one card for
+each entity, given field, and guess field in the data, and the position of each item in ordered
+lists like the greek alphabet. The anki format is tab separated text Anki can import, with a
+deck for each area; csv is for other flashcard apps.
+
+  memoryquiz export states presidents --format anki -o cards.txt`,
+	Run: func(cmd *cobra.Command, args []string) {
+		exitOnDatasetLoadProblems()
+		areas, err := flashcardAreas(args)
+		if err == nil {
+			cards := make([]flashcard, 0)
+			for _, area := range areas {
+				cards = append(cards, flashcardsForArea(area)...)
+			}
+			err = writeToFile(flashcardOutput, func(out io.Writer) error { return writeFlashcards(out, cards, flashcardFormat) })
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	memoryquizCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVar(&flashcardFormat, "format", "anki", "anki or csv")
+	exportCmd.Flags().StringVarP(&flashcardOutput, "output", "o", "", "File to write the cards to, instead of the terminal")
+}
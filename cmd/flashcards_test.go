@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type flashcardTestCountry struct {
+	name     string   `crossquery:"all"`
+	capital  string   `crossquery:""`
+	currency string   `crossquery:"guess"`
+	cities   []string `crossquery:""`
+}
+
+func TestCrossQueryFlashcards(t *testing.T) {
+	entityFields := [][]crossQueryField{
+		crossQueryFields(flashcardTestCountry{"France", "Paris", "euro", []string{"Lyon", "Nice"}}),
+		crossQueryFields(flashcardTestCountry{"Spain", "Madrid", "euro", []string{"Seville"}}),
+	}
+	cards := crossQueryFlashcards("countries", "country", entityFields)
+
+	fronts := make(map[string]string)
+	for _, card := range cards {
+		fronts[card.front] = card.back
+	}
+	expected := map[string]string{
+		"What is the capital of the country with name of France?":  "Paris",
+		"What is the currency of the country with cities of Nice?": "euro",
+		"What is the cities of the country with name of Spain?":    "Seville",
+		"Name every cities of the country with capital of Paris":   "Lyon, Nice",
+	}
+	for front, back := range expected {
+		if fronts[front] != back {
+			t.Errorf("Expected a card %q -> %q but got %q", front, back, fronts[front])
+		}
+	}
+	for front := range fronts {
+		if strings.Contains(front, "currency of euro") {
+			t.Errorf("Unexpected card %q", front)
+		}
+	}
+	// the name, the capital, and each city ask for the 3 other fields
+	if len(cards) != (3+4)*3 {
+		t.Errorf("Expected 21 cards but got %d", len(cards))
+	}
+}
+
+func TestOrdinalFlashcards(t *testing.T) {
+	cards := ordinalFlashcards("greek", "greek letter", []string{"alpha", "beta"})
+	if len(cards) != 4 || cards[2].front != "What position is beta?" || cards[2].back != "2" || cards[3].front != "What greek letter is at position 2?" || cards[3].back != "beta" {
+		t.Errorf("Unexpected ordinal cards %v", cards)
+	}
+}
+
+func TestFlashcardAreas(t *testing.T) {
+	areas, err := flashcardAreas(nil)
+	if err != nil || len(areas) == 0 {
+		t.Fatalf("Expected areas with flashcards but got %v", err)
+	}
+	for _, area := range areas {
+		if len(flashcardsForArea(area)) == 0 {
+			t.Errorf("Expected flashcards for %s", area.name)
+		}
+	}
+	if _, err := flashcardAreas([]string{"spellingbee"}); err == nil {
+		t.Errorf("Expected an error for an area without flashcards")
+	}
+}
+
+func TestWriteFlashcards(t *testing.T) {
+	cards := []flashcard{{area: "greek", front: "What position is\talpha?", back: "1", tags: []string{"greek", "ordinal"}}}
+
+	var out bytes.Buffer
+	if err := writeFlashcards(&out, cards, "anki"); err != nil {
+		t.Fatalf("Could not write anki cards: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "#separator:tab\n") || !strings.HasSuffix(out.String(), "\nWhat position is alpha?\t1\tgreek ordinal\tderrick_tools::greek\n") {
+		t.Errorf("Unexpected anki cards:\n%s", out.String())
+	}
+
+	out.Reset()
+	cards[0].front = "Is it 1, 2?"
+	if err := writeFlashcards(&out, cards, "csv"); err != nil {
+		t.Fatalf("Could not write csv cards: %v", err)
+	}
+	if out.String() != "area,front,back,tags\ngreek,\"Is it 1, 2?\",1,greek ordinal\n" {
+		t.Errorf("Unexpected csv cards:\n%s", out.String())
+	}
+
+	if err := writeFlashcards(&out, cards, "apkg"); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
@@ -14,22 +14,22 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var footballCmd = &cobra.Command{
-	Use:   "football-teams",
-	Short: "Quiz US football teams",
-	Run:   quizFootballTeams,
-}
+var footballCmd = registerQuizArea(quizArea{
+	name:       "football-teams",
+	aliases:    []string{"football"},
+	tags:       []string{"sports"},
+	short:      "Quiz US football teams",
+	generators: footballTeamQuestions,
+	dataset:    &footballTeams,
+	entity:     "football team",
+})
 
 type footballTeam struct {
 	index int    `crossquery:"all"`
-	name  string `crossquery:"all"`
+	name  string `crossquery:"all" nameall:"name"`
 	// some areas, such as New York, have more than one team
 	area   string `crossquery:"guess"`
-	league string `crossquery:"guess"`
+	league string `crossquery:"guess" nameall:"in the %v"`
 }
 
 const (
@@ -80,21 +80,22 @@ var footballTeams = []footballTeam{
 
 type footballQuestion func([]footballTeam) promptAndResponse
 
-func quizFootballTeams(cmd *cobra.Command, args []string) {
+func footballTeamQuestions() []questionGenerator {
 
 	var promptFuncs = []footballQuestion{
 		crossQueryFootballTeamInfo,
+		crossQueryFootballTeamInfo,
+		crossQueryFootballTeamInfo,
+		quizNameAllFootballTeams,
 	}
 
-	function := randomItemFromSlice(promptFuncs)
-	promptAndCheckResponse(function(footballTeams))
+	return generatorsFor(promptFuncs, footballTeams)
 }
 
 func crossQueryFootballTeamInfo(teams []footballTeam) promptAndResponse {
-	foundTeam := randomItemFromSlice(teams)
-	return constructCrossQuery("football team", foundTeam)
+	return constructCrossQueryFromSlice("football team", teams)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(footballCmd)
+func quizNameAllFootballTeams(teams []footballTeam) promptAndResponse {
+	return quizNameAll("football team", teams)
 }
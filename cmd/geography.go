@@ -0,0 +1,325 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Location questions for any dataset with coordinates: which place is further north, which is
+// nearest to another, which borders another, and which is marked on a rough map. Like crossquery,
+// it's driven by struct tags: geo:"name" marks the field naming the place, and geo:"latitude" and
+// geo:"longitude" mark its coordinates, in degrees, with south and west negative.
+//
+//	type countryInfo struct {
+//	  capital   string  `geo:"name"`
+//	  latitude  float64 `geo:"latitude"`
+//	  longitude float64 `geo:"longitude"`
+//	}
+//
+// Every answer is worked out from the coordinates, so adding a place to a dataset (or a dataset
+// file) is all it takes to ask about it.
+
+const (
+	// earthRadius is the mean radius of the earth, in km
+	earthRadius = 6371.0
+	// geoMinimumSeparation is how many degrees apart two places must be to ask which is further
+	// in some direction, so that the answer doesn't hang on how precise the data is
+	geoMinimumSeparation = 0.5
+	// geoNearestMargin is how much closer than the runner up the nearest place must be to ask
+	// about it, for the same reason
+	geoNearestMargin = 0.8
+	// geoChoices is how many places the bordering questions offer
+	geoChoices = 4
+	// geoAttempts is how many times to look for places that make a fair question
+	geoAttempts = 50
+)
+
+type geoPoint struct {
+	latitude  float64
+	longitude float64
+}
+
+// geoPlace is a place as the location questions see it
+type geoPlace struct {
+	// names are the accepted answers for the place, the first being the one shown
+	names []string
+	point geoPoint
+}
+
+func (place geoPlace) name() string {
+	return place.names[0]
+}
+
+// geoPlaces reads the places out of a dataset with geo tags
+func geoPlaces[S ~[]E, E any](entities S) []geoPlace {
+	entityType := reflect.TypeOf(entities).Elem()
+	nameIndex, latitudeIndex, longitudeIndex := -1, -1, -1
+	for i := 0; i < entityType.NumField(); i++ {
+		switch geo := entityType.Field(i).Tag.Get("geo"); geo {
+		case "":
+			continue
+		case "name":
+			nameIndex = i
+		case "latitude":
+			latitudeIndex = i
+		case "longitude":
+			longitudeIndex = i
+		default:
+			panic(fmt.Sprintf("Invalid value for geo: %s", geo))
+		}
+	}
+	if nameIndex < 0 || latitudeIndex < 0 || longitudeIndex < 0 {
+		// this is effectively a syntax error, so kill the program
+		panic(fmt.Sprintf("%s needs geo:\"name\", geo:\"latitude\", and geo:\"longitude\" fields", entityType.Name()))
+	}
+
+	places := make([]geoPlace, 0, len(entities))
+	for _, entity := range entities {
+		value := reflect.ValueOf(entity)
+		places = append(places, geoPlace{
+			names: []string{value.Field(nameIndex).String()},
+			point: geoPoint{latitude: value.Field(latitudeIndex).Float(), longitude: value.Field(longitudeIndex).Float()},
+		})
+	}
+	return places
+}
+
+// distance is the great circle distance between two points, in km
+func distance(from, to geoPoint) float64 {
+	radians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	latitudeChange := radians(to.latitude - from.latitude)
+	longitudeChange := radians(to.longitude - from.longitude)
+	a := math.Sin(latitudeChange/2)*math.Sin(latitudeChange/2) +
+		math.Cos(radians(from.latitude))*math.Cos(radians(to.latitude))*math.Sin(longitudeChange/2)*math.Sin(longitudeChange/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// eastward is how many degrees east of from to is, going the short way around, so it's negative
+// for a point to the west
+func eastward(from, to geoPoint) float64 {
+	degrees := math.Mod(to.longitude-from.longitude, 360)
+	switch {
+	case degrees > 180:
+		degrees -= 360
+	case degrees < -180:
+		degrees += 360
+	}
+	return degrees
+}
+
+// compassDirection is a direction to compare places in. beyond is how many degrees further in
+// the direction one point is than another.
+type compassDirection struct {
+	name   string
+	beyond func(point, other geoPoint) float64
+}
+
+var compassDirections = []compassDirection{
+	{"north", func(point, other geoPoint) float64 { return point.latitude - other.latitude }},
+	{"south", func(point, other geoPoint) float64 { return other.latitude - point.latitude }},
+	{"east", func(point, other geoPoint) float64 { return eastward(other, point) }},
+	{"west", func(point, other geoPoint) float64 { return eastward(point, other) }},
+}
+
+// quizFurther asks which of two places is further in a random direction, as in "Which capital
+// is further north: Oslo or Ottawa?"
+func quizFurther(placeType string, places []geoPlace) promptAndResponse {
+	direction := compassDirections[quizRand.Intn(len(compassDirections))]
+	first, second := randomItemFromSlice(places), randomItemFromSlice(places)
+	for i := 0; i < geoAttempts && math.Abs(direction.beyond(first.point, second.point)) < geoMinimumSeparation; i++ {
+		first, second = randomItemFromSlice(places), randomItemFromSlice(places)
+	}
+	answer := first
+	if direction.beyond(second.point, first.point) > 0 {
+		answer = second
+	}
+	return promptAndResponse{
+		prompt:      fmt.Sprintf("Which %s is further %s: %s or %s?", placeType, direction.name, first.name(), second.name()),
+		response:    answer.name(),
+		aliases:     answer.names[1:],
+		distractors: []string{first.name(), second.name()},
+		closedSet:   true,
+		oneOff:      true,
+	}
+}
+
+// byDistance returns the places other than the one called name, nearest to point first
+func byDistance(point geoPoint, name string, places []geoPlace) []geoPlace {
+	sorted := make([]geoPlace, 0, len(places))
+	for _, place := range places {
+		if place.name() != name {
+			sorted = append(sorted, place)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return distance(point, sorted[i].point) < distance(point, sorted[j].point) })
+	return sorted
+}
+
+func placeNames(places []geoPlace) []string {
+	names := make([]string, 0, len(places))
+	for _, place := range places {
+		names = append(names, place.name())
+	}
+	return names
+}
+
+// quizNearest asks which of places is nearest to one of targets, as in "What's the nearest
+// capital to Lake Titicaca?" Targets can be the places themselves. The places next nearest are
+// the wrong choices.
+func quizNearest(targets []geoPlace, placeType string, places []geoPlace) promptAndResponse {
+	var target geoPlace
+	var nearest []geoPlace
+	for i := 0; i < geoAttempts; i++ {
+		target = randomItemFromSlice(targets)
+		nearest = byDistance(target.point, target.name(), places)
+		if len(nearest) < 2 || distance(target.point, nearest[0].point) < geoNearestMargin*distance(target.point, nearest[1].point) {
+			break
+		}
+	}
+	if len(nearest) > multipleChoiceCount+1 {
+		nearest = nearest[:multipleChoiceCount+1]
+	}
+	return promptAndResponse{
+		prompt:      fmt.Sprintf("What's the nearest %s to %s?", placeType, target.name()),
+		response:    nearest[0].name(),
+		aliases:     nearest[0].names[1:],
+		distractors: placeNames(nearest[1:]),
+	}
+}
+
+// quizWhichBorders asks which of a few places borders the place called name, as in "Which
+// country borders Lake Victoria: Uganda, Ethiopia, Rwanda, or Burundi?" neighbors are the names of
+// the places that do. The ones that don't are the nearest of the rest, so they're plausible.
+func quizWhichBorders(placeType string, name string, point geoPoint, neighbors []string, places []geoPlace) promptAndResponse {
+	bordering := make([]geoPlace, 0)
+	others := make([]geoPlace, 0)
+	for _, place := range byDistance(point, name, places) {
+		if isStringInSlice(place.name(), neighbors) {
+			bordering = append(bordering, place)
+		} else if len(others) < geoChoices-1 {
+			others = append(others, place)
+		}
+	}
+	if len(bordering) == 0 {
+		// nothing to choose, so ask for them all
+		return promptAndResponse{
+			prompt:   fmt.Sprintf("Name every %s that borders %s (separate them with commas)", placeType, name),
+			response: strings.Join(neighbors, ", "),
+			allOf:    neighbors,
+		}
+	}
+
+	answer := randomItemFromSlice(bordering)
+	choices := append(placeNames(others), answer.name())
+	quizRand.Shuffle(len(choices), func(i, j int) { choices[i], choices[j] = choices[j], choices[i] })
+	return promptAndResponse{
+		prompt:      fmt.Sprintf("Which %s borders %s: %s?", placeType, name, joinWithOr(choices)),
+		response:    answer.name(),
+		aliases:     answer.names[1:],
+		distractors: choices,
+		closedSet:   true,
+	}
+}
+
+// geoMap is the part of the world a map shows and how many characters it takes to show it
+type geoMap struct {
+	north, south, west, east float64
+	width, height            int
+}
+
+var (
+	worldMap      = geoMap{north: 80, south: -60, west: -180, east: 180, width: 72, height: 22}
+	usMap         = geoMap{north: 50, south: 24, west: -125, east: -66, width: 60, height: 16}
+	canadaMap     = geoMap{north: 72, south: 42, west: -141, east: -52, width: 60, height: 18}
+	californiaMap = geoMap{north: 42, south: 32.5, west: -124.5, east: -114, width: 36, height: 20}
+)
+
+// cell returns the row and column of point on the map, and whether it's on the map at all
+func (m geoMap) cell(point geoPoint) (int, int, bool) {
+	if point.latitude > m.north || point.latitude < m.south || point.longitude < m.west || point.longitude > m.east {
+		return 0, 0, false
+	}
+	row := int((m.north - point.latitude) / (m.north - m.south) * float64(m.height-1))
+	column := int((point.longitude - m.west) / (m.east - m.west) * float64(m.width-1))
+	return row, column, true
+}
+
+// draw draws the map with a dot for each of the places and an X for marked
+func (m geoMap) draw(places []geoPlace, marked geoPoint) string {
+	grid := make([][]rune, m.height)
+	for row := range grid {
+		grid[row] = []rune(strings.Repeat(" ", m.width))
+	}
+	if row, _, ok := m.cell(geoPoint{}); ok {
+		// the equator, for a bearing
+		for column := range grid[row] {
+			grid[row][column] = '-'
+		}
+	}
+	for _, place := range places {
+		if row, column, ok := m.cell(place.point); ok {
+			grid[row][column] = '.'
+		}
+	}
+	if row, column, ok := m.cell(marked); ok {
+		grid[row][column] = 'X'
+	}
+
+	var drawn strings.Builder
+	border := "+" + strings.Repeat("-", m.width) + "+\n"
+	drawn.WriteString(border)
+	for _, row := range grid {
+		drawn.WriteString("|" + string(row) + "|\n")
+	}
+	drawn.WriteString(border)
+	return drawn.String()
+}
+
+// quizMarkedOnMap draws the places on a map and asks which one is marked with an X. Only a
+// place with a spot on the map to itself is marked, and the wrong choices are the places nearest
+// to it.
+func quizMarkedOnMap(placeType string, places []geoPlace, m geoMap) promptAndResponse {
+	onMap := make([]geoPlace, 0, len(places))
+	placesInCell := make(map[[2]int]int)
+	for _, place := range places {
+		if row, column, ok := m.cell(place.point); ok {
+			onMap = append(onMap, place)
+			placesInCell[[2]int{row, column}]++
+		}
+	}
+	markable := make([]geoPlace, 0, len(onMap))
+	for _, place := range onMap {
+		if row, column, _ := m.cell(place.point); placesInCell[[2]int{row, column}] == 1 {
+			markable = append(markable, place)
+		}
+	}
+	marked := randomItemFromSlice(markable)
+	nearest := byDistance(marked.point, marked.name(), onMap)
+	if len(nearest) > multipleChoiceCount+1 {
+		nearest = nearest[:multipleChoiceCount+1]
+	}
+	return promptAndResponse{
+		prompt:      fmt.Sprintf("Which %s is marked with an X?\n%s", placeType, strings.TrimSuffix(m.draw(onMap, marked.point), "\n")),
+		response:    marked.name(),
+		aliases:     marked.names[1:],
+		distractors: placeNames(nearest),
+	}
+}
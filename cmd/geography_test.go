@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+type geographyTestCity struct {
+	name      string  `geo:"name"`
+	latitude  float64 `geo:"latitude"`
+	longitude float64 `geo:"longitude"`
+}
+
+var geographyTestCities = []geographyTestCity{
+	{"London", 51.51, -0.13},
+	{"Paris", 48.86, 2.35},
+	{"Madrid", 40.42, -3.70},
+	{"Rome", 41.90, 12.50},
+	{"Fiji", -18.14, 178.44},
+	{"Samoa", -13.83, -171.77},
+}
+
+func TestDistance(t *testing.T) {
+	places := geoPlaces(geographyTestCities)
+	if km := distance(places[0].point, places[1].point); km < 330 || km > 360 {
+		t.Errorf("Expected London to be about 344km from Paris but got %v", km)
+	}
+	if km := distance(places[4].point, places[5].point); km > 1200 {
+		t.Errorf("Expected Fiji and Samoa to be close across the date line but got %v", km)
+	}
+}
+
+func TestEastward(t *testing.T) {
+	places := geoPlaces(geographyTestCities)
+	if degrees := eastward(places[0].point, places[3].point); degrees <= 0 {
+		t.Errorf("Expected Rome to be east of London but got %v", degrees)
+	}
+	// the short way from Fiji to Samoa crosses the date line
+	if degrees := eastward(places[4].point, places[5].point); degrees <= 0 || degrees > 20 {
+		t.Errorf("Expected Samoa to be a little east of Fiji but got %v", degrees)
+	}
+}
+
+func TestQuizFurther(t *testing.T) {
+	seedQuizRand(1)
+	places := geoPlaces(geographyTestCities[:4])
+	for i := 0; i < 50; i++ {
+		question := quizFurther("city", places)
+		if question.prompt == "Which city is further north: London or Madrid?" && question.response != "London" {
+			t.Errorf("Expected London to be further north than Madrid but got %s", question.response)
+		}
+		if question.prompt == "Which city is further west: Rome or Paris?" && question.response != "Paris" {
+			t.Errorf("Expected Paris to be further west than Rome but got %s", question.response)
+		}
+		if strings.Contains(question.prompt, "London or Paris") && strings.Contains(question.prompt, "west") && question.response != "London" {
+			t.Errorf("Expected London to be further west than Paris but got %s", question.response)
+		}
+	}
+}
+
+func TestQuizNearest(t *testing.T) {
+	seedQuizRand(2)
+	places := geoPlaces(geographyTestCities[:4])
+	for i := 0; i < 20; i++ {
+		question := quizNearest(places[:1], "city", places)
+		if question.prompt != "What's the nearest city to London?" || question.response != "Paris" {
+			t.Fatalf("Unexpected question %q -> %q", question.prompt, question.response)
+		}
+		if isStringInSlice("London", question.distractors) || isStringInSlice("Paris", question.distractors) {
+			t.Errorf("Expected only the other cities as distractors but got %v", question.distractors)
+		}
+	}
+}
+
+func TestQuizWhichBorders(t *testing.T) {
+	seedQuizRand(3)
+	for i := 0; i < 20; i++ {
+		question := quizCountryBordersLake(lakes[2:3])
+		if !strings.HasPrefix(question.prompt, "Which country borders Lake Victoria: ") || !isStringInSlice(question.response, lakes[2].countries) {
+			t.Fatalf("Unexpected question %q -> %q", question.prompt, question.response)
+		}
+		if len(question.distractors) != geoChoices {
+			t.Errorf("Expected %d choices but got %v", geoChoices, question.distractors)
+		}
+		for _, choice := range question.distractors {
+			if choice != question.response && isStringInSlice(choice, lakes[2].countries) {
+				t.Errorf("%s borders Lake Victoria, so it can't be a wrong choice", choice)
+			}
+		}
+	}
+}
+
+func TestQuizCountryBordersLakeSkipsVostok(t *testing.T) {
+	seedQuizRand(5)
+	vostokAndVictoria := []lakeInfo{lakes[16], lakes[2]}
+	for i := 0; i < 20; i++ {
+		if question := quizCountryBordersLake(vostokAndVictoria); strings.Contains(question.prompt, "Vostok") {
+			t.Fatalf("Expected Lake Vostok to be left out but got %q", question.prompt)
+		}
+	}
+
+	question := quizWhichBorders("country", "Atlantis", geoPoint{}, []string{"Lemuria", "Mu"}, geoPlaces(geographyTestCities))
+	if len(question.allOf) != 2 || matchAnswer("Mu, Lemuria", question) != answerCorrect {
+		t.Errorf("Expected the neighbors to be accepted in any order but got %+v", question)
+	}
+}
+
+func TestQuizMarkedOnMap(t *testing.T) {
+	seedQuizRand(4)
+	question := quizMarkedOnMap("state capital", geoPlaces(states), usMap)
+	lines := strings.Split(question.prompt, "\n")
+	if lines[0] != "Which state capital is marked with an X?" || len(lines) != usMap.height+3 || strings.Count(question.prompt, "X") != 2 {
+		t.Fatalf("Unexpected map:\n%s", question.prompt)
+	}
+	if question.response == "Juneau" || question.response == "Honolulu" {
+		t.Errorf("Expected only capitals on the map to be marked but got %s", question.response)
+	}
+	for _, line := range lines[1:] {
+		if len([]rune(line)) != usMap.width+2 {
+			t.Errorf("Expected every line to be %d wide: %q", usMap.width+2, line)
+		}
+	}
+}
+
+func TestGeoDatasets(t *testing.T) {
+	all := [][]geoPlace{geoPlaces(countries), geoPlaces(states), geoPlaces(canadianRegions), geoPlaces(caCounties), geoPlaces(lakes), geoPlaces(rivers)}
+	for _, places := range all {
+		for _, place := range places {
+			if place.point.latitude < -90 || place.point.latitude > 90 || place.point.longitude < -180 || place.point.longitude > 180 || place.point == (geoPoint{}) {
+				t.Errorf("%s has bad coordinates %v", place.name(), place.point)
+			}
+		}
+	}
+
+	countryNames := placeNames(countryPlaces(countries))
+	for _, lake := range lakes {
+		for _, country := range lake.countries {
+			if country != "Antarctica" && !isStringInSlice(country, countryNames) {
+				t.Errorf("Lake %s touches %s, which isn't in the countries", lake.name, country)
+			}
+		}
+	}
+}
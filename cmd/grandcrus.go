@@ -15,20 +15,16 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"fmt"
-	"math/rand"
-	"strings"
-
-	"github.com/spf13/cobra"
-)
-
 // countriesCmd represents the countries command
-var grandCrusCmd = &cobra.Command{
-	Use:   "grand-crus",
-	Short: "Memory quizzesa about Burgundy Grand Crus",
-	Run:   quizGrandCrus,
-}
+var grandCrusCmd = registerQuizArea(quizArea{
+	name:       "grand-crus",
+	aliases:    []string{"grandcrus"},
+	tags:       []string{"wine"},
+	short:      "Memory quizzesa about Burgundy Grand Crus",
+	generators: grandCruQuestions,
+	dataset:    &grandCrus,
+	entity:     "Grand Cru",
+})
 
 const (
 	GEVREY  string = "Gevrey-Chambertin"
@@ -44,8 +40,8 @@ const (
 
 type grandCru struct {
 	order   int    `crossquery:"all"`
-	name    string `crossquery:"guess"`
-	village string `crossquery:"guess"`
+	name    string `crossquery:"guess" nameall:"name"`
+	village string `crossquery:"guess" nameall:"near %v"`
 }
 
 var grandCrus = []grandCru{
@@ -88,28 +84,21 @@ var grandCrus = []grandCru{
 	{37, "Criots-Bâtard-Montrachet", "Chassagne-Montrachet"},
 }
 
-func quizGrandCrus(cmd *cobra.Command, args []string) {
-	randNumber := rand.Intn(10)
-	if randNumber == 0 {
-		promptAndCheckResponse(quizVineyardsForVillage(grandCrus))
-	} else {
-		grandCru := grandCrus[rand.Intn(len(grandCrus))]
-		promptAndCheckResponse(constructCrossQuery("Grand Cru", grandCru))
+type grandCruQuestion func([]grandCru) promptAndResponse
+
+func grandCruQuestions() []questionGenerator {
+	// one time in ten, ask for all the vineyards of a village
+	promptFuncs := []grandCruQuestion{quizVineyardsForVillage}
+	for i := 0; i < 9; i++ {
+		promptFuncs = append(promptFuncs, crossQueryGrandCru)
 	}
+	return generatorsFor(promptFuncs, grandCrus)
 }
 
-func quizVineyardsForVillage(crus []grandCru) promptAndResponse {
-	randomCru := randomItemFromSlice(crus)
-	village := randomCru.village
-	vineyardNames := []string{}
-	for _, cru := range crus {
-		if cru.village == village {
-			vineyardNames = append(vineyardNames, cru.name)
-		}
-	}
-	return promptAndResponse{fmt.Sprintf("Name all the vineyards near %s", village), strings.Join(vineyardNames, ",")}
+func crossQueryGrandCru(crus []grandCru) promptAndResponse {
+	return constructCrossQueryFromSlice("Grand Cru", crus)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(grandCrusCmd)
+func quizVineyardsForVillage(crus []grandCru) promptAndResponse {
+	return quizNameAll("Grand Cru", crus)
 }
@@ -17,17 +17,18 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
-
-	"github.com/spf13/cobra"
 )
 
 // greekCmd represents the greek command
-var greekCmd = &cobra.Command{
-	Use:   "greek",
-	Short: "Test memory of the Greek alphabet",
-	Run:   quizGreekAlphabet,
-}
+var greekCmd = registerQuizArea(quizArea{
+	name:       "greek",
+	aliases:    []string{"greek-alphabet"},
+	tags:       []string{"language"},
+	short:      "Test memory of the Greek alphabet",
+	generators: greekAlphabetQuestions,
+	dataset:    &greekAlphabet,
+	ordinal:    "greek letter",
+})
 
 var greekAlphabet = []string{
 	"alpha",
@@ -58,16 +59,20 @@ var greekAlphabet = []string{
 
 type quizGreekFunc func([]string) promptAndResponse
 
-func quizGreekAlphabet(cmd *cobra.Command, args []string) {
+func greekAlphabetQuestions() []questionGenerator {
 	funcs := []quizGreekFunc{
 		quizPositionFromLetter,
 		quizLetterFromPosition,
 		quizLetterBefore,
 		quizLetterAfter,
+		quizReciteLetters,
 	}
 
-	function := randomItemFromSlice(funcs)
-	promptAndCheckResponse(function(greekAlphabet))
+	return generatorsFor(funcs, greekAlphabet)
+}
+
+func quizReciteLetters(alphabet []string) promptAndResponse {
+	return quizReciteList("greek letters", alphabet)
 }
 
 func quizPositionFromLetter(alphabet []string) promptAndResponse {
@@ -81,16 +86,12 @@ func quizLetterFromPosition(alphabet []string) promptAndResponse {
 func quizLetterBefore(alphabet []string) promptAndResponse {
 	index := 0
 	for index == 0 {
-		index = rand.Intn(len(alphabet))
+		index = quizRand.Intn(len(alphabet))
 	}
-	return promptAndResponse{fmt.Sprintf("What letter comes before %s?", alphabet[index]), alphabet[index-1]}
+	return promptAndResponse{prompt: fmt.Sprintf("What letter comes before %s?", alphabet[index]), response: alphabet[index-1]}
 }
 
 func quizLetterAfter(alphabet []string) promptAndResponse {
-	index := rand.Intn(len(alphabet) - 1) // -1 to ensure we don't get the last item
-	return promptAndResponse{fmt.Sprintf("What letter comes after %s?", alphabet[index]), alphabet[index+1]}
-}
-
-func init() {
-	memoryquizCmd.AddCommand(greekCmd)
+	index := quizRand.Intn(len(alphabet) - 1) // -1 to ensure we don't get the last item
+	return promptAndResponse{prompt: fmt.Sprintf("What letter comes after %s?", alphabet[index]), response: alphabet[index+1]}
 }
@@ -15,16 +15,16 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
 // hebrewCmd represents the hebrew command
-var hebrewCmd = &cobra.Command{
-	Use:   "hebrew",
-	Short: "Quiz command of hebrew alphabet",
-	Run:   quizHebrewAlphabet,
-}
+var hebrewCmd = registerQuizArea(quizArea{
+	name:       "hebrew",
+	aliases:    []string{"hebrew-alphabet"},
+	tags:       []string{"language", "judaica"},
+	short:      "Quiz command of hebrew alphabet",
+	generators: hebrewAlphabetQuestions,
+	dataset:    &hebrewAlphabet,
+	ordinal:    "hebrew letter",
+})
 
 var hebrewAlphabet = []string{
 	"aleph",
@@ -53,7 +53,7 @@ var hebrewAlphabet = []string{
 
 type quizHebrewFunc func([]string) promptAndResponse
 
-func quizHebrewAlphabet(cmd *cobra.Command, args []string) {
+func hebrewAlphabetQuestions() []questionGenerator {
 	funcs := []quizHebrewFunc{
 		quizPositionFromLetter,
 		quizHebrewLetterFromPosition,
@@ -61,14 +61,9 @@ func quizHebrewAlphabet(cmd *cobra.Command, args []string) {
 		quizLetterAfter,
 	}
 
-	function := randomItemFromSlice(funcs)
-	promptAndCheckResponse(function(hebrewAlphabet))
+	return generatorsFor(funcs, hebrewAlphabet)
 }
 
 func quizHebrewLetterFromPosition(alphabet []string) promptAndResponse {
 	return quizStringAtIndexInList("hebrew letter", alphabet)
 }
-
-func init() {
-	memoryquizCmd.AddCommand(hebrewCmd)
-}
@@ -14,15 +14,14 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var hebrewCalendarCmd = &cobra.Command{
-	Use:   "hebrew-calendar",
-	Short: "Quiz Hebrew Calendar",
-	Run:   quizHebrewCalendar,
-}
+var hebrewCalendarCmd = registerQuizArea(quizArea{
+	name:       "hebrew-calendar",
+	tags:       []string{"judaica", "calendar"},
+	short:      "Quiz Hebrew Calendar",
+	generators: hebrewCalendarQuestions,
+	dataset:    &hebrewMonths,
+	entity:     "Hebrew calendar",
+})
 
 type hebrewCalendar struct {
 	index          int    `crossquery:"all" crossqueryname:"index"`
@@ -47,15 +46,11 @@ var hebrewMonths = []hebrewCalendar{
 
 type hebrewCalendarQuestion func([]hebrewCalendar) promptAndResponse
 
-func quizHebrewCalendar(cmd *cobra.Command, args []string) {
-	promptAndCheckResponse(crossQueryHebrewCalendar(hebrewMonths))
+func hebrewCalendarQuestions() []questionGenerator {
+	promptFuncs := []hebrewCalendarQuestion{crossQueryHebrewCalendar}
+	return generatorsFor(promptFuncs, hebrewMonths)
 }
 
 func crossQueryHebrewCalendar(months []hebrewCalendar) promptAndResponse {
-	foundMonth := randomItemFromSlice(months)
-	return constructCrossQuery("Hebrew calendar", foundMonth)
-}
-
-func init() {
-	memoryquizCmd.AddCommand(hebrewCalendarCmd)
+	return constructCrossQueryFromSlice("Hebrew calendar", months)
 }
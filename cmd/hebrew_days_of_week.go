@@ -14,15 +14,14 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var hebrewWeekCmd = &cobra.Command{
-	Use:   "hebrew-week",
-	Short: "Quiz Hebrew days of week",
-	Run:   quizHebrewWeek,
-}
+var hebrewWeekCmd = registerQuizArea(quizArea{
+	name:       "hebrew-week",
+	tags:       []string{"judaica", "calendar"},
+	short:      "Quiz Hebrew days of week",
+	generators: hebrewWeekQuestions,
+	dataset:    &hebrewWeek,
+	entity:     "Hebrew day",
+})
 
 type hebrewDayOfWeek struct {
 	index        int    `crossquery:"all" crossqueryname:"index"`
@@ -40,15 +39,11 @@ var hebrewWeek = []hebrewDayOfWeek{
 	{7, "Shabbat", "Saturday"},
 }
 
-func quizHebrewWeek(cmd *cobra.Command, args []string) {
-	promptAndCheckResponse(crossQueryHebrewWeek(hebrewWeek))
+func hebrewWeekQuestions() []questionGenerator {
+	promptFuncs := []func([]hebrewDayOfWeek) promptAndResponse{crossQueryHebrewWeek}
+	return generatorsFor(promptFuncs, hebrewWeek)
 }
 
 func crossQueryHebrewWeek(daysOfWeek []hebrewDayOfWeek) promptAndResponse {
-	foundDay := randomItemFromSlice(hebrewWeek)
-	return constructCrossQuery("Hebrew day", foundDay)
-}
-
-func init() {
-	memoryquizCmd.AddCommand(hebrewWeekCmd)
+	return constructCrossQueryFromSlice("Hebrew day", daysOfWeek)
 }
@@ -14,15 +14,15 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var httpCmd = &cobra.Command{
-	Use:   "http-codes",
-	Short: "Quiz HTTP Error Codes",
-	Run:   quizHttpCodes,
-}
+var httpCmd = registerQuizArea(quizArea{
+	name:       "http-codes",
+	aliases:    []string{"http-code"},
+	tags:       []string{"tech"},
+	short:      "Quiz HTTP Error Codes",
+	generators: httpCodeQuestions,
+	dataset:    &httpCodes,
+	entity:     "HTTP",
+})
 
 type httpCode struct {
 	code    int    `crossquery:"all"`
@@ -57,21 +57,15 @@ var httpCodes = []httpCode{
 
 type httpCodeQuestion func([]httpCode) promptAndResponse
 
-func quizHttpCodes(cmd *cobra.Command, args []string) {
+func httpCodeQuestions() []questionGenerator {
 
 	var promptFuncs = []httpCodeQuestion{
 		crossQueryHttpCodeInfo,
 	}
 
-	function := randomItemFromSlice(promptFuncs)
-	promptAndCheckResponse(function(httpCodes))
+	return generatorsFor(promptFuncs, httpCodes)
 }
 
 func crossQueryHttpCodeInfo(codes []httpCode) promptAndResponse {
-	foundCode := randomItemFromSlice(codes)
-	return constructCrossQuery("HTTP", foundCode)
-}
-
-func init() {
-	memoryquizCmd.AddCommand(httpCmd)
+	return constructCrossQueryFromSlice("HTTP", codes)
 }
@@ -17,111 +17,177 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"strconv"
-
-	"github.com/spf13/cobra"
+	"strings"
 )
 
 // shakespeareCmd represents the shakespeare command
-var lakesCmd = &cobra.Command{
-	Use:   "lakes",
-	Short: "Test recall of the names, sizes, and salinity of the world's largest lakes",
-	Run:   quizLakes,
-}
+var lakesCmd = registerQuizArea(quizArea{
+	name:       "lakes",
+	tags:       []string{"geography"},
+	short:      "Test recall of the names, sizes, and salinity of the world's largest lakes",
+	generators: lakeQuestions,
+	dataset:    &lakes,
+})
 
 type lakeInfo struct {
-	sizeOrder int
-	name      string
+	sizeOrder int    `rank:"desc" rankwords:"is bigger,is smaller,is the largest,is the smallest"`
+	name      string `rank:"name" nameall:"name" geo:"name"`
 	isSaline  bool
-	countries []string
+	countries []string `nameall:"in %v"`
+	// latitude and longitude are roughly the middle of the lake
+	latitude  float64 `geo:"latitude"`
+	longitude float64 `geo:"longitude"`
 }
 
 var lakes = []lakeInfo{
-	{1, "Caspian Sea", true, []string{"Russia", "Kazakhstan", "Turkmenistan", "Iran", "Azerbaijan"}},
-	{2, "Superior", false, []string{"Canada", "United States"}},
-	{3, "Victoria", false, []string{"Kenya", "Tanzania", "Uganda"}},
-	{4, "Huron", false, []string{"Canada", "United States"}},
-	{5, "Michigan", false, []string{"United States"}},
-	{6, "Tanganyika", false, []string{"Burundi", "Zambia", "Democratic Republic of the Congo", "Tanzania"}},
-	{7, "Baikal", false, []string{"Russia"}},
-	{8, "Great Bear Lake", false, []string{"Canada"}},
-	{9, "Malawi", false, []string{"Mozambique", "Tanzania"}},
-	{10, "Great Slave Lake", false, []string{"Canada"}},
-	{11, "Erie", false, []string{"Canada", "United States"}},
-	{12, "Winnipeg", false, []string{"Canada"}},
-	{13, "Ontario", false, []string{"Canada", "United States"}},
-	{14, "Ladoga", false, []string{"Russia"}},
-	{15, "Balkhash", true, []string{"Kazakhstan"}},
-	{16, "Bangweulu", false, []string{"Zambia"}},
-	{17, "Vostok", false, []string{"Antarctica"}},
-	{18, "Onega", false, []string{"Russia"}},
-	{19, "Titicaca", false, []string{"Bolivia", "Peru"}},
-	{20, "Nicaragua", false, []string{"Nicaragua"}},
-	{21, "Athabasca", false, []string{"Canada"}},
-	{22, "Turkana", true, []string{"Kenya", "Ethiopia"}},
-	{23, "Reindeer Lake", false, []string{"Canada"}},
-	{24, "Issyk-Kul", true, []string{"Kyrgyzstan"}},
-	{25, "Urmia", true, []string{"Iran"}},
-	{26, "Vanern", false, []string{"Sweden"}},
-	{27, "Winnipegosis", false, []string{"Canada"}},
-	{28, "Albert", false, []string{"Uganda", "Democratic Republic of the Congo"}},
-	{29, "Mweru", false, []string{"Zambia"}},
-	{30, "Nettilling", false, []string{"Canada"}},
-	{31, "Nipigon", false, []string{"Canada"}},
-	{32, "Manitoba", false, []string{"Canada"}},
-	{33, "Taymyr", false, []string{"Russia"}},
-	{34, "Qinghai Lake", true, []string{"China"}},
-	{35, "Saimaa", false, []string{"Finland"}},
-	{36, "Lake of the Woods", false, []string{"Canada", "United States"}},
-	{37, "Khanka", false, []string{"China"}},
-	{38, "Sarygamyish", false, []string{"Uzbekistan", "Turkmenistan"}},
-	{39, "Dubawnt", false, []string{"Canada"}},
-	{40, "Van", true, []string{"Turkey"}},
-	{41, "Peipus", false, []string{"Russia", "Estonia"}},
-	{42, "Uvs", true, []string{"Mongolia"}},
-	{43, "Poyang", false, []string{"China"}},
-	{44, "Tana", false, []string{"Ethiopia"}},
-	{45, "Amadjuak", false, []string{"Canada"}},
-	{46, "Melville", true, []string{"Canada"}},
+	{1, "Caspian Sea", true, []string{"Russia", "Kazakhstan", "Turkmenistan", "Iran", "Azerbaijan"}, 41.7, 50.6},
+	{2, "Superior", false, []string{"Canada", "United States"}, 47.7, -87.5},
+	{3, "Victoria", false, []string{"Kenya", "Tanzania", "Uganda"}, -1.0, 33.0},
+	{4, "Huron", false, []string{"Canada", "United States"}, 44.8, -82.4},
+	{5, "Michigan", false, []string{"United States"}, 44.0, -87.0},
+	{6, "Tanganyika", false, []string{"Burundi", "Zambia", "Democratic Republic of Congo", "Tanzania"}, -6.5, 29.9},
+	{7, "Baikal", false, []string{"Russia"}, 53.5, 108.2},
+	{8, "Great Bear Lake", false, []string{"Canada"}, 66.0, -121.0},
+	{9, "Malawi", false, []string{"Malawi", "Mozambique", "Tanzania"}, -12.0, 34.6},
+	{10, "Great Slave Lake", false, []string{"Canada"}, 61.7, -114.0},
+	{11, "Erie", false, []string{"Canada", "United States"}, 42.2, -81.2},
+	{12, "Winnipeg", false, []string{"Canada"}, 52.1, -97.3},
+	{13, "Ontario", false, []string{"Canada", "United States"}, 43.7, -77.9},
+	{14, "Ladoga", false, []string{"Russia"}, 60.8, 31.5},
+	{15, "Balkhash", true, []string{"Kazakhstan"}, 46.5, 74.9},
+	{16, "Bangweulu", false, []string{"Zambia"}, -11.1, 29.8},
+	{17, "Vostok", false, []string{"Antarctica"}, -77.5, 106.0},
+	{18, "Onega", false, []string{"Russia"}, 61.7, 35.4},
+	{19, "Titicaca", false, []string{"Bolivia", "Peru"}, -15.9, -69.3},
+	{20, "Nicaragua", false, []string{"Nicaragua"}, 11.6, -85.4},
+	{21, "Athabasca", false, []string{"Canada"}, 59.3, -109.4},
+	{22, "Turkana", true, []string{"Kenya", "Ethiopia"}, 3.6, 36.1},
+	{23, "Reindeer Lake", false, []string{"Canada"}, 57.3, -102.3},
+	{24, "Issyk-Kul", true, []string{"Kyrgyzstan"}, 42.4, 77.3},
+	{25, "Urmia", true, []string{"Iran"}, 37.7, 45.3},
+	{26, "Vanern", false, []string{"Sweden"}, 58.9, 13.2},
+	{27, "Winnipegosis", false, []string{"Canada"}, 52.5, -100.0},
+	{28, "Albert", false, []string{"Uganda", "Democratic Republic of Congo"}, 1.7, 30.9},
+	{29, "Mweru", false, []string{"Zambia", "Democratic Republic of Congo"}, -9.0, 28.7},
+	{30, "Nettilling", false, []string{"Canada"}, 66.5, -70.3},
+	{31, "Nipigon", false, []string{"Canada"}, 49.8, -88.5},
+	{32, "Manitoba", false, []string{"Canada"}, 51.0, -98.8},
+	{33, "Taymyr", false, []string{"Russia"}, 74.5, 102.5},
+	{34, "Qinghai Lake", true, []string{"China"}, 36.9, 100.2},
+	{35, "Saimaa", false, []string{"Finland"}, 61.2, 28.2},
+	{36, "Lake of the Woods", false, []string{"Canada", "United States"}, 49.3, -94.8},
+	{37, "Khanka", false, []string{"China", "Russia"}, 45.0, 132.4},
+	{38, "Sarygamyish", false, []string{"Uzbekistan", "Turkmenistan"}, 41.9, 57.4},
+	{39, "Dubawnt", false, []string{"Canada"}, 63.1, -101.5},
+	{40, "Van", true, []string{"Turkey"}, 38.6, 42.9},
+	{41, "Peipus", false, []string{"Russia", "Estonia"}, 58.7, 27.5},
+	{42, "Uvs", true, []string{"Mongolia", "Russia"}, 50.3, 92.7},
+	{43, "Poyang", false, []string{"China"}, 29.1, 116.3},
+	{44, "Tana", false, []string{"Ethiopia"}, 12.0, 37.3},
+	{45, "Amadjuak", false, []string{"Canada"}, 65.0, -71.1},
+	{46, "Melville", true, []string{"Canada"}, 53.7, -59.5},
 }
 
 type lakeQuiz func([]lakeInfo) promptAndResponse
 
-func quizLakes(cmd *cobra.Command, args []string) {
+func lakeQuestions() []questionGenerator {
 	quizzes := []lakeQuiz{
 		quizLakeBySizeRank,
 		quizSizeByLake,
 		quizLakeSalinity,
 		quizLakeInCountry,
+		quizRankLakes,
+		quizNameAllLakes,
+		quizLakeFurther,
+		quizNearestCapitalToLake,
+		quizCountryBordersLake,
+		quizLakeOnMap,
 	}
 
-	quiz := randomItemFromSlice(quizzes)
-	promptAndCheckResponse(quiz(lakes))
+	return generatorsFor(quizzes, lakes)
 }
 
 func quizLakeBySizeRank(lakes []lakeInfo) promptAndResponse {
 	lake := randomItemFromSlice(lakes)
-	return promptAndResponse{fmt.Sprintf("What is the name of the lake at position %d", lake.sizeOrder), lake.name}
+	return promptAndResponse{prompt: fmt.Sprintf("What is the name of the lake at position %d", lake.sizeOrder), response: lake.name}
 }
 
 func quizSizeByLake(lakes []lakeInfo) promptAndResponse {
 	lake := randomItemFromSlice(lakes)
-	return promptAndResponse{fmt.Sprintf("What is the size rank of lake %s?", lake.name), strconv.Itoa(lake.sizeOrder)}
+	return promptAndResponse{prompt: fmt.Sprintf("What is the size rank of lake %s?", lake.name), response: strconv.Itoa(lake.sizeOrder)}
 }
 
 func quizLakeSalinity(lakes []lakeInfo) promptAndResponse {
 	lake := randomItemFromSlice(lakes)
-	return promptAndResponse{fmt.Sprintf("%s is a saline lake, true or false?", lake.name), strconv.FormatBool(lake.isSaline)}
+	return promptAndResponse{prompt: fmt.Sprintf("%s is a saline lake, true or false?", lake.name), response: strconv.FormatBool(lake.isSaline)}
 }
 
 func quizLakeInCountry(lakes []lakeInfo) promptAndResponse {
 	lake1 := randomItemFromSlice(lakes)
-	country := lake1.countries[rand.Intn(len(lake1.countries))]
+	country := lake1.countries[quizRand.Intn(len(lake1.countries))]
 	lake2 := randomItemFromSlice(lakes)
-	return promptAndResponse{fmt.Sprintf("Lake %s touches %s, true or false?", lake2.name, country), strconv.FormatBool(isStringInSlice(country, lake2.countries))}
+	return promptAndResponse{prompt: fmt.Sprintf("Lake %s touches %s, true or false?", lake2.name, country), response: strconv.FormatBool(isStringInSlice(country, lake2.countries))}
+}
+
+func quizRankLakes(lakes []lakeInfo) promptAndResponse {
+	return quizRanked("lake", lakes)
+}
+
+func quizNameAllLakes(lakes []lakeInfo) promptAndResponse {
+	return quizNameAll("lake", lakes)
+}
+
+// lakeTitle is how to refer to a lake, as in Lake Victoria or the Caspian Sea
+func lakeTitle(name string) string {
+	switch {
+	case strings.HasSuffix(name, " Sea"):
+		return "the " + name
+	case strings.Contains(name, "Lake"):
+		return name
+	}
+	return "Lake " + name
+}
+
+// lakePlaces are the lakes by title, also accepting the bare name
+func lakePlaces(lakes []lakeInfo) []geoPlace {
+	places := geoPlaces(lakes)
+	for index, lake := range lakes {
+		if title := lakeTitle(lake.name); title != lake.name {
+			places[index].names = []string{title, lake.name}
+		}
+	}
+	return places
+}
+
+func quizLakeFurther(lakes []lakeInfo) promptAndResponse {
+	return quizFurther("lake", lakePlaces(lakes))
+}
+
+func quizNearestCapitalToLake(lakes []lakeInfo) promptAndResponse {
+	return quizNearest(lakePlaces(lakes), "capital", geoPlaces(countries))
+}
+
+// quizCountryBordersLake leaves out lakes like Vostok, which has no countries around it
+func quizCountryBordersLake(lakes []lakeInfo) promptAndResponse {
+	places := countryPlaces(countries)
+	names := placeNames(places)
+	bordered := make([]lakeInfo, 0, len(lakes))
+	for _, lake := range lakes {
+		for _, country := range lake.countries {
+			if isStringInSlice(country, names) {
+				bordered = append(bordered, lake)
+				break
+			}
+		}
+	}
+	if len(bordered) == 0 {
+		bordered = lakes
+	}
+	lake := randomItemFromSlice(bordered)
+	return quizWhichBorders("country", lakeTitle(lake.name), geoPoint{lake.latitude, lake.longitude}, lake.countries, places)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(lakesCmd)
+func quizLakeOnMap(lakes []lakeInfo) promptAndResponse {
+	return quizMarkedOnMap("lake", lakePlaces(lakes), worldMap)
 }
@@ -0,0 +1,169 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Learn mode is for learning an ordered list, like Shakespeare's plays, from scratch, rather than
+// being quizzed on a list already learned. It shows the list a chunk at a time and after each
+// chunk asks for every position learned so far, so the earlier chunks get reviewed as the list
+// grows. Once enough of them are right it goes on to the next chunk, and otherwise it shows the
+// list again and asks again.
+
+var learnChunkSize int
+var learnThreshold float64
+var learnStart int
+
+// learner walks a list, asking with ask and showing chunks with show, so tests can stand in for the user
+type learner struct {
+	itemName  string
+	items     []string
+	chunkSize int
+	threshold float64
+	ask       func(promptAndResponse) answerMatch
+	show      func(text string, instructions string)
+}
+
+// learnFrom works through the list from position start (counting from 1), stopping early if
+// stdin is closed. It returns how many items have been learned.
+func (learning learner) learnFrom(start int) int {
+	learned := start - 1
+	for learned < len(learning.items) && !stdinClosed {
+		end := learned + learning.chunkSize
+		if end > len(learning.items) {
+			end = len(learning.items)
+		}
+		learning.show(formatLearnList(learning.items, learned, end, nil), "Press enter when you're ready to be tested")
+
+		for !stdinClosed {
+			missed := learning.recall(end)
+			if stdinClosed {
+				return learned
+			}
+			if recallPassed(end-len(missed), end, learning.threshold) {
+				quizPrintf("You got %d of %d right. On to the next chunk!\n\n", end-len(missed), end)
+				break
+			}
+			quizPrintf("You got %d of %d right. Have another look.\n\n", end-len(missed), end)
+			learning.show(formatLearnList(learning.items, 0, end, missed), "Press enter when you're ready to try again")
+		}
+		learned = end
+	}
+	if learned == len(learning.items) {
+		quizPrintf("You've learned all %d!\n", len(learning.items))
+	}
+	return learned
+}
+
+// recall asks for each of the first end items in order, returning the indexes of the ones missed
+func (learning learner) recall(end int) []int {
+	missed := make([]int, 0)
+	for index := 0; index < end && !stdinClosed; index++ {
+		if !learning.ask(stringAtIndexQuestion(learning.itemName, learning.items, index)).correct() {
+			missed = append(missed, index)
+		}
+	}
+	return missed
+}
+
+// recallPassed reports whether correct out of asked meets the threshold, a fraction of 1
+func recallPassed(correct int, asked int, threshold float64) bool {
+	return asked > 0 && float64(correct)/float64(asked) >= threshold
+}
+
+// formatLearnList lists items[start:end] with their positions, marking the missed ones
+func formatLearnList(items []string, start int, end int, missed []int) string {
+	lines := make([]string, 0, end-start)
+	for index := start; index < end; index++ {
+		marker := " "
+		for _, missedIndex := range missed {
+			if missedIndex == index {
+				marker = "*"
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s %3d. %s", marker, index+1, items[index]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// learnableArea returns the area's list, if it's an ordered list of strings
+func learnableArea(name string) (*quizArea, []string, error) {
+	area, found := findQuizArea(name)
+	if !found {
+		return nil, nil, fmt.Errorf("There's no quiz area called %s", name)
+	}
+	items, isList := area.dataset.(*[]string)
+	if area.ordinal == "" || !isList {
+		return nil, nil, fmt.Errorf("%s isn't an ordered list that can be learned", area.name)
+	}
+	return area, *items, nil
+}
+
+var learnCmd = &cobra.Command{
+	Use:   "learn AREA",
+	Short: "Learn an ordered list a chunk at a time",
+	Long: `Learn an ordered list, like shakespeare or bible, from scratch. The list is shown a few
+items at a time (--chunk), and after each chunk you're asked for every position learned so far.
+When enough of those are right (--threshold), it moves on to the next chunk; otherwise it shows
+the list again, marking the ones you missed. Use --start to pick up where you left off.
+
+  memoryquiz learn shakespeare --chunk 4 --threshold 0.9`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exitOnDatasetLoadProblems()
+		area, items, err := learnableArea(args[0])
+		if err == nil && learnChunkSize < 1 {
+			err = fmt.Errorf("The chunk size must be at least 1")
+		}
+		if err == nil && (learnStart < 1 || learnStart > len(items)) {
+			err = fmt.Errorf("The start must be between 1 and %d", len(items))
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		learning := learner{
+			itemName:  area.ordinal,
+			items:     items,
+			chunkSize: learnChunkSize,
+			threshold: learnThreshold,
+			ask: func(question promptAndResponse) answerMatch {
+				match, _, _ := timedPromptAndMatchResponse(question)
+				return match
+			},
+			show: showUntilEnter,
+		}
+		withQuizScreen(cmd.CommandPath(), func() {
+			if learned := learning.learnFrom(learnStart); learned < len(items) {
+				quizPrintf("You've learned up to %d. Use --start %d to carry on from there.\n", learned, learned+1)
+			}
+		})
+	},
+}
+
+func init() {
+	memoryquizCmd.AddCommand(learnCmd)
+	learnCmd.Flags().IntVar(&learnChunkSize, "chunk", 5, "How many new items to show at a time")
+	learnCmd.Flags().Float64Var(&learnThreshold, "threshold", 0.9, "The fraction of the list so far to get right before moving on")
+	learnCmd.Flags().IntVar(&learnStart, "start", 1, "The position to start learning from")
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLearnFrom(t *testing.T) {
+	items := []string{"alpha", "beta", "gamma", "delta", "epsilon"}
+	shown := make([]string, 0)
+	asked := make([]string, 0)
+	missedOnce := false
+	learning := learner{
+		itemName:  "greek letter",
+		items:     items,
+		chunkSize: 2,
+		threshold: 1,
+		ask: func(question promptAndResponse) answerMatch {
+			asked = append(asked, question.response)
+			// miss beta the first time it comes up after the second chunk
+			if question.response == "beta" && len(asked) > 3 && !missedOnce {
+				missedOnce = true
+				return answerIncorrect
+			}
+			return answerCorrect
+		},
+		show: func(text string, instructions string) { shown = append(shown, text) },
+	}
+
+	if learned := learning.learnFrom(1); learned != 5 {
+		t.Errorf("Expected all 5 to be learned but got %d", learned)
+	}
+	expectedAsked := []string{
+		"alpha", "beta",
+		"alpha", "beta", "gamma", "delta",
+		"alpha", "beta", "gamma", "delta",
+		"alpha", "beta", "gamma", "delta", "epsilon",
+	}
+	if !reflect.DeepEqual(asked, expectedAsked) {
+		t.Errorf("Expected cumulative recall %v but got %v", expectedAsked, asked)
+	}
+	if len(shown) != 4 || !strings.Contains(shown[2], "*   2. beta") || strings.Contains(shown[1], "alpha") {
+		t.Errorf("Unexpected lists shown %q", shown)
+	}
+}
+
+func TestRecallPassed(t *testing.T) {
+	if !recallPassed(9, 10, 0.9) || recallPassed(8, 10, 0.9) || recallPassed(0, 0, 0) {
+		t.Errorf("Unexpected recall thresholds")
+	}
+}
+
+func TestLearnableArea(t *testing.T) {
+	if area, items, err := learnableArea("shakespeare"); err != nil || area.ordinal == "" || len(items) == 0 {
+		t.Errorf("Expected shakespeare to be learnable but got %v", err)
+	}
+	if _, _, err := learnableArea("states"); err == nil {
+		t.Errorf("Expected an error for an area that isn't an ordered list")
+	}
+}
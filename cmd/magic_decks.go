@@ -18,15 +18,15 @@ package cmd
 import (
 	"fmt"
 	"strings"
-
-	"github.com/spf13/cobra"
 )
 
-var magicCmd = &cobra.Command{
-	Use:   "magic",
-	Short: "Quiz command of Magic the Gathering information",
-	Run:   quizMagic,
-}
+var magicCmd = registerQuizArea(quizArea{
+	name:       "magic",
+	tags:       []string{"games"},
+	short:      "Quiz command of Magic the Gathering information",
+	generators: magicDeckQuestions,
+	dataset:    &magicDecks,
+})
 
 type color string
 
@@ -68,26 +68,25 @@ var magicDecks = []magicDeck{
 
 type quizMagicFunc func([]magicDeck) promptAndResponse
 
-func quizMagic(cmd *cobra.Command, args []string) {
+func magicDeckQuestions() []questionGenerator {
 	funcs := []quizMagicFunc{
 		quizDeckFromColors,
 		quizColorsFromDeck,
 	}
 
-	function := randomItemFromSlice(funcs)
-	promptAndCheckResponse(function(magicDecks))
+	return generatorsFor(funcs, magicDecks)
 }
 
 func quizDeckFromColors(decks []magicDeck) promptAndResponse {
 	deck := randomItemFromSlice(decks)
 	colorCombo := colorArrayToString(deck.colors)
-	return promptAndResponse{fmt.Sprintf("What is the deck name for %s", colorCombo), deck.name}
+	return promptAndResponse{prompt: fmt.Sprintf("What is the deck name for %s", colorCombo), response: deck.name}
 }
 
 func quizColorsFromDeck(decks []magicDeck) promptAndResponse {
 	deck := randomItemFromSlice(decks)
 	colors := colorArrayToString(deck.colors)
-	return promptAndResponse{fmt.Sprintf("What are the colors (WUBRG order) for %s", deck.name), colors}
+	return promptAndResponse{prompt: fmt.Sprintf("What are the colors (WUBRG order) for %s", deck.name), response: colors}
 }
 
 func colorArrayToString(deckColors []color) string {
@@ -97,7 +96,3 @@ func colorArrayToString(deckColors []color) string {
 	}
 	return strings.Join(colors, ",")
 }
-
-func init() {
-	memoryquizCmd.AddCommand(magicCmd)
-}
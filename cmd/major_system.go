@@ -0,0 +1,346 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Helpers for the major system, which turns digits into consonant sounds (0 is s or z, 1 is t or
+// d, 2 is n, and so on) and so numbers into words, called pegs: 42 is rain, 14 is tire. The
+// pegs quiz drills the table, memoryquiz pegs show suggests pegs for a number or the chunks of pi,
+// and memoryquiz pegs set stores your own image for a 2 or 4 digit chunk, which then shows up as
+// a hint in the numbers and pi quizzes.
+//
+// Any peg can be replaced in the config file, and a PAO (person, action, object) table can be
+// added, in which case 4 and 6 digit chunks are also suggested as the first pair's person doing
+// the second pair's action (to the third pair's object):
+//
+//	pegs:
+//	  "14": tyre
+//	pao:
+//	  "14": [Tiger Woods, teeing off, tee]
+
+var mnemonicImagesFile string
+var pegNumberLength int
+var pegShowPi bool
+
+// defaultPegs is a common major system table, for single digits and every pair
+var defaultPegs = map[string]string{
+	"0": "zoo", "1": "tie", "2": "Noah", "3": "ma", "4": "rye", "5": "law", "6": "shoe", "7": "cow", "8": "ivy", "9": "bee",
+	"00": "sauce", "01": "suit", "02": "snow", "03": "sumo", "04": "sierra", "05": "sail", "06": "sash", "07": "sock", "08": "sofa", "09": "soap",
+	"10": "dice", "11": "tot", "12": "tuna", "13": "tomb", "14": "tire", "15": "towel", "16": "dish", "17": "duck", "18": "dove", "19": "tuba",
+	"20": "nose", "21": "net", "22": "nun", "23": "gnome", "24": "Nero", "25": "nail", "26": "notch", "27": "neck", "28": "knife", "29": "knob",
+	"30": "mouse", "31": "mat", "32": "moon", "33": "mummy", "34": "mower", "35": "mule", "36": "match", "37": "mug", "38": "movie", "39": "map",
+	"40": "rose", "41": "rat", "42": "rain", "43": "ram", "44": "rower", "45": "roll", "46": "roach", "47": "rock", "48": "roof", "49": "rope",
+	"50": "lace", "51": "lot", "52": "lion", "53": "lamb", "54": "lure", "55": "lily", "56": "leash", "57": "log", "58": "lava", "59": "lip",
+	"60": "cheese", "61": "sheet", "62": "chain", "63": "jam", "64": "chair", "65": "jail", "66": "judge", "67": "chalk", "68": "chef", "69": "ship",
+	"70": "case", "71": "cat", "72": "coin", "73": "comb", "74": "car", "75": "coal", "76": "cage", "77": "cake", "78": "cave", "79": "cap",
+	"80": "fez", "81": "foot", "82": "phone", "83": "foam", "84": "fire", "85": "file", "86": "fish", "87": "fog", "88": "fife", "89": "fob",
+	"90": "bus", "91": "bat", "92": "bone", "93": "puma", "94": "bear", "95": "bell", "96": "beach", "97": "book", "98": "puff", "99": "pipe",
+}
+
+// pegsCmd represents the pegs command
+var pegsCmd = registerQuizArea(quizArea{
+	name:       "pegs",
+	aliases:    []string{"major-system"},
+	tags:       []string{"math"},
+	short:      "Drill the major system peg table, and get pegs for numbers",
+	generators: pegQuestions,
+})
+
+// pegTable returns the default pegs with any from the config file in their place
+func pegTable() map[string]string {
+	pegs := make(map[string]string, len(defaultPegs))
+	for digits, peg := range defaultPegs {
+		pegs[digits] = peg
+	}
+	for digits, peg := range viper.GetStringMapString("pegs") {
+		pegs[digits] = peg
+	}
+	return pegs
+}
+
+// sortedPegDigits returns the table's numbers with the single digits first, as in 0-9 then 00-99
+func sortedPegDigits(pegs map[string]string) []string {
+	digits := make([]string, 0, len(pegs))
+	for number := range pegs {
+		digits = append(digits, number)
+	}
+	sort.Slice(digits, func(i, j int) bool {
+		if len(digits[i]) != len(digits[j]) {
+			return len(digits[i]) < len(digits[j])
+		}
+		return digits[i] < digits[j]
+	})
+	return digits
+}
+
+type pegQuestion func(map[string]string) promptAndResponse
+
+func pegQuestions() []questionGenerator {
+	funcs := []pegQuestion{
+		quizPegForNumber,
+		quizNumberForPeg,
+	}
+	return generatorsFor(funcs, pegTable())
+}
+
+func quizPegForNumber(pegs map[string]string) promptAndResponse {
+	digits := sortedPegDigits(pegs)
+	number := randomItemFromSlice(digits)
+	distractors := make([]string, 0, len(digits))
+	for _, other := range digits {
+		if other != number {
+			distractors = append(distractors, pegs[other])
+		}
+	}
+	return promptAndResponse{prompt: fmt.Sprintf("What is the peg for %s?", number), response: pegs[number], distractors: distractors}
+}
+
+func quizNumberForPeg(pegs map[string]string) promptAndResponse {
+	number := randomItemFromSlice(sortedPegDigits(pegs))
+	return promptAndResponse{prompt: fmt.Sprintf("What number is the peg %s?", pegs[number]), response: number}
+}
+
+// pegPairs splits digits into pairs, with a single digit at the end if there's one left over
+func pegPairs(digits string) []string {
+	pairs := make([]string, 0, (len(digits)+1)/2)
+	for start := 0; start < len(digits); start += 2 {
+		end := start + 2
+		if end > len(digits) {
+			end = len(digits)
+		}
+		pairs = append(pairs, digits[start:end])
+	}
+	return pairs
+}
+
+// chunkDigits splits a number into chunks of size digits
+func chunkDigits(number string, size int) []string {
+	chunks := make([]string, 0, len(number)/size+1)
+	for start := 0; start < len(number); start += size {
+		end := start + size
+		if end > len(number) {
+			end = len(number)
+		}
+		chunks = append(chunks, number[start:end])
+	}
+	return chunks
+}
+
+// suggestPegs suggests an image for a chunk: its pegs, and from the PAO table for a chunk of
+// 4 or 6 digits, if there is one
+func suggestPegs(chunk string, pegs map[string]string, pao map[string][]string) string {
+	pairs := pegPairs(chunk)
+	words := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		words = append(words, pegs[pair])
+	}
+	suggestion := strings.Join(words, " + ")
+	if len(chunk) != 4 && len(chunk) != 6 {
+		return suggestion
+	}
+	parts := make([]string, 0, len(pairs))
+	for index, pair := range pairs {
+		if len(pao[pair]) != 3 {
+			return suggestion
+		}
+		parts = append(parts, pao[pair][index])
+	}
+	return fmt.Sprintf("%s (PAO: %s)", suggestion, strings.Join(parts, " "))
+}
+
+// paoTable returns the PAO table from the config file
+func paoTable() map[string][]string {
+	return viper.GetStringMapStringSlice("pao")
+}
+
+func mnemonicImagesPath() (string, error) {
+	if mnemonicImagesFile != "" {
+		return mnemonicImagesFile, nil
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".derrick_tools", "mnemonic_images.json"), nil
+}
+
+// loadMnemonicImages reads the stored images, keyed by chunk. A missing file has no images.
+func loadMnemonicImages(fileName string) (map[string]string, error) {
+	images := make(map[string]string)
+	contents, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return images, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(contents, &images); err != nil {
+		return nil, fmt.Errorf("Could not parse mnemonic images %s: %v", fileName, err)
+	}
+	return images, nil
+}
+
+func saveMnemonicImages(fileName string, images map[string]string) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	contents, err := json.MarshalIndent(images, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, contents, 0644)
+}
+
+// the images are loaded once per run, for the hints
+var loadedMnemonicImages map[string]string
+
+func currentMnemonicImages() map[string]string {
+	if loadedMnemonicImages != nil {
+		return loadedMnemonicImages
+	}
+	fileName, err := mnemonicImagesPath()
+	if err == nil {
+		loadedMnemonicImages, err = loadMnemonicImages(fileName)
+	}
+	if err != nil {
+		// the hints are a nicety; don't stop the quiz for them
+		fmt.Printf("Could not load mnemonic images: %v\n", err)
+		loadedMnemonicImages = make(map[string]string)
+	}
+	return loadedMnemonicImages
+}
+
+// chunkImage returns the stored image for a chunk, or for a 4 digit chunk without one, the
+// images for both of its pairs
+func chunkImage(chunk string, images map[string]string) string {
+	if image, found := images[chunk]; found {
+		return image
+	}
+	if len(chunk) == 4 && images[chunk[:2]] != "" && images[chunk[2:]] != "" {
+		return images[chunk[:2]] + " + " + images[chunk[2:]]
+	}
+	return ""
+}
+
+// imageHints lists the stored images for the 4 digit chunks of a number, for the numbers quiz
+func imageHints(number string, images map[string]string) string {
+	hints := make([]string, 0)
+	for _, chunk := range chunkDigits(number, 4) {
+		if image := chunkImage(chunk, images); image != "" {
+			hints = append(hints, fmt.Sprintf("%s: %s", chunk, image))
+		}
+	}
+	return strings.Join(hints, "\n")
+}
+
+var mnemonicChunk = regexp.MustCompile(`^(\d{2}|\d{4})$`)
+
+var nonDigits = regexp.MustCompile(`\D`)
+
+var pegTableCmd = &cobra.Command{
+	Use:   "table",
+	Short: "Print the peg table",
+	Run: func(cmd *cobra.Command, args []string) {
+		pegs := pegTable()
+		for _, digits := range sortedPegDigits(pegs) {
+			fmt.Printf("%-3s %s\n", digits, pegs[digits])
+		}
+	},
+}
+
+var pegShowCmd = &cobra.Command{
+	Use:   "show [NUMBER...]",
+	Short: "Suggest pegs for each chunk of a number",
+	Long: `Suggest pegs for each 4 digit chunk of the given numbers, or of a random number of --length
+digits, or with --pi, for each chunk of pi. Images stored with memoryquiz pegs set are shown too.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		chunks := make([]string, 0)
+		switch {
+		case pegShowPi:
+			chunks = piChunks
+		case len(args) == 0:
+			chunks = chunkDigits(generateNumberStringOfLength(pegNumberLength), 4)
+		default:
+			for _, number := range args {
+				chunks = append(chunks, chunkDigits(nonDigits.ReplaceAllString(number, ""), 4)...)
+			}
+		}
+
+		pegs, pao, images := pegTable(), paoTable(), currentMnemonicImages()
+		for _, chunk := range chunks {
+			fmt.Printf("%-6s %s\n", chunk, suggestPegs(chunk, pegs, pao))
+			if image := chunkImage(chunk, images); image != "" {
+				fmt.Printf("       your image: %s\n", image)
+			}
+		}
+	},
+}
+
+var pegSetCmd = &cobra.Command{
+	Use:   "set CHUNK [IMAGE...]",
+	Short: "Store your own image for a 2 or 4 digit chunk",
+	Long: `Store your own image for a 2 or 4 digit chunk, which the numbers and pi quizzes then show as
+a hint. Leave out the image to remove a stored one.
+
+  memoryquiz pegs set 1415 a tire wrapped in a beach towel`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !mnemonicChunk.MatchString(args[0]) {
+			fmt.Printf("%s isn't a 2 or 4 digit chunk\n", args[0])
+			os.Exit(1)
+		}
+
+		fileName, err := mnemonicImagesPath()
+		var images map[string]string
+		if err == nil {
+			images, err = loadMnemonicImages(fileName)
+		}
+		if err == nil {
+			if image := strings.Join(args[1:], " "); image != "" {
+				images[args[0]] = image
+			} else {
+				delete(images, args[0])
+			}
+			err = saveMnemonicImages(fileName, images)
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	pegsCmd.AddCommand(pegTableCmd)
+	pegsCmd.AddCommand(pegShowCmd)
+	pegsCmd.AddCommand(pegSetCmd)
+	memoryquizCmd.PersistentFlags().StringVar(&mnemonicImagesFile, "images", "", "file for your mnemonic images (default is $HOME/.derrick_tools/mnemonic_images.json)")
+	pegShowCmd.Flags().IntVarP(&pegNumberLength, "length", "l", 20, "length of the random number")
+	pegShowCmd.Flags().BoolVar(&pegShowPi, "pi", false, "Show the chunks of pi")
+}
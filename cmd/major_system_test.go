@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestPegTable(t *testing.T) {
+	defer viper.Set("pegs", nil)
+	viper.Set("pegs", map[string]interface{}{"14": "tyre"})
+
+	pegs := pegTable()
+	if len(pegs) != 110 || pegs["14"] != "tyre" || pegs["42"] != "rain" {
+		t.Errorf("Expected 110 pegs with tyre for 14 but got %d, %s", len(pegs), pegs["14"])
+	}
+	if digits := sortedPegDigits(pegs); digits[0] != "0" || digits[9] != "9" || digits[10] != "00" || digits[109] != "99" {
+		t.Errorf("Expected 0-9 and then 00-99 but got %v", digits)
+	}
+}
+
+func TestSuggestPegs(t *testing.T) {
+	if chunks := chunkDigits("31415926535", 4); !reflect.DeepEqual(chunks, []string{"3141", "5926", "535"}) {
+		t.Errorf("Unexpected chunks %v", chunks)
+	}
+
+	pao := map[string][]string{"31": {"Marie Curie", "measuring", "mat"}, "41": {"Rat King", "racing", "rattle"}}
+	cases := map[string]string{
+		"3141": "mat + rat (PAO: Marie Curie racing)",
+		"535":  "lamb + law",
+		"5926": "lip + notch",
+	}
+	for chunk, expected := range cases {
+		if suggestion := suggestPegs(chunk, defaultPegs, pao); suggestion != expected {
+			t.Errorf("Expected %s for %s but got %s", expected, chunk, suggestion)
+		}
+	}
+}
+
+func TestMnemonicImages(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "images.json")
+	images, err := loadMnemonicImages(fileName)
+	if err != nil || len(images) != 0 {
+		t.Fatalf("Expected no images from a missing file but got %v, %v", images, err)
+	}
+
+	images["1415"] = "a tire in a towel"
+	images["92"] = "a bone"
+	images["65"] = "a jail"
+	if err := saveMnemonicImages(fileName, images); err != nil {
+		t.Fatalf("Could not save images: %v", err)
+	}
+	if loaded, err := loadMnemonicImages(fileName); err != nil || !reflect.DeepEqual(loaded, images) {
+		t.Errorf("Expected the images to load as saved but got %v, %v", loaded, err)
+	}
+
+	if image := chunkImage("9265", images); image != "a bone + a jail" {
+		t.Errorf("Expected the pairs' images for 9265 but got %q", image)
+	}
+	if hints := imageHints("141592653589", images); hints != "1415: a tire in a towel\n9265: a bone + a jail" {
+		t.Errorf("Unexpected hints %q", hints)
+	}
+}
@@ -18,8 +18,9 @@ package cmd
 import (
 	"bufio"
 	"fmt"
-	"math/rand"
 	"os"
+	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -30,114 +31,175 @@ import (
 type promptAndResponse struct {
 	prompt   string
 	response string
+	// aliases are other answers that count as correct
+	aliases []string
+	// allOf is set for questions whose answer is a comma separated list of all these, in any order
+	allOf []string
+	// sequence is set for questions whose answer is a comma separated list of these, in this order,
+	// which is scored item by item. Each item is its accepted answers. See recite.go.
+	sequence [][]string
+	// distractors are plausible wrong answers, such as other countries' capitals, for multiple choice
+	distractors []string
+	// choices is set when the question is asked as multiple choice. See multiple_choice.go.
+	choices []string
+	// closedSet is set when the prompt names every possible answer, as in "Which is larger: A
+	// or B?". The response and distractors are those names, and multiple choice offers only them.
+	closedSet bool
+	// hint is shown with the prompt, such as your image for a chunk of pi. See major_system.go.
+	hint string
+	// oneOff is set for questions made up on the spot, such as arithmetic problems or a random
+	// pair to compare, which history tracks by question type rather than by prompt
+	oneOff bool
 }
 
-// promptAndCheckResponse will use promot to pose a question to the user and wait for
-// a response. If correct, it will print Correct! and return true. Otherwise it will
-// print the user's answer and the right answer and return false
-func promptAndCheckResponse(prompt promptAndResponse) bool {
+// timedPromptAndMatchResponse asks the question and reports how well the answer matched
+// (see answer_matching.go), how long the user took to answer, and how many hints they took
+// on the full screen
+func timedPromptAndMatchResponse(prompt promptAndResponse) (answerMatch, time.Duration, int) {
 	start := time.Now()
 	userResponse := responseFromPrompt(prompt)
-	fmt.Printf("You took %v to answer\n", time.Now().Sub(start))
-	if userResponse != "" {
-		if strings.TrimSpace(userResponse) == prompt.response {
-			fmt.Println("Correct!")
-			return true
-		} else {
-			fmt.Printf("Incorrect. The right answer was %s\n", prompt.response)
-			return false
+	took := time.Now().Sub(start)
+	hints := 0
+	if activeQuizScreen != nil {
+		hints = activeQuizScreen.lastHints
+	}
+	quizPrintf("You took %v to answer\n", took)
+	if userResponse == "" {
+		if activeQuizScreen != nil && !stdinClosed {
+			activeQuizScreen.score(answerIncorrect, prompt)
+		}
+		return answerIncorrect, took, hints
+	}
+
+	match := matchAnswer(userResponse, prompt)
+	rightAnswer := prompt.response
+	for index, choice := range prompt.choices {
+		if choice == prompt.response {
+			rightAnswer = fmt.Sprintf("%s) %s", choiceLetter(index), choice)
 		}
 	}
-	return false
+	switch {
+	case len(prompt.sequence) > 0 && !match.correct():
+		printRecitationDiff(userResponse, prompt.sequence)
+	case len(prompt.allOf) > 0 && !match.correct():
+		printAllAnswersDiff(userResponse, prompt.allOf)
+	case match == answerCorrect:
+		quizPrintln("Correct!")
+	case match == answerTypo:
+		quizPrintf("Close enough! It's %s\n", rightAnswer)
+	case match == answerClose:
+		quizPrintf("Close, but the right answer was %s\n", rightAnswer)
+	default:
+		quizPrintf("Incorrect. The right answer was %s\n", rightAnswer)
+	}
+	if !match.correct() && len(prompt.aliases) > 0 && len(prompt.choices) == 0 {
+		quizPrintf("(%s would also have been right)\n", strings.Join(prompt.aliases, ", "))
+	}
+	if activeQuizScreen != nil {
+		activeQuizScreen.score(match, prompt)
+	}
+	return match, took, hints
 }
 
 func responseFromPrompt(prompt promptAndResponse) string {
+	if activeQuizScreen != nil {
+		return activeQuizScreen.readAnswer(prompt)
+	}
 	fmt.Println(prompt.prompt)
-	scanner := bufio.NewScanner(os.Stdin)
-	if scanner.Scan() {
-		return scanner.Text()
+	if prompt.hint != "" {
+		fmt.Printf("(Hint: %s)\n", prompt.hint)
 	}
-	return ""
+	printChoices(prompt.choices)
+	return readStdinLine()
+}
+
+// all reads from stdin share one scanner, since a scanner can buffer past the line it returns
+var stdinScanner = bufio.NewScanner(os.Stdin)
 
+// stdinClosed is set once stdin runs out, or the full screen quiz is quit or runs out of time,
+// so a quiz session knows to stop asking
+var stdinClosed bool
+
+// readStdinLine returns the next line from stdin, or "" if there isn't one
+func readStdinLine() string {
+	if stdinScanner.Scan() {
+		return stdinScanner.Text()
+	}
+	stdinClosed = true
+	return ""
 }
 
 // quizIndexOfStringInList will ask you to identify the index of a random item within the set of items
 // for instance, you might get a question such as "what position is greek letter eta?"
 func quizIndexOfStringInList(items []string) promptAndResponse {
-	itemIndex := rand.Intn(len(items))
-	return promptAndResponse{fmt.Sprintf("What position is %s?", items[itemIndex]), strconv.Itoa(itemIndex + 1)}
+	return indexOfStringQuestion(items, quizRand.Intn(len(items)))
+}
+
+func indexOfStringQuestion(items []string, itemIndex int) promptAndResponse {
+	return promptAndResponse{prompt: fmt.Sprintf("What position is %s?", items[itemIndex]), response: strconv.Itoa(itemIndex + 1)}
 }
 
 // quizStringAtIndexInList will ask you to identify what string is at the given position in items
 // for instance, you might get a question such as "which hebrew letter is at position 2"
 func quizStringAtIndexInList(itemName string, items []string) promptAndResponse {
-	itemIndex := rand.Intn(len(items))
-	return promptAndResponse{fmt.Sprintf("What %s is at position %d?", itemName, itemIndex+1), items[itemIndex]}
+	return stringAtIndexQuestion(itemName, items, quizRand.Intn(len(items)))
+}
+
+func stringAtIndexQuestion(itemName string, items []string, itemIndex int) promptAndResponse {
+	return promptAndResponse{prompt: fmt.Sprintf("What %s is at position %d?", itemName, itemIndex+1), response: items[itemIndex]}
+}
+
+// questionGenerator is a named source of questions. The name identifies the type of question
+// (quizWhichIsBigger vs. quizCountryFromFlag, for instance) in quiz history.
+type questionGenerator struct {
+	name     string
+	generate func() promptAndResponse
+	// deadline is how long there should be to answer, or 0 for no deadline. See question_deadlines.go.
+	deadline time.Duration
+}
+
+// generatorsFor binds each of the quiz functions to data. Functions that appear more than once
+// in funcs will produce more than one generator, which keeps the bias quizzes use to favor some
+// question types.
+func generatorsFor[D any, F ~func(D) promptAndResponse](funcs []F, data D) []questionGenerator {
+	generators := make([]questionGenerator, 0, len(funcs))
+	for _, function := range funcs {
+		quizFunc := function
+		generators = append(generators, questionGenerator{name: quizFunctionName(quizFunc), generate: func() promptAndResponse { return quizFunc(data) }})
+	}
+	return generators
 }
 
-type areaToQuizFunction struct {
-	area     string
-	quizFunc func(*cobra.Command, []string)
+// namedGenerators is generatorsFor for quiz functions that don't need any data
+func namedGenerators[F ~func() promptAndResponse](funcs []F) []questionGenerator {
+	generators := make([]questionGenerator, 0, len(funcs))
+	for _, function := range funcs {
+		generators = append(generators, questionGenerator{name: quizFunctionName(function), generate: function})
+	}
+	return generators
+}
+
+// quizFunctionName returns the unqualified name of a function, e.g. quizWhichIsBigger
+func quizFunctionName(function interface{}) string {
+	fullName := runtime.FuncForPC(reflect.ValueOf(function).Pointer()).Name()
+	return fullName[strings.LastIndex(fullName, ".")+1:]
 }
 
 // memoryquizCmd represents the memoryquiz command
 var memoryquizCmd = &cobra.Command{
 	Use:   "memoryquiz",
 	Short: "Fire up various memory quizzes",
-	Run: func(cmd *cobra.Command, args []string) {
-		// select an arbitrary memory quiz to run
-		areaToQuizFuncs := []areaToQuizFunction{
-			{"presidents", quizPresidents},
-			{"countries", quizCountries},
-			{"digits of pi", quizPiDigts},
-			{"greek alphabet", quizGreekAlphabet},
-			{"hebrew alphabet", quizHebrewAlphabet},
-			{"cranial nerves", quizCranialNerves},
-			{"shakespeare", quizShakespeare},
-			{"powers of two", quizPowersOfTwo},
-			{"elements", quizElements},
-			{"states", quizStates},
-			{"muses", quizMuses},
-			{"chinesezodiac", quizChineseZodiacs},
-			{"bible books", quizKingJames},
-			{"canada", quizCanada},
-			{"lakes", quizLakes},
-			{"constellations", quizConstellations},
-			{"arrondisements", quizArrondisements},
-			{"spellingbee", quizSpellingBee},
-			{"monopoly", quizMonopoly},
-			{"numbers", quizLargeNumbers},
-			{"doomsday", quizDoomsday},
-			{"whosonfirst", quizWhosonfirst},
-			{"english royalty", quizEnglishRoyalty},
-			{"grand crus", quizGrandCrus},
-			{"bottles", quizWineBottles},
-			{"ca-counties", quizCaCounties},
-			{"baseball-teams", quizBaseballTeams},
-			{"football-teams", quizFootballTeams},
-			{"magic", quizMagic},
-			{"wnba-teams", quizWnbaTeams},
-			{"http-code", quizHttpCodes},
-			{"nba-teams", quizNbaTeams},
-			{"sheep-counting", quizSheepCounting},
-			{"hebrew-calendar", quizHebrewCalendar},
-			{"outer-hebrides", quizOuterHebrides},
-			{"orkneys", quizOrkneys},
-			{"hebrew-week", quizHebrewWeek},
-			{"roman-names", quizRomanNames},
-			{"rivers", quizRivers},
-		}
-
-		areaToQuiz := areaToQuizFuncs[rand.Intn(len(areaToQuizFuncs))]
-		fmt.Printf("[%s]\n", areaToQuiz.area)
-		areaToQuiz.quizFunc(cmd, args)
-	},
+	Long: `Run with no subcommand to get a question from a random area. Use memoryquiz list to see all
+the areas and their tags, and --include and --exclude to choose among them by name or tag. Areas
+can be weighted in the config file, as in weights: {countries: 3, nba-teams: 0.5}.`,
+	Run: runQuizSession(askRandomQuizArea),
 }
 
 func randomItemFromSlice[S ~[]E, E interface{}](s S) E {
-	return s[rand.Intn(len(s))]
+	return s[quizRand.Intn(len(s))]
 }
 
 func init() {
 	rootCmd.AddCommand(memoryquizCmd)
+	memoryquizCmd.PersistentFlags().StringVar(&quizHistoryFile, "history", "", "file for recording quiz history (default is $HOME/.derrick_tools/quiz_history.json)")
 }
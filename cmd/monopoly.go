@@ -17,19 +17,18 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"strconv"
 	"strings"
-
-	"github.com/spf13/cobra"
 )
 
 // countriesCmd represents the countries command
-var monopolyCmd = &cobra.Command{
-	Use:   "monopoly",
-	Short: "Memory quizzes about spaces in Monopoly",
-	Run:   quizMonopoly,
-}
+var monopolyCmd = registerQuizArea(quizArea{
+	name:       "monopoly",
+	tags:       []string{"games"},
+	short:      "Memory quizzes about spaces in Monopoly",
+	generators: monopolyQuestions,
+	dataset:    &monopolyBoard,
+})
 
 type monopolySquare struct {
 	position      int
@@ -93,33 +92,31 @@ var monopolyBoard = []monopolySquare{
 	{40, "Boardwalk", DARK_BLUE, 400},
 }
 
-// the usual pattern is to just pass a list to various quiz functions
-// but because we only want to run certain quizzes (color and purchase price)
-// on certain properties, we pass just the property to the quiz function,
-type monopolyQuery func(monopolySquare) promptAndResponse
-
-func quizMonopoly(cmd *cobra.Command, args []string) {
+type monopolyQuery func([]monopolySquare) promptAndResponse
 
-	quizFuncs := []monopolyQuery{quizMonopolyNameFromPosition, quizMonopolyPositionFromName}
-	property := monopolyBoard[rand.Intn(len(monopolyBoard))]
-	if property.color != NO_COLOR {
-		quizFuncs = append(quizFuncs, quizMonopolyColorForProperty)
+func monopolyQuestions() []questionGenerator {
+	quizFuncs := []monopolyQuery{
+		quizMonopolyNameFromPosition,
+		quizMonopolyPositionFromName,
+		quizMonopolyColorForProperty,
+		quizMonopolyPurchasePriceForProperty,
+		quizMonopolyPropertiesByColor,
 	}
+	return generatorsFor(quizFuncs, monopolyBoard)
+}
 
-	if property.purchasePrice != 0 {
-		quizFuncs = append(quizFuncs, quizMonopolyPurchasePriceForProperty)
+// randomMonopolySquare picks a square that satisfies include. Color and purchase price questions
+// only make sense for some squares.
+func randomMonopolySquare(board []monopolySquare, include func(monopolySquare) bool) monopolySquare {
+	square := randomItemFromSlice(board)
+	for !include(square) {
+		square = randomItemFromSlice(board)
 	}
+	return square
+}
 
-	// special case for quizzing all the properties of a specific color
-	// that can't work off a single property, so it's handled separately.
-	// we do this by picking a random number between 0 and one more the length of the board.
-	// if the random number = the len of the board, then use the special question
-	if rand.Intn(len(monopolyBoard)+1) == len(monopolyBoard) {
-		promptAndCheckResponse(quizMonopolyPropertiesByColor(monopolyBoard))
-	} else {
-		function := quizFuncs[rand.Intn(len(quizFuncs))]
-		promptAndCheckResponse(function(property))
-	}
+func anyMonopolySquare(square monopolySquare) bool {
+	return true
 }
 
 func quizMonopolyPropertiesByColor(monopolyBoard []monopolySquare) promptAndResponse {
@@ -127,40 +124,32 @@ func quizMonopolyPropertiesByColor(monopolyBoard []monopolySquare) promptAndResp
 	randomColor := randomItemFromSlice(colors)
 
 	properties := make([]string, 0)
+	sequence := make([][]string, 0)
 	for _, property := range monopolyBoard {
 		if property.color == randomColor {
 			properties = append(properties, property.name)
+			sequence = append(sequence, []string{property.name})
 		}
 	}
-	return promptAndResponse{fmt.Sprintf("Name the %s properties (in order)", randomColor), strings.Join(properties, ",")}
+	return promptAndResponse{prompt: fmt.Sprintf("Name the %s properties (in order)", randomColor), response: strings.Join(properties, ", "), sequence: sequence}
 }
 
-func quizMonopolyNameFromPosition(property monopolySquare) promptAndResponse {
-	return promptAndResponse{fmt.Sprintf("What is the name of the square at position %d", property.position), property.name}
+func quizMonopolyNameFromPosition(board []monopolySquare) promptAndResponse {
+	property := randomMonopolySquare(board, anyMonopolySquare)
+	return promptAndResponse{prompt: fmt.Sprintf("What is the name of the square at position %d", property.position), response: property.name}
 }
 
-func quizMonopolyPositionFromName(property monopolySquare) promptAndResponse {
-	return promptAndResponse{fmt.Sprintf("What position is %s at?", property.name), strconv.Itoa(property.position)}
+func quizMonopolyPositionFromName(board []monopolySquare) promptAndResponse {
+	property := randomMonopolySquare(board, anyMonopolySquare)
+	return promptAndResponse{prompt: fmt.Sprintf("What position is %s at?", property.name), response: strconv.Itoa(property.position)}
 }
 
-func quizMonopolyColorForProperty(property monopolySquare) promptAndResponse {
-	return promptAndResponse{fmt.Sprintf("What color is %s?", property.name), property.color}
+func quizMonopolyColorForProperty(board []monopolySquare) promptAndResponse {
+	property := randomMonopolySquare(board, func(square monopolySquare) bool { return square.color != NO_COLOR })
+	return promptAndResponse{prompt: fmt.Sprintf("What color is %s?", property.name), response: property.color}
 }
 
-func quizMonopolyPurchasePriceForProperty(property monopolySquare) promptAndResponse {
-	return promptAndResponse{fmt.Sprintf("What is the purchase price for %s?", property.name), strconv.Itoa(property.purchasePrice)}
-}
-
-func init() {
-	memoryquizCmd.AddCommand(monopolyCmd)
-
-	// Here you will define your flags and configuration settings.
-
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// countriesCmd.PersistentFlags().String("foo", "", "A help for foo")
-
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// countriesCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+func quizMonopolyPurchasePriceForProperty(board []monopolySquare) promptAndResponse {
+	property := randomMonopolySquare(board, func(square monopolySquare) bool { return square.purchasePrice != 0 })
+	return promptAndResponse{prompt: fmt.Sprintf("What is the purchase price for %s?", property.name), response: strconv.Itoa(property.purchasePrice)}
 }
@@ -0,0 +1,127 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Multiple choice is a recognition stage for learning a new set: memoryquiz --multiple-choice
+// shows the right answer among wrong ones and takes the answer by letter. The wrong answers come
+// from the question's distractors, which cross queries fill in from the same field of the other
+// entities (other capitals for a capital question). Other questions get theirs by asking the
+// question's generator for more questions and using their answers, except for questions whose
+// prompt already names the options, which offer just those.
+//
+// Multiple choice answers are tracked separately from free recall in the quiz history, since
+// recognizing an answer doesn't mean you can recall it.
+
+var multipleChoice bool
+
+// the number of choices offered, including the right one
+const multipleChoiceCount = 4
+
+// how many extra questions to generate when looking for distractors
+const distractorAttempts = 30
+
+// multipleChoiceSuffix marks multiple choice question types in the quiz history
+const multipleChoiceSuffix = "/choice"
+
+// withMultipleChoice returns the question with its choices set, using generate for more
+// distractors if the question doesn't have enough and isn't a closed set. Questions that want a list of answers
+// or that can't find any distractors are left as free recall.
+func withMultipleChoice(question promptAndResponse, generate func() promptAndResponse) promptAndResponse {
+	if len(question.allOf) > 0 || len(question.sequence) > 0 {
+		return question
+	}
+
+	rightAnswers := append([]string{question.response}, question.aliases...)
+	isWrongAnswer := func(candidate string, wrongAnswers []string) bool {
+		normalized := normalizeAnswer(candidate)
+		if normalized == "" {
+			return false
+		}
+		for _, answer := range rightAnswers {
+			if normalizeAnswer(answer) == normalized {
+				return false
+			}
+		}
+		for _, answer := range wrongAnswers {
+			if normalizeAnswer(answer) == normalized {
+				return false
+			}
+		}
+		return true
+	}
+
+	wrongAnswers := make([]string, 0, multipleChoiceCount-1)
+	for _, index := range quizRand.Perm(len(question.distractors)) {
+		if len(wrongAnswers) == multipleChoiceCount-1 && !question.closedSet {
+			break
+		}
+		if candidate := question.distractors[index]; isWrongAnswer(candidate, wrongAnswers) {
+			wrongAnswers = append(wrongAnswers, candidate)
+		}
+	}
+	for i := 0; i < distractorAttempts && len(wrongAnswers) < multipleChoiceCount-1 && !question.closedSet; i++ {
+		other := generate()
+		if len(other.allOf) == 0 && len(other.sequence) == 0 && isWrongAnswer(other.response, wrongAnswers) {
+			wrongAnswers = append(wrongAnswers, other.response)
+		}
+	}
+	if len(wrongAnswers) == 0 {
+		return question
+	}
+
+	question.choices = append(wrongAnswers, question.response)
+	quizRand.Shuffle(len(question.choices), func(i, j int) {
+		question.choices[i], question.choices[j] = question.choices[j], question.choices[i]
+	})
+	return question
+}
+
+func choiceLetter(index int) string {
+	return string(rune('A' + index))
+}
+
+func printChoices(choices []string) {
+	for index, choice := range choices {
+		fmt.Printf("  %s) %s\n", choiceLetter(index), choice)
+	}
+}
+
+// matchChoice matches a multiple choice answer, given by letter. A wrong choice is simply wrong,
+// never close. Anything other than a letter is matched as a typed answer.
+func matchChoice(userResponse string, prompt promptAndResponse) answerMatch {
+	answer := strings.ToUpper(strings.TrimSpace(userResponse))
+	for index, choice := range prompt.choices {
+		if answer == choiceLetter(index) {
+			if choice == prompt.response {
+				return answerCorrect
+			}
+			return answerIncorrect
+		}
+	}
+
+	typed := prompt
+	typed.choices = nil
+	return matchAnswer(userResponse, typed)
+}
+
+func init() {
+	memoryquizCmd.PersistentFlags().BoolVarP(&multipleChoice, "multiple-choice", "m", false, "Ask questions as multiple choice, answered by letter")
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMultipleChoiceFromDistractors(t *testing.T) {
+	question := promptAndResponse{
+		prompt:      "What is the capital of the country with name of France?",
+		response:    "Paris",
+		distractors: []string{"Berlin", "paris", "Madrid", "Berlin", "Rome", "Lisbon"},
+	}
+	generate := func() promptAndResponse {
+		t.Fatalf("Should not need to generate questions when there are enough distractors")
+		return question
+	}
+
+	choices := withMultipleChoice(question, generate).choices
+	if len(choices) != multipleChoiceCount {
+		t.Fatalf("Expected %d choices but got %v", multipleChoiceCount, choices)
+	}
+	seen := make(map[string]bool)
+	for _, choice := range choices {
+		if seen[normalizeAnswer(choice)] {
+			t.Errorf("Choice %s appears twice in %v", choice, choices)
+		}
+		seen[normalizeAnswer(choice)] = true
+	}
+	if !seen["paris"] {
+		t.Errorf("Expected the right answer among %v", choices)
+	}
+}
+
+func TestMultipleChoiceFromGenerator(t *testing.T) {
+	plays := []string{"Hamlet", "Macbeth", "Othello", "King Lear", "The Tempest"}
+	question := quizStringAtIndexInList("play", plays)
+	choices := withMultipleChoice(question, func() promptAndResponse { return quizStringAtIndexInList("play", plays) }).choices
+	if len(choices) != multipleChoiceCount {
+		t.Errorf("Expected %d choices from generated questions but got %v", multipleChoiceCount, choices)
+	}
+
+	lonely := promptAndResponse{prompt: "What is the only answer?", response: "this"}
+	if choices := withMultipleChoice(lonely, func() promptAndResponse { return lonely }).choices; choices != nil {
+		t.Errorf("Expected free recall when there are no wrong answers but got %v", choices)
+	}
+}
+
+func TestMultipleChoiceClosedSet(t *testing.T) {
+	countries := []countryInfo{{rankInArea: 1, name: "Chad"}, {rankInArea: 2, name: "Peru"}, {rankInArea: 3, name: "Fiji"}}
+	question := quizCompareRanked("country", countries, true)
+	generate := func() promptAndResponse {
+		t.Fatalf("Should not pad a question that names its own options")
+		return question
+	}
+
+	choices := withMultipleChoice(question, generate).choices
+	if len(choices) != 2 {
+		t.Fatalf("Expected just the two countries in %q but got %v", question.prompt, choices)
+	}
+	for _, choice := range choices {
+		if !strings.Contains(question.prompt, choice) {
+			t.Errorf("Choice %s isn't one the prompt %q offers", choice, question.prompt)
+		}
+	}
+}
+
+func TestMatchChoice(t *testing.T) {
+	question := promptAndResponse{prompt: "What is the capital of France?", response: "Paris", choices: []string{"Rome", "Paris", "Berlin"}}
+	cases := map[string]answerMatch{
+		"b":     answerCorrect,
+		" B ":   answerCorrect,
+		"A":     answerIncorrect,
+		"D":     answerIncorrect,
+		"paris": answerCorrect,
+	}
+	for answer, expected := range cases {
+		if match := matchAnswer(answer, question); match != expected {
+			t.Errorf("Expected %q to match as %v but got %v", answer, expected, match)
+		}
+	}
+}
+
+func TestCrossQueryDistractors(t *testing.T) {
+	entities := []crossQuery1{{"France", "Paris"}, {"Germany", "Berlin"}, {"Italy", "Rome"}}
+	question := constructCrossQueryFromSlice("country", entities)
+	if len(question.distractors) != 2 || isStringInSlice(question.response, question.distractors) {
+		t.Errorf("Expected the other capitals as distractors for %s but got %v", question.response, question.distractors)
+	}
+}
@@ -17,18 +17,17 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"strings"
-
-	"github.com/spf13/cobra"
 )
 
 // shakespeareCmd represents the shakespeare command
-var musesCmd = &cobra.Command{
-	Use:   "muses",
-	Short: "Test recall of the names and areas of the nine muses",
-	Run:   quizMuses,
-}
+var musesCmd = registerQuizArea(quizArea{
+	name:       "muses",
+	tags:       []string{"mythology"},
+	short:      "Test recall of the names and areas of the nine muses",
+	generators: museQuestions,
+	dataset:    &muses,
+})
 
 type muse struct {
 	name  string
@@ -53,39 +52,36 @@ var muses = []muse{
 
 type museQuiz func([]muse) promptAndResponse
 
-func quizMuses(cmd *cobra.Command, args []string) {
+func museQuestions() []questionGenerator {
 	quizzes := []museQuiz{
 		quizMuseByArea,
 		quizAreaByMuse,
 		quizAllMuses,
 	}
 
-	quiz := randomItemFromSlice(quizzes)
-	promptAndCheckResponse(quiz(muses))
+	return generatorsFor(quizzes, muses)
 }
 
 func quizMuseByArea(muses []muse) promptAndResponse {
 	muse := randomMuse(muses)
-	return promptAndResponse{fmt.Sprintf("Who is the muse of %s?", muse.areasFormatted()), muse.name}
+	return promptAndResponse{prompt: fmt.Sprintf("Who is the muse of %s?", muse.areasFormatted()), response: muse.name}
 }
 
 func quizAreaByMuse(muses []muse) promptAndResponse {
 	muse := randomMuse(muses)
-	return promptAndResponse{fmt.Sprintf("What is %s the muse of?", muse.name), muse.areasFormatted()}
+	return promptAndResponse{prompt: fmt.Sprintf("What is %s the muse of?", muse.name), response: muse.areasFormatted()}
 }
 
 func quizAllMuses(muses []muse) promptAndResponse {
-	museNames := []string{}
-	for i := 0; i < len(muses); i++ {
-		museNames = append(museNames, muses[i].name)
+	museNames := make([]string, 0, len(muses))
+	sequence := make([][]string, 0, len(muses))
+	for _, muse := range muses {
+		museNames = append(museNames, muse.name)
+		sequence = append(sequence, []string{muse.name})
 	}
-	return promptAndResponse{"Name all the muses in memory walk order", strings.Join(museNames, ",")}
+	return promptAndResponse{prompt: "Name all the muses in memory walk order", response: strings.Join(museNames, ", "), sequence: sequence}
 }
 
 func randomMuse(muses []muse) muse {
-	return muses[rand.Intn(len(muses))]
-}
-
-func init() {
-	memoryquizCmd.AddCommand(musesCmd)
+	return muses[quizRand.Intn(len(muses))]
 }
@@ -0,0 +1,112 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// "Name them all" questions, like every state that joined in 1788, for any dataset whose struct
+// tags say how to group it. nameall:"name" marks the field to list, and any other nameall tag
+// marks a field to group by, with a phrase saying how, where %v is the field's value:
+//
+//	type state struct {
+//	  name       string `nameall:"name"`
+//	  yearJoined int    `nameall:"that joined in %v"`
+//	}
+//
+// asks "Name every state that joined in 1788". A multi-valued field, like the countries a lake
+// touches, groups an entity under each of its values. The answers can be in any order, and
+// the ones found, missed, and wrong are all reported (see matchAllAnswers).
+
+// nameAllField is a field to group by and the phrase for it
+type nameAllField struct {
+	index  int
+	phrase string
+}
+
+// nameAllFields returns the index of the nameall:"name" field and the fields to group by
+func nameAllFields(entityType reflect.Type) (int, []nameAllField) {
+	nameIndex := -1
+	fields := make([]nameAllField, 0)
+	for i := 0; i < entityType.NumField(); i++ {
+		switch phrase := entityType.Field(i).Tag.Get("nameall"); phrase {
+		case "":
+			continue
+		case "name":
+			nameIndex = i
+		default:
+			if !strings.Contains(phrase, "%v") {
+				// this is effectively a syntax error, so kill the program
+				panic(fmt.Sprintf("nameall for %s.%s needs a %%v for the value", entityType.Name(), entityType.Field(i).Name))
+			}
+			fields = append(fields, nameAllField{index: i, phrase: phrase})
+		}
+	}
+	if nameIndex < 0 || len(fields) == 0 {
+		panic(fmt.Sprintf("%s needs a nameall:\"name\" field and a field to group by", entityType.Name()))
+	}
+	return nameIndex, fields
+}
+
+// quizNameAll asks for every entity that shares a value of one of the grouping fields with a
+// random entity. If none of the entities have a value for any of them, which can happen with a
+// dataset file, it asks for every entity.
+func quizNameAll[S ~[]E, E any](entityType string, entities S) promptAndResponse {
+	nameIndex, fields := nameAllFields(reflect.TypeOf(entities).Elem())
+
+	var field nameAllField
+	var values []string
+	for _, index := range quizRand.Perm(len(fields)) {
+		grouped := make([][]string, 0, len(entities))
+		for _, entity := range entities {
+			if entityValues := reflectValueToStrings(reflect.ValueOf(entity).Field(fields[index].index)); len(entityValues) > 0 {
+				grouped = append(grouped, entityValues)
+			}
+		}
+		if len(grouped) > 0 {
+			field, values = fields[index], randomItemFromSlice(grouped)
+			break
+		}
+	}
+
+	names := make([]string, 0)
+	if len(values) == 0 {
+		for _, entity := range entities {
+			names = append(names, reflect.ValueOf(entity).Field(nameIndex).String())
+		}
+		return promptAndResponse{
+			prompt:   fmt.Sprintf("Name every %s (separate them with commas)", entityType),
+			response: strings.Join(names, ", "),
+			allOf:    names,
+		}
+	}
+	value := randomItemFromSlice(values)
+
+	for _, entity := range entities {
+		reflectEntity := reflect.ValueOf(entity)
+		if isStringInSlice(value, reflectValueToStrings(reflectEntity.Field(field.index))) {
+			names = append(names, reflectEntity.Field(nameIndex).String())
+		}
+	}
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("Name every %s %s (separate them with commas)", entityType, fmt.Sprintf(field.phrase, value)),
+		response: strings.Join(names, ", "),
+		allOf:    names,
+	}
+}
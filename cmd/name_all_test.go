@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+type nameAllTestLake struct {
+	name      string   `nameall:"name"`
+	countries []string `nameall:"in %v"`
+}
+
+var nameAllTestLakes = []nameAllTestLake{
+	{"Superior", []string{"Canada", "United States"}},
+	{"Michigan", []string{"United States"}},
+	{"Great Bear Lake", []string{"Canada"}},
+}
+
+func TestQuizNameAll(t *testing.T) {
+	seedQuizRand(1)
+	asked := make(map[string]string)
+	for i := 0; i < 30; i++ {
+		question := quizNameAll("lake", nameAllTestLakes)
+		asked[question.prompt] = question.response
+	}
+	expected := map[string]string{
+		"Name every lake in Canada (separate them with commas)":        "Superior, Great Bear Lake",
+		"Name every lake in United States (separate them with commas)": "Superior, Michigan",
+	}
+	for prompt, response := range expected {
+		if asked[prompt] != response {
+			t.Errorf("Expected %q -> %q but got %q", prompt, response, asked[prompt])
+		}
+	}
+
+	for i := 0; i < 20; i++ {
+		question := quizNameAllStates(states)
+		if !strings.HasPrefix(question.prompt, "Name every state that joined in ") || len(question.allOf) == 0 {
+			t.Errorf("Unexpected question %q -> %q", question.prompt, question.response)
+		}
+	}
+}
+
+func TestDiffAllAnswers(t *testing.T) {
+	found, missed, wrong, typos := diffAllAnswers("great bear lake, Erie,, ", []string{"Superior", "Great Bear Lake"})
+	if found != 1 || len(missed) != 1 || missed[0] != "Superior" || len(wrong) != 1 || wrong[0] != "Erie" || typos {
+		t.Errorf("Unexpected diff: %d found, missed %v, wrong %v", found, missed, wrong)
+	}
+	if match := matchAllAnswers("great bear lake, Erie", []string{"Superior", "Great Bear Lake"}); match != answerClose {
+		t.Errorf("Expected some right answers to be close but got %v", match)
+	}
+}
+
+func TestQuizSpellingBee(t *testing.T) {
+	seedQuizRand(2)
+	question := quizSpellingBee([][]string{{"TOME", "TOTEM", "MOTE"}})
+	word := strings.TrimSuffix(strings.TrimPrefix(question.prompt, "What are other Spelling Bee words for "), " (separate by commas)?")
+	if len(question.allOf) != 2 || isStringInSlice(word, question.allOf) {
+		t.Errorf("Expected the other two words for %s but got %v", word, question.allOf)
+	}
+}
+
+func TestQuizNameAllWithoutValues(t *testing.T) {
+	unplaced := []nameAllTestLake{{"Atlantis Lake", nil}, {"Lost Lake", []string{}}}
+	question := quizNameAll("lake", unplaced)
+	if question.prompt != "Name every lake (separate them with commas)" || question.response != "Atlantis Lake, Lost Lake" {
+		t.Errorf("Expected every lake to be asked for but got %q -> %q", question.prompt, question.response)
+	}
+}
@@ -14,22 +14,22 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var nbaCmd = &cobra.Command{
-	Use:   "nba-teams",
-	Short: "Quiz US nba teams",
-	Run:   quizNbaTeams,
-}
+var nbaCmd = registerQuizArea(quizArea{
+	name:       "nba-teams",
+	aliases:    []string{"nba"},
+	tags:       []string{"sports"},
+	short:      "Quiz US nba teams",
+	generators: nbaTeamQuestions,
+	dataset:    &nbaTeams,
+	entity:     "NBA team",
+})
 
 type nbaTeam struct {
 	index int    `crossquery:"all"`
-	name  string `crossquery:"all"`
+	name  string `crossquery:"all" nameall:"name"`
 	// some areas, such as New York, have more than one team
 	area     string `crossquery:"guess"`
-	division string `crossquery:"guess"`
+	division string `crossquery:"guess" nameall:"in the %v division"`
 }
 
 const (
@@ -74,15 +74,20 @@ var nbaTeams = []nbaTeam{
 	{30, "Spurs", "San Antonio", NBA_SOUTHWEST},
 }
 
-func quizNbaTeams(cmd *cobra.Command, args []string) {
-	promptAndCheckResponse(crossQueryNbaTeamInfo(nbaTeams))
+func nbaTeamQuestions() []questionGenerator {
+	promptFuncs := []func([]nbaTeam) promptAndResponse{
+		crossQueryNbaTeamInfo,
+		crossQueryNbaTeamInfo,
+		crossQueryNbaTeamInfo,
+		quizNameAllNbaTeams,
+	}
+	return generatorsFor(promptFuncs, nbaTeams)
 }
 
 func crossQueryNbaTeamInfo(teams []nbaTeam) promptAndResponse {
-	foundTeam := randomItemFromSlice(teams)
-	return constructCrossQuery("NBA team", foundTeam)
+	return constructCrossQueryFromSlice("NBA team", teams)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(nbaCmd)
+func quizNameAllNbaTeams(teams []nbaTeam) promptAndResponse {
+	return quizNameAll("NBA team", teams)
 }
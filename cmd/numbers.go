@@ -17,7 +17,6 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"strconv"
 	"strings"
 	"time"
@@ -28,60 +27,82 @@ import (
 var numberLength int
 
 // numbersCmd represents the numbers command
-var numbersCmd = &cobra.Command{
-	Use:   "numbers",
-	Short: "Test the ability to remember large numbers",
-	Run:   quizLargeNumbers,
-}
+var numbersCmd = registerQuizArea(quizArea{
+	name:  "numbers",
+	tags:  []string{"math"},
+	short: "Test the ability to remember large numbers",
+	run:   quizLargeNumbers,
+})
 
 func generateNumberStringOfLength(length int) string {
 	numbers := make([]string, length, length)
 	for i := 0; i < length; i++ {
-		numbers[i] = strconv.Itoa(rand.Intn(10))
+		numbers[i] = strconv.Itoa(quizRand.Intn(10))
 	}
 	return strings.Join(numbers, "")
 }
 
+// numberRecallPrompt asks for the number back. It has no response, so the full screen has no
+// hints to give, which would show the number.
+var numberRecallPrompt = promptAndResponse{prompt: "Enter the number and press the Enter key when you're done"}
+
 func quizLargeNumbers(cmd *cobra.Command, args []string) {
 
 	// generate a large number
 	// display and start a timer
 	stringToMemorize := generateNumberStringOfLength(numberLength)
-	fmt.Println(stringToMemorize)
-
 	startTime := time.Now()
-	// ask user to hit a key when ready.
-	var pressedKey string
-	fmt.Println("Press enter when you've memorized the number")
-	fmt.Scanln(&pressedKey)
+	toShow := stringToMemorize
+	if hints := imageHints(stringToMemorize, currentMnemonicImages()); hints != "" {
+		toShow += "\n\nYour images:\n" + hints
+	}
+	showUntilEnter(toShow, "Press enter when you've memorized the number")
+	if stdinClosed {
+		return
+	}
 
-	fmt.Print("\u001b[3A")
-	fmt.Print("\u001b[1000D")
 	endTime := time.Now()
-	fmt.Printf("You took %.2f seconds to memorize\n", endTime.Sub(startTime).Seconds())
-	guess := responseFromPrompt(promptAndResponse{"Enter the number and press the Enter key when you're done", stringToMemorize})
+	quizPrintf("You took %.2f seconds to memorize\n", endTime.Sub(startTime).Seconds())
+	guess := responseFromPrompt(numberRecallPrompt)
+	if stdinClosed {
+		return
+	}
 
+	match := answerCorrect
 	if guess == stringToMemorize {
-		fmt.Println("Awesome! You memorized it!")
+		quizPrintln("Awesome! You memorized it!")
 	} else {
-		fmt.Println("Original   : " + stringToMemorize)
-		fmt.Println("Your guess : " + guess)
-
-		// find score, which is the last correct character
-		score := 0
-		for i := 0; i < len(stringToMemorize) && i < len(guess); i++ {
-			if []byte(stringToMemorize)[i] == []byte(guess)[i] {
-				score = score + 1
-			} else {
-				break
-			}
+		match = answerIncorrect
+		quizPrintln("Original   : " + stringToMemorize)
+		quizPrintln("Your guess : " + guess)
+		quizPrintf("Score: %d\n", numberScore(stringToMemorize, guess))
+	}
+	if activeQuizScreen != nil {
+		activeQuizScreen.score(match, numberRecallPrompt)
+	}
+
+	// the history tracks how well you do at numbers of this length, since the number itself
+	// won't come up again
+	recordAnswer(scheduledQuestion{
+		area:         "numbers",
+		questionType: "quizLargeNumbers",
+		question:     promptAndResponse{prompt: fmt.Sprintf("Memorize a %d digit number", numberLength), response: stringToMemorize},
+	}, match, time.Since(startTime), 0)
+}
+
+// numberScore is how many digits of the guess are right before the first mistake
+func numberScore(number string, guess string) int {
+	score := 0
+	for i := 0; i < len(number) && i < len(guess); i++ {
+		if number[i] != guess[i] {
+			break
 		}
-		fmt.Printf("Score: %d\n", score)
+		score++
 	}
+	return score
 }
 
 func init() {
-	memoryquizCmd.AddCommand(numbersCmd)
 	numbersCmd.Flags().IntVarP(&numberLength, "length", "l", 20, "length of number to present")
 
 	// Here you will define your flags and configuration settings.
@@ -14,15 +14,15 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var orkneysCmd = &cobra.Command{
-	Use:   "orkneyss",
-	Short: "Quiz Orkney islands",
-	Run:   quizOrkneys,
-}
+var orkneysCmd = registerQuizArea(quizArea{
+	name:       "orkneys",
+	aliases:    []string{"orkneyss"},
+	tags:       []string{"geography"},
+	short:      "Quiz Orkney islands",
+	generators: orkneyQuestions,
+	dataset:    &orkneys,
+	entity:     "Orkneys",
+})
 
 var orkneys = []island{
 	{1, "Papa Westray"},
@@ -54,12 +54,11 @@ var orkneys = []island{
 	{27, "Graemsay"},
 }
 
-func quizOrkneys(cmd *cobra.Command, args []string) {
-
-	chosenIsland := randomItemFromSlice(orkneys)
-	promptAndCheckResponse(constructCrossQuery("Orkneys", chosenIsland))
+func orkneyQuestions() []questionGenerator {
+	promptFuncs := []func([]island) promptAndResponse{crossQueryOrkney}
+	return generatorsFor(promptFuncs, orkneys)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(orkneysCmd)
+func crossQueryOrkney(islands []island) promptAndResponse {
+	return constructCrossQueryFromSlice("Orkneys", islands)
 }
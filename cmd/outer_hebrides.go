@@ -14,15 +14,14 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var outerHebridesCmd = &cobra.Command{
-	Use:   "outer-hebrides",
-	Short: "Quiz Outer Hebrides islands",
-	Run:   quizOuterHebrides,
-}
+var outerHebridesCmd = registerQuizArea(quizArea{
+	name:       "outer-hebrides",
+	tags:       []string{"geography"},
+	short:      "Quiz Outer Hebrides islands",
+	generators: outerHebridesQuestions,
+	dataset:    &outerHebrides,
+	entity:     "Outer Hebrides",
+})
 
 // use a generic struct since this will apply to
 // Inner Hebrides, Orkney, and other groups of islands
@@ -69,12 +68,11 @@ var outerHebrides = []island{
 	{35, "Shiant Islands"},
 }
 
-func quizOuterHebrides(cmd *cobra.Command, args []string) {
-
-	chosenIsland := randomItemFromSlice(outerHebrides)
-	promptAndCheckResponse(constructCrossQuery("Outer Hebrides", chosenIsland))
+func outerHebridesQuestions() []questionGenerator {
+	promptFuncs := []func([]island) promptAndResponse{crossQueryOuterHebride}
+	return generatorsFor(promptFuncs, outerHebrides)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(outerHebridesCmd)
+func crossQueryOuterHebride(islands []island) promptAndResponse {
+	return constructCrossQueryFromSlice("Outer Hebrides", islands)
 }
@@ -0,0 +1,115 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	periodicTableGroups = 18
+	// periodicTableCellWidth fits a symbol and a space between it and the next one
+	periodicTableCellWidth = 3
+	// periodicTableBlank is what's shown in place of the element to fill in
+	periodicTableBlank = "?"
+)
+
+// periodicTableRows lays the elements out as the periodic table: a row for each period, with
+// each element under its group, and then the lanthanides and actinides in rows of their own,
+// lined up under group 3 the way they usually are
+func periodicTableRows(elements []elementInfo) ([][]*elementInfo, [][]*elementInfo) {
+	periods := 0
+	for _, element := range elements {
+		if element.period > periods {
+			periods = element.period
+		}
+	}
+	rows := make([][]*elementInfo, periods)
+	fBlockRows := make(map[int][]*elementInfo)
+	for i := range elements {
+		element := &elements[i]
+		group, err := strconv.Atoi(element.group)
+		switch {
+		case element.period < 1:
+			continue
+		case element.group == "":
+			if len(fBlockRows[element.period]) == 0 {
+				// leave groups 1 and 2 empty
+				fBlockRows[element.period] = make([]*elementInfo, 2)
+			}
+			fBlockRows[element.period] = append(fBlockRows[element.period], element)
+		case err == nil && group >= 1 && group <= periodicTableGroups:
+			if len(rows[element.period-1]) == 0 {
+				rows[element.period-1] = make([]*elementInfo, periodicTableGroups)
+			}
+			rows[element.period-1][group-1] = element
+		}
+	}
+
+	fBlock := make([][]*elementInfo, 0, len(fBlockRows))
+	for period := 1; period <= periods; period++ {
+		if row, ok := fBlockRows[period]; ok {
+			fBlock = append(fBlock, row)
+		}
+	}
+	return rows, fBlock
+}
+
+// drawPeriodicTable draws the table with each element's symbol, except for blank's, which is
+// shown as a question mark
+func drawPeriodicTable(elements []elementInfo, blank *elementInfo) string {
+	drawRow := func(row []*elementInfo) string {
+		cells := make([]string, 0, len(row))
+		for _, element := range row {
+			cell := ""
+			switch {
+			case element == nil:
+			case element == blank:
+				cell = periodicTableBlank
+			default:
+				cell = element.symbol
+			}
+			cells = append(cells, fmt.Sprintf("%-*s", periodicTableCellWidth, cell))
+		}
+		return strings.TrimRight(strings.Join(cells, ""), " ")
+	}
+
+	rows, fBlock := periodicTableRows(elements)
+	lines := make([]string, 0, len(rows)+len(fBlock)+1)
+	for _, row := range rows {
+		lines = append(lines, drawRow(row))
+	}
+	if len(fBlock) > 0 {
+		lines = append(lines, "")
+		for _, row := range fBlock {
+			lines = append(lines, drawRow(row))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// quizBlankInPeriodicTable draws the periodic table with one element left out and asks which
+// one goes there
+func quizBlankInPeriodicTable(elements []elementInfo) promptAndResponse {
+	blank := &elements[quizRand.Intn(len(elements))]
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("Which element goes where the %s is?\n%s", periodicTableBlank, drawPeriodicTable(elements, blank)),
+		response: blank.name,
+		aliases:  []string{blank.symbol},
+	}
+}
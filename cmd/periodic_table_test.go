@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestElementsLayout(t *testing.T) {
+	rows, fBlock := periodicTableRows(elements)
+	if len(rows) != 7 || len(fBlock) != 2 {
+		t.Fatalf("Expected 7 periods and 2 f-block rows but got %d and %d", len(rows), len(fBlock))
+	}
+	placed := 0
+	for _, row := range append(rows, fBlock...) {
+		for _, element := range row {
+			if element != nil {
+				placed++
+			}
+		}
+	}
+	if placed != len(elements) {
+		t.Errorf("Expected all %d elements in the table but placed %d", len(elements), placed)
+	}
+	if iron := rows[3][7]; iron == nil || iron.symbol != "Fe" {
+		t.Errorf("Expected Fe in group 8, period 4 but got %v", iron)
+	}
+	if lanthanum := fBlock[0][2]; lanthanum == nil || lanthanum.symbol != "La" {
+		t.Errorf("Expected La to start the lanthanides under group 3 but got %v", lanthanum)
+	}
+}
+
+func TestElementData(t *testing.T) {
+	for i, element := range elements {
+		if element.atomicNumber != i+1 {
+			t.Errorf("Expected %s to be element %d", element.name, i+1)
+		}
+		if i > 0 && element.period < elements[i-1].period {
+			t.Errorf("%s is in an earlier period than %s", element.name, elements[i-1].name)
+		}
+		if (element.group == "") != (element.block == "f") {
+			t.Errorf("%s is in group %q but the %s-block", element.name, element.group, element.block)
+		}
+		if element.atomicMass <= 0 || element.category == "" || element.discovered == "" {
+			t.Errorf("%s is missing data: %+v", element.name, element)
+		}
+	}
+}
+
+func TestQuizBlankInPeriodicTable(t *testing.T) {
+	seedQuizRand(1)
+	for i := 0; i < 20; i++ {
+		question := quizBlankInPeriodicTable(elements)
+		lines := strings.Split(question.prompt, "\n")
+		if lines[0] != "Which element goes where the ? is?" || len(lines) != 11 || strings.Count(strings.Join(lines[1:], "\n"), "?") != 1 {
+			t.Fatalf("Unexpected table:\n%s", question.prompt)
+		}
+		if isStringInSlice(question.aliases[0], strings.Fields(strings.Join(lines[1:], " "))) {
+			t.Errorf("Expected %s to be blanked out:\n%s", question.aliases[0], question.prompt)
+		}
+		if matchAnswer(question.aliases[0], question) != answerCorrect {
+			t.Errorf("Expected the symbol %s to be accepted for %s", question.aliases[0], question.response)
+		}
+	}
+}
+
+func TestQuizElementGroup(t *testing.T) {
+	seedQuizRand(2)
+	for i := 0; i < 50; i++ {
+		if question := quizElementGroup(elements); question.response == "" {
+			t.Errorf("Expected f-block elements to be left out but got %q", question.prompt)
+		}
+	}
+	question := quizElementAtPosition(elements[16:17])
+	if question.prompt != "Which element is in group 17, period 3?" || question.response != "Chlorine" {
+		t.Errorf("Unexpected question %q -> %q", question.prompt, question.response)
+	}
+}
+
+func TestCrossQueryElementGroup(t *testing.T) {
+	seedQuizRand(3)
+	for i := 0; i < 50; i++ {
+		if cerium := constructCrossQueryFromSlice("atomic element", elements[57:58]); strings.Contains(cerium.prompt, "group") {
+			t.Fatalf("Expected no group question for cerium but got %q", cerium.prompt)
+		}
+	}
+
+	asked := false
+	for i := 0; i < 200 && !asked; i++ {
+		question := constructCrossQueryFromSlice("atomic element", elements[25:26])
+		if strings.HasPrefix(question.prompt, "What is the group of ") {
+			asked = true
+			if question.response != "8" {
+				t.Errorf("Expected iron to be in group 8 but got %q", question.response)
+			}
+		}
+		if strings.Contains(question.prompt, "with group of") {
+			t.Errorf("Expected group to only be guessed but got %q", question.prompt)
+		}
+	}
+	if !asked {
+		t.Errorf("Expected a question about iron's group")
+	}
+}
+
+func TestElementsValidateWithoutWarnings(t *testing.T) {
+	if problems := validateDataset(&elements); len(problems) > 0 {
+		t.Errorf("Expected the f-block's blank groups to pass validation but got %v", problems)
+	}
+}
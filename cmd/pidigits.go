@@ -17,18 +17,19 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"strings"
-
-	"github.com/spf13/cobra"
 )
 
 // pidigitsCmd represents the pidigits command
-var pidigitsCmd = &cobra.Command{
-	Use:   "pidigits",
-	Short: "Quiz recall of chunks of pi",
-	Run:   quizPiDigts,
-}
+var pidigitsCmd = registerQuizArea(quizArea{
+	name:       "pidigits",
+	aliases:    []string{"pi"},
+	tags:       []string{"math"},
+	short:      "Quiz recall of chunks of pi",
+	generators: piDigitQuestions,
+	dataset:    &piChunks,
+	ordinal:    "pi chunk",
+})
 
 var piChunks = []string{
 	"3141",
@@ -87,32 +88,40 @@ var piChunks = []string{
 
 type quizPi func([]string) promptAndResponse
 
-func quizPiDigts(cmd *cobra.Command, args []string) {
+func piDigitQuestions() []questionGenerator {
 	quizzes := []quizPi{
 		quizIndexOfPiChunk,
 		quizPiChunkByIndex,
 		quizPiDigitByIndex,
+		quizRecitePiChunks,
 	}
 
-	quiz := quizzes[rand.Intn(len(quizzes))]
-	promptAndCheckResponse(quiz(piChunks))
+	return generatorsFor(quizzes, piChunks)
 }
 
 func quizIndexOfPiChunk(chunks []string) promptAndResponse {
 	return quizIndexOfStringInList(chunks)
 }
 
+// quizPiChunkByIndex and quizPiDigitByIndex hint with your image for the chunk, if you've stored one
 func quizPiChunkByIndex(chunks []string) promptAndResponse {
-	return quizStringAtIndexInList("pi chunk", chunks)
+	chunkIndex := quizRand.Intn(len(chunks))
+	question := stringAtIndexQuestion("pi chunk", chunks, chunkIndex)
+	question.hint = chunkImage(chunks[chunkIndex], currentMnemonicImages())
+	return question
 }
 
 func quizPiDigitByIndex(chunks []string) promptAndResponse {
-	chunkIndex := rand.Intn(len(piChunks))
+	chunkIndex := quizRand.Intn(len(piChunks))
 	digits := strings.Split(chunks[chunkIndex], "")
-	indexInChunk := rand.Intn(len(digits))
-	return promptAndResponse{fmt.Sprintf("What pi digit is at position %d?", (chunkIndex*4)+indexInChunk+1), digits[indexInChunk]}
+	indexInChunk := quizRand.Intn(len(digits))
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("What pi digit is at position %d?", (chunkIndex*4)+indexInChunk+1),
+		response: digits[indexInChunk],
+		hint:     chunkImage(chunks[chunkIndex], currentMnemonicImages()),
+	}
 }
 
-func init() {
-	memoryquizCmd.AddCommand(pidigitsCmd)
+func quizRecitePiChunks(chunks []string) promptAndResponse {
+	return quizReciteList("pi chunks", chunks)
 }
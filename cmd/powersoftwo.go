@@ -18,52 +18,50 @@ package cmd
 import (
 	"fmt"
 	"math"
-	"math/rand"
 	"strconv"
-
-	"github.com/spf13/cobra"
 )
 
 // powersoftwoCmd represents the powersoftwo command
-var powersoftwoCmd = &cobra.Command{
-	Use:   "powersoftwo",
-	Short: "Memory quiz on powers of two (up to 2^32)",
-	Run:   quizPowersOfTwo,
-}
+var powersoftwoCmd = registerQuizArea(quizArea{
+	name:       "powersoftwo",
+	aliases:    []string{"powers-of-two"},
+	tags:       []string{"math"},
+	short:      "Memory quiz on powers of two (up to 2^32)",
+	generators: powersOfTwoQuestions,
+})
+
+const maxPowerOfTwoExponent = 32
 
-func quizPowersOfTwo(cmd *cobra.Command, args []string) {
-	exponent := rand.Intn(33)
+// each of the quiz functions takes the largest exponent to ask about
+func powersOfTwoQuestions() []questionGenerator {
 	funcs := []func(int) promptAndResponse{
 		quizExponentForPowerOfTwo,
 		quizPowerOfTwoFromExponent,
 		quizPowerOfTwoOrderOfMagnitude,
 	}
-	quizFunc := funcs[rand.Intn(len(funcs))]
-	promptAndCheckResponse(quizFunc(exponent))
+	return generatorsFor(funcs, maxPowerOfTwoExponent)
 }
 
-func quizExponentForPowerOfTwo(exponent int) promptAndResponse {
+func quizExponentForPowerOfTwo(maxExponent int) promptAndResponse {
+	exponent := quizRand.Intn(maxExponent + 1)
 	twoToExponent := int(math.Exp2(float64(exponent)))
-	return promptAndResponse{fmt.Sprintf("What exponent for 2 gives you %d?", twoToExponent), strconv.Itoa(exponent)}
+	return promptAndResponse{prompt: fmt.Sprintf("What exponent for 2 gives you %d?", twoToExponent), response: strconv.Itoa(exponent)}
 }
 
-func quizPowerOfTwoFromExponent(exponent int) promptAndResponse {
+func quizPowerOfTwoFromExponent(maxExponent int) promptAndResponse {
+	exponent := quizRand.Intn(maxExponent + 1)
 	twoToExponent := powerOfTwoFromExponent(exponent)
-	return promptAndResponse{fmt.Sprintf("What is 2^%d?", exponent), strconv.Itoa(twoToExponent)}
+	return promptAndResponse{prompt: fmt.Sprintf("What is 2^%d?", exponent), response: strconv.Itoa(twoToExponent)}
 }
 
 // quiz the order of magnitude (1, 10, 10000, etc) for a given power of two
-func quizPowerOfTwoOrderOfMagnitude(exponent int) promptAndResponse {
-
+func quizPowerOfTwoOrderOfMagnitude(maxExponent int) promptAndResponse {
+	exponent := quizRand.Intn(maxExponent + 1)
 	twoToExponent := powerOfTwoFromExponent(exponent)
 	log := int(math.Log10(float64(twoToExponent)))
-	return promptAndResponse{fmt.Sprintf("What is the order of magnitude of 2^%d", exponent), strconv.Itoa(int(math.Pow10(log)))}
+	return promptAndResponse{prompt: fmt.Sprintf("What is the order of magnitude of 2^%d", exponent), response: strconv.Itoa(int(math.Pow10(log)))}
 }
 
 func powerOfTwoFromExponent(exponent int) int {
 	return int(math.Exp2(float64(exponent)))
 }
-
-func init() {
-	memoryquizCmd.AddCommand(powersoftwoCmd)
-}
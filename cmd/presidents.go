@@ -17,79 +17,91 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
+	"regexp"
 	"strconv"
 	"strings"
-
-	"github.com/spf13/cobra"
 )
 
 // presidentsCmd represents the presidents command
-var presidentsCmd = &cobra.Command{
-	Use:   "presidents",
-	Short: "Memory quizzes about presidents",
-	Run:   quizPresidents,
-}
+var presidentsCmd = registerQuizArea(quizArea{
+	name:       "presidents",
+	tags:       []string{"history", "politics"},
+	short:      "Memory quizzes about presidents",
+	generators: presidentQuestions,
+	dataset:    &presidents,
+	entity:     "president",
+})
 
 type president struct {
 	number         int    `crossquery:"all"`
-	name           string `crossquery:"all"`
-	startYear      int    `crossquery:"all" crossqueryname:"first year of Presidency"`
+	name           string `crossquery:"all" rank:"name"`
+	startYear      int    `crossquery:"all" crossqueryname:"first year of Presidency" rank:"asc" rankwords:"took office later,took office earlier,took office last,took office first"`
 	vicePresidents []string
 	firstLadies    []string `crossquery:"given" crossqueryname:"First Lady"`
 }
 
-func quizPresidents(cmd *cobra.Command, args []string) {
-	presidents := []president{
-		{1, "George Washington", 1789, []string{"John Adams"}, []string{"Martha Washington"}},
-		{2, "John Adams", 1797, []string{"Thomas Jefferson"}, []string{"Abigail Adams"}},
-		{3, "Thomas Jefferson", 1801, []string{"Aaron Burr", "George Clinton"}, []string{"Martha Jefferson"}},
-		{4, "James Madison", 1809, []string{"George Clinton", "Elbridge Gerry"}, []string{"Dolley Madison"}},
-		{5, "James Monroe", 1817, []string{"Daniel Tompkins"}, []string{"Elizabeth Monroe"}},
-		{6, "John Quincy Adams", 1825, []string{"John C. Calhoun"}, []string{"Louisa Adams"}},
-		{7, "Andrew Jackson", 1829, []string{"John C. Calhoun", "Martin Van Buren"}, []string{"Rachel Jackson", "Emily Donelson"}},
-		{8, "Martin Van Buren", 1837, []string{"Richard Mentor Johnson"}, []string{"Hannah Van Buren", "Angelica Van Buren"}},
-		{9, "William Henry Harrison", 1841, []string{"John Tyler"}, []string{"Anna Harrison", "Jane Harrison"}},
-		{10, "John Tyler", 1841, []string{}, []string{"Letitia Tyler", "Julia Tyler"}},
-		{11, "James K. Polk", 1845, []string{"George Dallas"}, []string{"Sarah Polk"}},
-		{12, "Zachary Taylor", 1849, []string{"Millard Fillmore"}, []string{"Margaret Taylor"}},
-		{13, "Millard Fillmore", 1850, []string{}, []string{"Abigail Powers Fillmore"}},
-		{14, "Franklin Pierce", 1853, []string{"William R. King"}, []string{"Jane Pierce"}},
-		{15, "James Buchanan", 1857, []string{"John C. Breckinridge"}, []string{"Harriet Lane"}},
-		{16, "Abraham Lincoln", 1861, []string{"Hannibal Hamlin", "Andrew Johnson"}, []string{"Mary Lincoln"}},
-		{17, "Andrew Johnson", 1865, []string{}, []string{"Eliza Johnson", "Martha Johnson Patterson"}},
-		{18, "Ulysses S. Grant", 1869, []string{"Schuyler Colfax", "Henry Wilson"}, []string{"Julia Grant"}},
-		{19, "Rutherford B. Hayes", 1877, []string{"William Wheeler"}, []string{"Lucy Hayes"}},
-		{20, "James Garfield", 1881, []string{"Chester A. Arthur"}, []string{"Lucretia Garfield"}},
-		{21, "Chester A. Arthur", 1881, []string{}, []string{"Ellen Arthur", "Mary Arthur McElroy"}},
-		{22, "Grover Cleveland (22)", 1885, []string{"Thomas Hendricks"}, []string{"Rose Cleveland", "Frances Cleveland"}},
-		{23, "Benjamin Harrison", 1889, []string{}, []string{"Caroline Harrison"}},
-		{24, "Grover Cleveland (24)", 1893, []string{"Adlai Stevenson"}, []string{"Frances Cleveland"}},
-		{25, "William McKinley", 1897, []string{"Garret Hobart", "Theodore Roosevelt"}, []string{"Ida McKinley"}},
-		{26, "Theodore Roosevelt", 1901, []string{"Charles Fairbanks"}, []string{"Edith Roosevelt"}},
-		{27, "William Howard Taft", 1909, []string{"James Sherman"}, []string{"Helen Taft"}},
-		{28, "Woodrow Wilson", 1913, []string{"Thomas Marshall"}, []string{"Ellen Wilson", "Edith Wilson"}},
-		{29, "Warren G. Harding", 1921, []string{"Calvin Coolidge"}, []string{"Florence Harding"}},
-		{30, "Calvin Coolidge", 1923, []string{"Charles Dawes"}, []string{"Grace Coolidge"}},
-		{31, "Herbert Hoover", 1929, []string{"Charles Curtis"}, []string{"Lou Hoover"}},
-		{32, "Franklin Delano Roosevelt", 1933, []string{"John Garner", "Henry Wallace", "Harry S. Truman"}, []string{"Eleanor Roosevelt"}},
-		{33, "Harry S. Truman", 1945, []string{"Alben Barkley"}, []string{"Elizabeth 'Bess' Truman"}},
-		{34, "Dwight D. Eisenhower", 1953, []string{"Richard Nixon"}, []string{"Mamie Eisenhower"}},
-		{35, "John F. Kennedy", 1961, []string{"Lyndon B. Johnson"}, []string{"Jacqueline Kennedy"}},
-		{36, "Lyndon B. Johnson", 1963, []string{"Hubert Humphrey"}, []string{"Claudia 'Ladybird' Johnson"}},
-		{37, "Richard M. Nixon", 1969, []string{"Spiro Agnew", "Gerald Ford"}, []string{"Patricia Nixon"}},
-		{38, "Gerald Ford", 1974, []string{"Nelson Rockefeller"}, []string{"Betty Ford"}},
-		{39, "Jimmy Carter", 1977, []string{"Walter Mondale"}, []string{"Rosalynn Carter"}},
-		{40, "Ronald Reagan", 1981, []string{"George H. W. Bush"}, []string{"Nancy Reagan"}},
-		{41, "George H. W. Bush", 1989, []string{"Dan Quayle"}, []string{"Barbara Bush"}},
-		{42, "Bill Clinton", 1993, []string{"Al Gore"}, []string{"Hillary Clinton"}},
-		{43, "George W. Bush", 2001, []string{"Dick Cheney"}, []string{"Laura Bush"}},
-		{44, "Barack Obama", 2009, []string{"Joseph R. Biden"}, []string{"Michelle Obama"}},
-		{45, "Donald Trump (45)", 2017, []string{"Mike Pence"}, []string{"Melania Trump"}},
-		{46, "Joseph R. Biden", 2021, []string{"Kamala Harris"}, []string{"Dr. Jill Biden"}},
-		{47, "Donald Trump (47)", 2025, []string{"JD Vance"}, []string{"Melania Trump"}},
+// presidents who served non-consecutive terms have the term in their name, as in
+// Grover Cleveland (22), but the plain name is also a right answer
+var presidentTermSuffix = regexp.MustCompile(` \(\d+\)$`)
+
+func (p president) answerAliases(fieldName string) []string {
+	if fieldName == "name" && presidentTermSuffix.MatchString(p.name) {
+		return []string{presidentTermSuffix.ReplaceAllString(p.name, "")}
 	}
+	return nil
+}
+
+var presidents = []president{
+	{1, "George Washington", 1789, []string{"John Adams"}, []string{"Martha Washington"}},
+	{2, "John Adams", 1797, []string{"Thomas Jefferson"}, []string{"Abigail Adams"}},
+	{3, "Thomas Jefferson", 1801, []string{"Aaron Burr", "George Clinton"}, []string{"Martha Jefferson"}},
+	{4, "James Madison", 1809, []string{"George Clinton", "Elbridge Gerry"}, []string{"Dolley Madison"}},
+	{5, "James Monroe", 1817, []string{"Daniel Tompkins"}, []string{"Elizabeth Monroe"}},
+	{6, "John Quincy Adams", 1825, []string{"John C. Calhoun"}, []string{"Louisa Adams"}},
+	{7, "Andrew Jackson", 1829, []string{"John C. Calhoun", "Martin Van Buren"}, []string{"Rachel Jackson", "Emily Donelson"}},
+	{8, "Martin Van Buren", 1837, []string{"Richard Mentor Johnson"}, []string{"Hannah Van Buren", "Angelica Van Buren"}},
+	{9, "William Henry Harrison", 1841, []string{"John Tyler"}, []string{"Anna Harrison", "Jane Harrison"}},
+	{10, "John Tyler", 1841, []string{}, []string{"Letitia Tyler", "Julia Tyler"}},
+	{11, "James K. Polk", 1845, []string{"George Dallas"}, []string{"Sarah Polk"}},
+	{12, "Zachary Taylor", 1849, []string{"Millard Fillmore"}, []string{"Margaret Taylor"}},
+	{13, "Millard Fillmore", 1850, []string{}, []string{"Abigail Powers Fillmore"}},
+	{14, "Franklin Pierce", 1853, []string{"William R. King"}, []string{"Jane Pierce"}},
+	{15, "James Buchanan", 1857, []string{"John C. Breckinridge"}, []string{"Harriet Lane"}},
+	{16, "Abraham Lincoln", 1861, []string{"Hannibal Hamlin", "Andrew Johnson"}, []string{"Mary Lincoln"}},
+	{17, "Andrew Johnson", 1865, []string{}, []string{"Eliza Johnson", "Martha Johnson Patterson"}},
+	{18, "Ulysses S. Grant", 1869, []string{"Schuyler Colfax", "Henry Wilson"}, []string{"Julia Grant"}},
+	{19, "Rutherford B. Hayes", 1877, []string{"William Wheeler"}, []string{"Lucy Hayes"}},
+	{20, "James Garfield", 1881, []string{"Chester A. Arthur"}, []string{"Lucretia Garfield"}},
+	{21, "Chester A. Arthur", 1881, []string{}, []string{"Ellen Arthur", "Mary Arthur McElroy"}},
+	{22, "Grover Cleveland (22)", 1885, []string{"Thomas Hendricks"}, []string{"Rose Cleveland", "Frances Cleveland"}},
+	{23, "Benjamin Harrison", 1889, []string{}, []string{"Caroline Harrison"}},
+	{24, "Grover Cleveland (24)", 1893, []string{"Adlai Stevenson"}, []string{"Frances Cleveland"}},
+	{25, "William McKinley", 1897, []string{"Garret Hobart", "Theodore Roosevelt"}, []string{"Ida McKinley"}},
+	{26, "Theodore Roosevelt", 1901, []string{"Charles Fairbanks"}, []string{"Edith Roosevelt"}},
+	{27, "William Howard Taft", 1909, []string{"James Sherman"}, []string{"Helen Taft"}},
+	{28, "Woodrow Wilson", 1913, []string{"Thomas Marshall"}, []string{"Ellen Wilson", "Edith Wilson"}},
+	{29, "Warren G. Harding", 1921, []string{"Calvin Coolidge"}, []string{"Florence Harding"}},
+	{30, "Calvin Coolidge", 1923, []string{"Charles Dawes"}, []string{"Grace Coolidge"}},
+	{31, "Herbert Hoover", 1929, []string{"Charles Curtis"}, []string{"Lou Hoover"}},
+	{32, "Franklin Delano Roosevelt", 1933, []string{"John Garner", "Henry Wallace", "Harry S. Truman"}, []string{"Eleanor Roosevelt"}},
+	{33, "Harry S. Truman", 1945, []string{"Alben Barkley"}, []string{"Elizabeth 'Bess' Truman"}},
+	{34, "Dwight D. Eisenhower", 1953, []string{"Richard Nixon"}, []string{"Mamie Eisenhower"}},
+	{35, "John F. Kennedy", 1961, []string{"Lyndon B. Johnson"}, []string{"Jacqueline Kennedy"}},
+	{36, "Lyndon B. Johnson", 1963, []string{"Hubert Humphrey"}, []string{"Claudia 'Ladybird' Johnson"}},
+	{37, "Richard M. Nixon", 1969, []string{"Spiro Agnew", "Gerald Ford"}, []string{"Patricia Nixon"}},
+	{38, "Gerald Ford", 1974, []string{"Nelson Rockefeller"}, []string{"Betty Ford"}},
+	{39, "Jimmy Carter", 1977, []string{"Walter Mondale"}, []string{"Rosalynn Carter"}},
+	{40, "Ronald Reagan", 1981, []string{"George H. W. Bush"}, []string{"Nancy Reagan"}},
+	{41, "George H. W. Bush", 1989, []string{"Dan Quayle"}, []string{"Barbara Bush"}},
+	{42, "Bill Clinton", 1993, []string{"Al Gore"}, []string{"Hillary Clinton"}},
+	{43, "George W. Bush", 2001, []string{"Dick Cheney"}, []string{"Laura Bush"}},
+	{44, "Barack Obama", 2009, []string{"Joseph R. Biden"}, []string{"Michelle Obama"}},
+	{45, "Donald Trump (45)", 2017, []string{"Mike Pence"}, []string{"Melania Trump"}},
+	{46, "Joseph R. Biden", 2021, []string{"Kamala Harris"}, []string{"Dr. Jill Biden"}},
+	{47, "Donald Trump (47)", 2025, []string{"JD Vance"}, []string{"Melania Trump"}},
+}
 
+func presidentQuestions() []questionGenerator {
 	var promptFuncs []presidentQuestion
 
 	if vicePresidentsOnly {
@@ -110,11 +122,12 @@ func quizPresidents(cmd *cobra.Command, args []string) {
 			quizVicePresidents,
 			quizPresidentsForVicePresident,
 			quizFirstLadiesFromPresident,
+			quizRecitePresidents,
+			quizRankPresidents,
 		}
 	}
 
-	function := randomItemFromSlice(promptFuncs)
-	promptAndCheckResponse(function(presidents))
+	return generatorsFor(promptFuncs, presidents)
 }
 
 var vicePresidentsOnly bool
@@ -122,31 +135,30 @@ var vicePresidentsOnly bool
 type presidentQuestion func([]president) promptAndResponse
 
 func crossQueryPresidentInfo(presidents []president) promptAndResponse {
-	president := randomItemFromSlice(presidents)
-	return constructCrossQuery("president", president)
+	return constructCrossQueryFromSlice("president", presidents)
 }
 
 func quizBefore(presidents []president) promptAndResponse {
 	index := 0
 	for index == 0 {
-		index = rand.Intn(len(presidents))
+		index = quizRand.Intn(len(presidents))
 	}
-	return promptAndResponse{fmt.Sprintf("Who was President before %s?", presidents[index].name), presidents[index-1].name}
+	return promptAndResponse{prompt: fmt.Sprintf("Who was President before %s?", presidents[index].name), response: presidents[index-1].name, aliases: presidents[index-1].answerAliases("name")}
 }
 
 func quizAfter(presidents []president) promptAndResponse {
 	index := len(presidents) - 1
 	for index == len(presidents)-1 {
-		index = rand.Intn(len(presidents))
+		index = quizRand.Intn(len(presidents))
 	}
-	return promptAndResponse{fmt.Sprintf("Who was President after %s?", presidents[index].name), presidents[index+1].name}
+	return promptAndResponse{prompt: fmt.Sprintf("Who was President after %s?", presidents[index].name), response: presidents[index+1].name, aliases: presidents[index+1].answerAliases("name")}
 }
 
 func quizWhenPresidentEnded(presidents []president) promptAndResponse {
-	presidentIndex := rand.Intn(len(presidents) - 1)
+	presidentIndex := quizRand.Intn(len(presidents) - 1)
 	president := presidents[presidentIndex]
 	nextPresident := presidents[presidentIndex+1]
-	return promptAndResponse{fmt.Sprintf("What was the last year of %s's presidency?", president.name), strconv.Itoa(nextPresident.startYear)}
+	return promptAndResponse{prompt: fmt.Sprintf("What was the last year of %s's presidency?", president.name), response: strconv.Itoa(nextPresident.startYear)}
 }
 
 // ask who was president in a given year
@@ -157,7 +169,7 @@ func quizWhoWasPresidentWhen(presidents []president) promptAndResponse {
 	distanceBetweenStarts := 0
 	for distanceBetweenStarts < 2 {
 		// it doesn't make sense to ask for the next president for the one currently in office
-		presidentIndex := rand.Intn(len(presidents) - 1)
+		presidentIndex := quizRand.Intn(len(presidents) - 1)
 		president1 = presidents[presidentIndex]
 		president2 = presidents[presidentIndex+1]
 		distanceBetweenStarts = president2.startYear - president1.startYear
@@ -167,9 +179,9 @@ func quizWhoWasPresidentWhen(presidents []president) promptAndResponse {
 	// we'll query about.
 	offsetFromCurrentPresident := 0
 	for offsetFromCurrentPresident == 0 {
-		offsetFromCurrentPresident = rand.Intn(president2.startYear - president1.startYear)
+		offsetFromCurrentPresident = quizRand.Intn(president2.startYear - president1.startYear)
 	}
-	return promptAndResponse{fmt.Sprintf("Who was president in %d?", president1.startYear+offsetFromCurrentPresident), president1.name}
+	return promptAndResponse{prompt: fmt.Sprintf("Who was president in %d?", president1.startYear+offsetFromCurrentPresident), response: president1.name, aliases: president1.answerAliases("name")}
 }
 
 func quizVicePresidents(presidents []president) promptAndResponse {
@@ -177,7 +189,7 @@ func quizVicePresidents(presidents []president) promptAndResponse {
 	for len(president.vicePresidents) == 0 {
 		president = randomItemFromSlice(presidents)
 	}
-	return promptAndResponse{fmt.Sprintf("Who served as vice president under %s (separate names with commas)?", president.name), strings.Join(president.vicePresidents, ",")}
+	return promptAndResponse{prompt: fmt.Sprintf("Who served as vice president under %s (separate names with commas)?", president.name), response: strings.Join(president.vicePresidents, ", "), allOf: president.vicePresidents}
 }
 
 // the complicated logic here is because some vice presidents served under more than one president
@@ -188,7 +200,7 @@ func quizPresidentsForVicePresident(presidents []president) promptAndResponse {
 		p = randomItemFromSlice(presidents)
 	}
 
-	vp := p.vicePresidents[rand.Intn(len(p.vicePresidents))]
+	vp := p.vicePresidents[quizRand.Intn(len(p.vicePresidents))]
 	presList := make([]string, 0)
 
 	for _, president := range presidents {
@@ -196,7 +208,7 @@ func quizPresidentsForVicePresident(presidents []president) promptAndResponse {
 			presList = append(presList, president.name)
 		}
 	}
-	return promptAndResponse{fmt.Sprintf("Which Presidents did %s serve under as Vice President? (Separate names with commas)", vp), strings.Join(presList, ",")}
+	return promptAndResponse{prompt: fmt.Sprintf("Which Presidents did %s serve under as Vice President? (Separate names with commas)", vp), response: strings.Join(presList, ", "), allOf: presList}
 }
 
 func quizFirstLadiesFromPresident(presidents []president) promptAndResponse {
@@ -205,7 +217,7 @@ func quizFirstLadiesFromPresident(presidents []president) promptAndResponse {
 		// not a necessity now, but future-proofing
 		p = randomItemFromSlice(presidents)
 	}
-	return promptAndResponse{fmt.Sprintf("Who were %s's First Ladies (join with commas)?", p.name), strings.Join(p.firstLadies, ",")}
+	return promptAndResponse{prompt: fmt.Sprintf("Who were %s's First Ladies (join with commas)?", p.name), response: strings.Join(p.firstLadies, ", "), allOf: p.firstLadies}
 }
 
 func vpServedUnderPres(vp string, pres president) bool {
@@ -219,5 +231,14 @@ func vpServedUnderPres(vp string, pres president) bool {
 
 func init() {
 	presidentsCmd.Flags().BoolVarP(&vicePresidentsOnly, "vicepresidents", "", false, "If set, only ask questions about vice presidents")
-	memoryquizCmd.AddCommand(presidentsCmd)
+}
+
+func quizRecitePresidents(presidents []president) promptAndResponse {
+	return quizReciteOrdered("presidents", presidents,
+		func(p president) int { return p.number },
+		func(p president) []string { return append([]string{p.name}, p.answerAliases("name")...) })
+}
+
+func quizRankPresidents(presidents []president) promptAndResponse {
+	return quizRanked("president", presidents)
 }
@@ -0,0 +1,110 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Deadlines for answering questions. A question's deadline comes from --deadline if it's set,
+// then from the deadlines section of the config file, which is by area:
+//
+//	deadlines:
+//	  speedmath: 20s
+//	  powersoftwo: 5s
+//
+// and then from the question's generator (each kind of speedmath problem has its own). An answer
+// after the deadline is late. With --late=partial, the default, a late right answer still counts
+// but for less; with --late=miss it counts as a miss.
+//
+// Every answer gets a speed-weighted score from 0 to 1, which is recorded in the quiz history so
+// you can see whether your answers are getting faster as well as more accurate.
+
+var questionDeadline time.Duration
+
+type latePolicy string
+
+const (
+	latePartialCredit latePolicy = "partial"
+	lateIsMiss        latePolicy = "miss"
+)
+
+var lateAnswers = latePartialCredit
+
+func (policy *latePolicy) String() string {
+	return string(*policy)
+}
+
+func (policy *latePolicy) Set(value string) error {
+	switch latePolicy(value) {
+	case latePartialCredit, lateIsMiss:
+		*policy = latePolicy(value)
+		return nil
+	}
+	return fmt.Errorf("must be %s or %s", latePartialCredit, lateIsMiss)
+}
+
+func (policy *latePolicy) Type() string {
+	return "policy"
+}
+
+// deadlineFor returns how long there is to answer a question from the generator in area,
+// or 0 for no deadline
+func deadlineFor(area string, generator questionGenerator) time.Duration {
+	if questionDeadline > 0 {
+		return questionDeadline
+	}
+	if configured := viper.GetDuration("deadlines." + area); configured > 0 {
+		return configured
+	}
+	return generator.deadline
+}
+
+func isLate(took time.Duration, deadline time.Duration) bool {
+	return deadline > 0 && took > deadline
+}
+
+// applyLatePolicy returns how an answer should count once its timing is taken into account.
+// Only a late right answer under --late=miss changes.
+func applyLatePolicy(match answerMatch, took time.Duration, deadline time.Duration, policy latePolicy) answerMatch {
+	if match.correct() && isLate(took, deadline) && policy == lateIsMiss {
+		return answerIncorrect
+	}
+	return match
+}
+
+// speedScore weights an answer by how fast it came. Wrong answers score 0, and right answers
+// without a deadline score 1. With a deadline, an answer on time scores from 1 (instant) down to
+// 0.5 (right at the deadline), and a late one keeps dropping from 0.5 for partial credit, or
+// scores 0 if late answers are misses.
+func speedScore(correct bool, took time.Duration, deadline time.Duration, policy latePolicy) float64 {
+	if !correct {
+		return 0
+	}
+	if deadline <= 0 {
+		return 1
+	}
+	if !isLate(took, deadline) {
+		return 1 - float64(took)/float64(2*deadline)
+	}
+	if policy == lateIsMiss {
+		return 0
+	}
+	return float64(deadline) / float64(2*took)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestSpeedScore(t *testing.T) {
+	cases := []struct {
+		correct  bool
+		took     time.Duration
+		deadline time.Duration
+		policy   latePolicy
+		expected float64
+	}{
+		{false, time.Second, 10 * time.Second, latePartialCredit, 0},
+		{true, time.Minute, 0, latePartialCredit, 1},
+		{true, 0, 10 * time.Second, latePartialCredit, 1},
+		{true, 5 * time.Second, 10 * time.Second, latePartialCredit, 0.75},
+		{true, 10 * time.Second, 10 * time.Second, latePartialCredit, 0.5},
+		{true, 20 * time.Second, 10 * time.Second, latePartialCredit, 0.25},
+		{true, 20 * time.Second, 10 * time.Second, lateIsMiss, 0},
+	}
+	for _, c := range cases {
+		if actual := speedScore(c.correct, c.took, c.deadline, c.policy); actual != c.expected {
+			t.Errorf("Expected %v for %+v but got %v", c.expected, c, actual)
+		}
+	}
+}
+
+func TestApplyLatePolicy(t *testing.T) {
+	if match := applyLatePolicy(answerCorrect, 11*time.Second, 10*time.Second, lateIsMiss); match != answerIncorrect {
+		t.Errorf("Expected a late answer to be a miss but got %v", match)
+	}
+	if match := applyLatePolicy(answerCorrect, 11*time.Second, 10*time.Second, latePartialCredit); match != answerCorrect {
+		t.Errorf("Expected a late answer to still count for partial credit but got %v", match)
+	}
+	if match := applyLatePolicy(answerTypo, 9*time.Second, 10*time.Second, lateIsMiss); match != answerTypo {
+		t.Errorf("Expected an answer on time to be unchanged but got %v", match)
+	}
+}
+
+func TestDeadlineFor(t *testing.T) {
+	defer func(deadline time.Duration) { questionDeadline = deadline }(questionDeadline)
+	defer viper.Set("deadlines.speedmath", nil)
+	generator := questionGenerator{name: "speedMathAddition", deadline: 10 * time.Second}
+
+	if deadline := deadlineFor("speedmath", generator); deadline != 10*time.Second {
+		t.Errorf("Expected the generator's deadline but got %v", deadline)
+	}
+	viper.Set("deadlines.speedmath", "30s")
+	if deadline := deadlineFor("speedmath", generator); deadline != 30*time.Second {
+		t.Errorf("Expected the configured deadline but got %v", deadline)
+	}
+	if deadline := deadlineFor("states", questionGenerator{}); deadline != 0 {
+		t.Errorf("Expected no deadline for another area but got %v", deadline)
+	}
+	questionDeadline = 5 * time.Second
+	if deadline := deadlineFor("speedmath", generator); deadline != 5*time.Second {
+		t.Errorf("Expected --deadline to override the others but got %v", deadline)
+	}
+}
+
+func TestLatePolicyFlag(t *testing.T) {
+	var policy latePolicy
+	if err := policy.Set("miss"); err != nil || policy != lateIsMiss {
+		t.Errorf("Expected miss to set the policy but got %v, %v", policy, err)
+	}
+	if err := policy.Set("never"); err == nil {
+		t.Errorf("Expected an error for an unknown policy")
+	}
+}
+
+func TestSpeedMathDeadlines(t *testing.T) {
+	for _, generator := range speedMathQuestions() {
+		if generator.deadline <= 0 {
+			t.Errorf("Expected %s to have a deadline", generator.name)
+		}
+	}
+}
+
+func TestRecordSpeedScore(t *testing.T) {
+	history := &quizHistory{make(map[string]*itemHistory)}
+	question := scheduledQuestion{"speedmath", "speedMathAddition", promptAndResponse{prompt: "2 + 2 = ", response: "4"}, 10 * time.Second}
+	history.record(question, answerCorrect, 5*time.Second, 0, time.Now())
+
+	attempt := history.Items[question.key()].Attempts[0]
+	if attempt.Deadline != 10*time.Second || attempt.Score != 0.75 {
+		t.Errorf("Expected the deadline and score to be recorded but got %+v", attempt)
+	}
+}
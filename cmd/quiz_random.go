@@ -0,0 +1,79 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+)
+
+// All the quizzes draw their random numbers from quizRand rather than the global math/rand,
+// so a run can be repeated with --seed. A seed of "daily" is the same for everyone on a given
+// day, for sharing a daily challenge. Without --seed the seed comes from the clock, and a session
+// prints it at the end so a session that went badly can be replayed.
+//
+// The spaced repetition picks from the candidate questions by what's due, so replaying a session
+// asks the same questions only if the history is the same, e.g. with a copy passed to --history.
+//
+// Tests can call seedQuizRand with a fixed seed to get the same questions every time.
+
+var quizSeedFlag string
+
+// quizSeed is the seed quizRand was last seeded with
+var quizSeed = time.Now().UnixNano()
+
+var quizRand = rand.New(rand.NewSource(quizSeed))
+
+// seedQuizRand starts quizRand over from seed
+func seedQuizRand(seed int64) {
+	quizSeed = seed
+	quizRand = rand.New(rand.NewSource(seed))
+}
+
+// parseQuizSeed turns the --seed flag into a seed. A number is used as is, "daily" is the
+// date (e.g. 20220601), and an empty flag means a seed from the clock.
+func parseQuizSeed(value string, now time.Time) (int64, error) {
+	switch value {
+	case "":
+		return now.UnixNano(), nil
+	case "daily":
+		year, month, day := now.Date()
+		return int64(year*10000 + int(month)*100 + day), nil
+	}
+
+	seed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("The seed must be a number or daily, not %s", value)
+	}
+	return seed, nil
+}
+
+// initQuizRand seeds quizRand from --seed
+func initQuizRand() {
+	seed, err := parseQuizSeed(quizSeedFlag, time.Now())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	seedQuizRand(seed)
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&quizSeedFlag, "seed", "", "Seed for the random choice of questions, to repeat a session, or daily for the day's challenge")
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuizSeed(t *testing.T) {
+	now := time.Date(2022, 6, 1, 9, 30, 0, 0, time.UTC)
+	cases := map[string]int64{
+		"":      now.UnixNano(),
+		"42":    42,
+		"-7":    -7,
+		"daily": 20220601,
+	}
+	for value, expected := range cases {
+		if seed, err := parseQuizSeed(value, now); err != nil || seed != expected {
+			t.Errorf("Expected %q to be seed %d but got %d, %v", value, expected, seed, err)
+		}
+	}
+	if _, err := parseQuizSeed("tuesday", now); err == nil {
+		t.Errorf("Expected an error for a seed that isn't a number")
+	}
+}
+
+// generatedPrompts seeds quizRand and asks each generator of every area for a few questions
+func generatedPrompts(seed int64) []string {
+	seedQuizRand(seed)
+	prompts := make([]string, 0)
+	for _, area := range sortedQuizAreas() {
+		if area.generators == nil {
+			continue
+		}
+		for _, generator := range area.generators() {
+			for i := 0; i < 3; i++ {
+				question := generator.generate()
+				prompts = append(prompts, area.name+" "+generator.name+": "+question.prompt+" -> "+question.response)
+			}
+		}
+	}
+	return prompts
+}
+
+func TestSeedRepeatsQuestions(t *testing.T) {
+	defer seedQuizRand(time.Now().UnixNano())
+
+	first := generatedPrompts(20220601)
+	second := generatedPrompts(20220601)
+	if len(first) != len(second) {
+		t.Fatalf("Expected the same number of questions but got %d and %d", len(first), len(second))
+	}
+	for index := range first {
+		if first[index] != second[index] {
+			t.Errorf("Expected the same seed to ask the same question but got %q and %q", first[index], second[index])
+		}
+	}
+
+	different := generatedPrompts(1)
+	same := 0
+	for index := range first {
+		if first[index] == different[index] {
+			same++
+		}
+	}
+	if same == len(first) {
+		t.Errorf("Expected a different seed to ask different questions")
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// The quiz registry. Each quiz file registers its area once, and the random memoryquiz picker,
+// the quiz subcommands (and so completion), and memoryquiz list all come from here.
+// Adding a quiz means writing one file that calls registerQuizArea.
+//
+// The random picker can be limited with --include and --exclude, which take area names or tags
+// (memoryquiz --include geography --exclude rivers), and areas can be made more or less likely
+// with weights in the config file. An area's weight defaults to 1, and 0 leaves it out:
+//
+//	weights:
+//	  countries: 3
+//	  nba-teams: 0.5
+
+// quizArea describes one area that memoryquiz can ask about
+type quizArea struct {
+	name    string
+	aliases []string
+	tags    []string
+	short   string
+	long    string
+	// generators returns the questions this area can ask. It's a function so that
+	// flags (like presidents --vicepresidents) can change the questions.
+	generators func() []questionGenerator
+	// run is for areas with their own interactive flow, such as numbers, that don't
+	// fit the single question and answer model. Set either run or generators.
+	run func(*cobra.Command, []string)
+	// dataset points to the slice the questions come from, so it can be changed from files.
+	// See datasets.go.
+	dataset interface{}
+	// validate checks rules specific to the area's dataset. See validate.go.
+	validate func() []datasetProblem
+	// entity names one record of the dataset in cross query flashcards, e.g. country
+	entity string
+	// ordinal names one item of a dataset whose order matters, like the greek alphabet, for
+	// flashcards about each item's position. See flashcards.go.
+	ordinal string
+	// parent is the command the area's subcommand hangs off of. Defaults to memoryquiz.
+	parent *cobra.Command
+	// unpicked keeps the area out of the random memoryquiz picker unless --include asks for it.
+	// Areas outside of memoryquiz, like speedmath, are always unpicked.
+	unpicked bool
+	command  *cobra.Command
+}
+
+var quizAreas []*quizArea
+
+var includeQuizAreas []string
+var excludeQuizAreas []string
+
+// registerQuizArea adds area to the registry and creates its subcommand, which is returned
+// so the quiz can add its own flags
+func registerQuizArea(area quizArea) *cobra.Command {
+	registered := &area
+	registered.command = &cobra.Command{
+		Use:     area.name,
+		Aliases: area.aliases,
+		Short:   area.short,
+		Long:    area.long,
+		Run: runQuizSession(func(cmd *cobra.Command, args []string) {
+			askQuizArea(registered, cmd, args)
+		}),
+	}
+
+	if registered.parent == nil {
+		registered.parent = memoryquizCmd
+	} else {
+		registered.unpicked = true
+	}
+	if registered.parent == rootCmd {
+		// quizzes outside of memoryquiz need their own session flags
+		addSessionFlags(registered.command)
+	}
+	registered.parent.AddCommand(registered.command)
+	if registered.dataset != nil {
+		registerDataset(registered.name, registered.dataset)
+	}
+	quizAreas = append(quizAreas, registered)
+	return registered.command
+}
+
+// askQuizArea asks one question from the area
+func askQuizArea(area *quizArea, cmd *cobra.Command, args []string) {
+	if area.run != nil {
+		area.run(cmd, args)
+	} else {
+		askScheduledQuestion(area.name, area.generators())
+	}
+}
+
+// findQuizArea looks up an area by name or alias
+func findQuizArea(name string) (*quizArea, bool) {
+	for _, area := range quizAreas {
+		if area.name == name || isStringInSlice(name, area.aliases) {
+			return area, true
+		}
+	}
+	return nil, false
+}
+
+// sortedQuizAreas returns the registered areas in alphabetical order
+func sortedQuizAreas() []*quizArea {
+	sorted := make([]*quizArea, len(quizAreas))
+	copy(sorted, quizAreas)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].name < sorted[j].name })
+	return sorted
+}
+
+// matches reports whether term is the area's name, one of its aliases, or one of its tags
+func (area *quizArea) matches(term string) bool {
+	for _, name := range append(append([]string{area.name}, area.aliases...), area.tags...) {
+		if strings.EqualFold(name, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// quizAreaWeight is how likely the random picker is to pick the area, relative to the others
+func quizAreaWeight(area *quizArea) float64 {
+	key := "weights." + area.name
+	if !viper.IsSet(key) {
+		return 1
+	}
+	if weight := viper.GetFloat64(key); weight > 0 {
+		return weight
+	}
+	return 0
+}
+
+// selectQuizAreas returns the areas matching one of include (or all of them but the unpicked
+// ones, if it's empty) and none of exclude, leaving out the ones weighted 0
+func selectQuizAreas(areas []*quizArea, include []string, exclude []string) ([]*quizArea, error) {
+	for _, term := range append(append([]string{}, include...), exclude...) {
+		known := false
+		for _, area := range areas {
+			known = known || area.matches(term)
+		}
+		if !known {
+			return nil, fmt.Errorf("There's no quiz area or tag called %s", term)
+		}
+	}
+
+	selected := make([]*quizArea, 0, len(areas))
+	for _, area := range areas {
+		included := len(include) == 0 && !area.unpicked
+		for _, term := range include {
+			included = included || area.matches(term)
+		}
+		for _, term := range exclude {
+			included = included && !area.matches(term)
+		}
+		if included && quizAreaWeight(area) > 0 {
+			selected = append(selected, area)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("No quiz areas are left to ask about")
+	}
+	return selected, nil
+}
+
+// pickWeightedQuizArea picks one of areas at random, in proportion to their weights
+func pickWeightedQuizArea(areas []*quizArea) *quizArea {
+	total := 0.0
+	for _, area := range areas {
+		total += quizAreaWeight(area)
+	}
+	pick := quizRand.Float64() * total
+	for _, area := range areas {
+		if pick < quizAreaWeight(area) {
+			return area
+		}
+		pick -= quizAreaWeight(area)
+	}
+	return areas[len(areas)-1]
+}
+
+func askRandomQuizArea(cmd *cobra.Command, args []string) {
+	areas, err := selectQuizAreas(quizAreas, includeQuizAreas, excludeQuizAreas)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	area := pickWeightedQuizArea(areas)
+	quizPrintf("[%s]\n", area.name)
+	askQuizArea(area, cmd, args)
+}
+
+var listQuizAreasCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available memory quizzes",
+	Run:   listQuizAreas,
+}
+
+func listQuizAreas(cmd *cobra.Command, args []string) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tALIASES\tTAGS\tDESCRIPTION")
+	for _, area := range sortedQuizAreas() {
+		name := area.name
+		if area.parent != memoryquizCmd && area.parent != rootCmd {
+			name = area.parent.Name() + " " + name
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", name, strings.Join(area.aliases, ","), strings.Join(area.tags, ","), area.short)
+	}
+	writer.Flush()
+}
+
+func init() {
+	memoryquizCmd.AddCommand(listQuizAreasCmd)
+	memoryquizCmd.Flags().StringSliceVar(&includeQuizAreas, "include", nil, "Only ask about these areas or tags (e.g. geography,presidents)")
+	memoryquizCmd.Flags().StringSliceVar(&excludeQuizAreas, "exclude", nil, "Don't ask about these areas or tags (e.g. sports)")
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestQuizAreaNamesAreUnique(t *testing.T) {
+	seen := make(map[string]string)
+	for _, area := range quizAreas {
+		for _, name := range append([]string{area.name}, area.aliases...) {
+			if other, exists := seen[name]; exists {
+				t.Errorf("%s is used by both %s and %s", name, other, area.name)
+			}
+			seen[name] = area.name
+		}
+	}
+}
+
+func TestQuizAreasCanAskQuestions(t *testing.T) {
+	for _, area := range quizAreas {
+		if (area.run == nil) == (area.generators == nil) {
+			t.Errorf("%s should have exactly one of run or generators", area.name)
+			continue
+		}
+		if area.generators == nil {
+			continue
+		}
+
+		for _, generator := range area.generators() {
+			for i := 0; i < 20; i++ {
+				question := generator.generate()
+				if question.prompt == "" || question.response == "" {
+					t.Errorf("%s %s generated an incomplete question: %+v", area.name, generator.name, question)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestFindQuizArea(t *testing.T) {
+	if area, found := findQuizArea("orkneyss"); !found || area.name != "orkneys" {
+		t.Errorf("Expected the orkneyss alias to find orkneys")
+	}
+	if _, found := findQuizArea("no such area"); found {
+		t.Errorf("Did not expect to find an area")
+	}
+}
+
+func TestSelectQuizAreas(t *testing.T) {
+	areas, err := selectQuizAreas(quizAreas, []string{"wine", "Judaica"}, []string{"hebrew-week"})
+	if err != nil {
+		t.Fatalf("Could not select areas: %v", err)
+	}
+	names := make([]string, 0)
+	for _, area := range areas {
+		names = append(names, area.name)
+	}
+	if len(names) != 4 || !isStringInSlice("bottles", names) || !isStringInSlice("hebrew", names) || isStringInSlice("hebrew-week", names) {
+		t.Errorf("Unexpected areas %v", names)
+	}
+
+	if _, err := selectQuizAreas(quizAreas, []string{"wine"}, []string{"wine"}); err == nil {
+		t.Errorf("Expected an error when everything is excluded")
+	}
+	if _, err := selectQuizAreas(quizAreas, []string{"astrology"}, nil); err == nil {
+		t.Errorf("Expected an error for an unknown tag")
+	}
+}
+
+func TestQuizAreaWeights(t *testing.T) {
+	defer viper.Set("weights", nil)
+	viper.Set("weights", map[string]interface{}{"bottles": 0, "grand-crus": 3})
+
+	areas, err := selectQuizAreas(quizAreas, []string{"wine", "greek"}, nil)
+	if err != nil || len(areas) != 2 {
+		t.Fatalf("Expected bottles to be left out but got %v, %v", areas, err)
+	}
+
+	seedQuizRand(1)
+	picks := make(map[string]int)
+	for i := 0; i < 4000; i++ {
+		picks[pickWeightedQuizArea(areas).name]++
+	}
+	if picks["grand-crus"] < 2700 || picks["grand-crus"] > 3300 {
+		t.Errorf("Expected grand-crus about 3 times as often as greek but got %v", picks)
+	}
+}
+
+func TestUnpickedQuizAreas(t *testing.T) {
+	areas, err := selectQuizAreas(quizAreas, nil, nil)
+	if err != nil {
+		t.Fatalf("Could not select areas: %v", err)
+	}
+	for _, area := range areas {
+		if area.name == "speedmath" || area.name == "dayofweek" {
+			t.Errorf("Expected %s to be left out of the random picker", area.name)
+		}
+	}
+
+	areas, err = selectQuizAreas(quizAreas, []string{"speedmath"}, nil)
+	if err != nil || len(areas) != 1 || areas[0].name != "speedmath" {
+		t.Errorf("Expected --include to ask for speedmath but got %v, %v", areas, err)
+	}
+}
@@ -0,0 +1,434 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/term"
+)
+
+// The full screen quiz mode (--tui). The screen takes over the terminal, reads keys one at a
+// time, and redraws everything on each key and each tick of the clock: a header with the score
+// bar and a countdown, the recent output of the quiz, the question, and what's been typed so far.
+// Tab reveals one more letter or digit of the answer, which lowers what the answer is worth, and
+// Ctrl-N skips the question.
+//
+// Quiz code doesn't need to know about the screen. Output goes through quizPrintf, which adds
+// to the screen's log when there is one, and responseFromPrompt reads from the screen.
+
+var fullScreen bool
+
+const (
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyBackspace = 8
+	keyTab       = '\t'
+	keyCtrlN     = 14
+	keyEscape    = 27
+	keyDelete    = 127
+)
+
+// how often the countdown is redrawn
+const screenTick = 250 * time.Millisecond
+
+// the screen the current quiz is drawn on, if any
+var activeQuizScreen *quizScreen
+
+type quizScreen struct {
+	out     io.Writer
+	keys    <-chan rune
+	restore func()
+	title   string
+	width   int
+	height  int
+	// deadline is when the countdown for the session runs out
+	deadline time.Time
+	// questionDeadline is how long there is to answer the next question, if there's a limit.
+	// Without a deadline of either kind the header shows the time taken on the question.
+	questionDeadline time.Duration
+	log              []string
+	// points and asked feed the score bar. A correct answer is worth a point, less whatever
+	// share of it was revealed by hints.
+	points float64
+	asked  int
+	// question is the question being asked, if any
+	question *questionState
+	// lastHints is how many hints were taken on the last question answered
+	lastHints int
+}
+
+// questionState is what's been typed and revealed for the question on screen
+type questionState struct {
+	question promptAndResponse
+	typed    []rune
+	hints    int
+	started  time.Time
+	// escape is set while skipping the rest of an escape sequence, such as an arrow key
+	escape bool
+}
+
+// openQuizScreen switches the terminal to raw mode and the alternate screen
+func openQuizScreen(title string) (*quizScreen, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, errors.New("the full screen quiz needs a terminal")
+	}
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	screen := newQuizScreen(os.Stdout, readKeys(os.Stdin), title)
+	if width, height, err := term.GetSize(fd); err == nil && width > 0 && height > 0 {
+		screen.width, screen.height = width, height
+	}
+	screen.restore = func() { term.Restore(fd, oldState) }
+	fmt.Fprint(screen.out, "\u001b[?1049h")
+	return screen, nil
+}
+
+func newQuizScreen(out io.Writer, keys <-chan rune, title string) *quizScreen {
+	return &quizScreen{out: out, keys: keys, title: title, width: 80, height: 24}
+}
+
+// readKeys sends each rune read from in to the returned channel, closing it when in runs out
+func readKeys(in io.Reader) <-chan rune {
+	keys := make(chan rune)
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			key, _, err := reader.ReadRune()
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- key
+		}
+	}()
+	return keys
+}
+
+// close gives the terminal back and prints the log, so what happened is still there
+// after the screen goes away
+func (screen *quizScreen) close() {
+	fmt.Fprint(screen.out, "\u001b[?1049l")
+	if screen.restore != nil {
+		screen.restore()
+	}
+	for _, line := range screen.log {
+		fmt.Fprintln(screen.out, line)
+	}
+}
+
+// withQuizScreen runs quiz on a full screen if --tui was set and one isn't already open
+func withQuizScreen(title string, quiz func()) {
+	if !fullScreen || activeQuizScreen != nil {
+		quiz()
+		return
+	}
+
+	screen, err := openQuizScreen(title)
+	if err != nil {
+		fmt.Println(err)
+		quiz()
+		return
+	}
+	activeQuizScreen = screen
+	defer func() {
+		activeQuizScreen = nil
+		screen.close()
+	}()
+	quiz()
+}
+
+// quizPrintf prints quiz output, either to the screen's log or to stdout
+func quizPrintf(format string, args ...interface{}) {
+	if activeQuizScreen == nil {
+		fmt.Printf(format, args...)
+		return
+	}
+	activeQuizScreen.print(fmt.Sprintf(format, args...))
+}
+
+func quizPrintln(args ...interface{}) {
+	quizPrintf("%s", fmt.Sprintln(args...))
+}
+
+func (screen *quizScreen) print(text string) {
+	screen.log = append(screen.log, strings.Split(strings.TrimSuffix(text, "\n"), "\n")...)
+	screen.draw(time.Now())
+}
+
+// readAnswer asks the question and returns what was typed. Skipping returns "", and quitting
+// also marks stdin as closed so the session stops.
+func (screen *quizScreen) readAnswer(question promptAndResponse) string {
+	state := &questionState{question: question, started: time.Now()}
+	screen.question = state
+	defer func() {
+		screen.question = nil
+		screen.lastHints = state.hints
+	}()
+
+	ticker := time.NewTicker(screenTick)
+	defer ticker.Stop()
+	for {
+		screen.draw(time.Now())
+		select {
+		case key, ok := <-screen.keys:
+			if !ok || key == keyCtrlC || key == keyCtrlD {
+				stdinClosed = true
+				return ""
+			}
+			if done, skipped := state.handleKey(key); skipped {
+				screen.print(fmt.Sprintf("%s (skipped)", question.prompt))
+				return ""
+			} else if done {
+				screen.print(fmt.Sprintf("%s %s", question.prompt, string(state.typed)))
+				return string(state.typed)
+			}
+		case now := <-ticker.C:
+			if !screen.deadline.IsZero() && !now.Before(screen.deadline) {
+				// the session is over, and an unanswered question shouldn't count against you
+				screen.print(fmt.Sprintf("%s (out of time)", question.prompt))
+				stdinClosed = true
+				return ""
+			}
+		}
+	}
+}
+
+// showUntilEnter shows text until enter is pressed and then hides it, for things like a number
+// to memorize. On the full screen it takes the place of the question. Otherwise it has the
+// alternate screen to itself until enter is pressed.
+func showUntilEnter(text string, instructions string) {
+	if activeQuizScreen != nil {
+		activeQuizScreen.showUntilEnter(text, instructions)
+		return
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		// there's no hiding it, but there's also nobody looking
+		fmt.Println(text)
+		fmt.Println(instructions)
+		readStdinLine()
+		return
+	}
+	fmt.Printf("\u001b[?1049h\u001b[H\u001b[2J%s\n\n%s\n", text, instructions)
+	readStdinLine()
+	fmt.Print("\u001b[?1049l")
+}
+
+func (screen *quizScreen) showUntilEnter(text string, instructions string) {
+	screen.question = &questionState{question: promptAndResponse{prompt: text + "\n\n" + instructions}, started: time.Now()}
+	defer func() { screen.question = nil }()
+
+	ticker := time.NewTicker(screenTick)
+	defer ticker.Stop()
+	for {
+		screen.draw(time.Now())
+		select {
+		case key, ok := <-screen.keys:
+			if !ok || key == keyCtrlC || key == keyCtrlD {
+				stdinClosed = true
+				return
+			}
+			if key == '\r' || key == '\n' {
+				return
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// score adds the answer to the score bar, taking off for any hints
+func (screen *quizScreen) score(match answerMatch, question promptAndResponse) {
+	screen.asked++
+	screen.points += questionScore(match, screen.lastHints, hintableCount(question.response))
+	screen.draw(time.Now())
+}
+
+// handleKey updates the state for one key, reporting whether the answer is done or skipped
+func (state *questionState) handleKey(key rune) (done bool, skipped bool) {
+	if state.escape {
+		// escape sequences end with a letter or ~, e.g. ESC [ A for the up arrow
+		if unicode.IsLetter(key) || key == '~' {
+			state.escape = false
+		}
+		return false, false
+	}
+
+	switch key {
+	case '\r', '\n':
+		return true, false
+	case keyCtrlN:
+		return false, true
+	case keyTab:
+		if state.hints < hintableCount(state.question.response) {
+			state.hints++
+		}
+	case keyBackspace, keyDelete:
+		if len(state.typed) > 0 {
+			state.typed = state.typed[:len(state.typed)-1]
+		}
+	case keyEscape:
+		state.escape = true
+	default:
+		if unicode.IsPrint(key) {
+			state.typed = append(state.typed, key)
+		}
+	}
+	return false, false
+}
+
+// hintableCount is the number of letters and digits in the answer, which is how many
+// hints there can be
+func hintableCount(answer string) int {
+	count := 0
+	for _, character := range answer {
+		if unicode.IsLetter(character) || unicode.IsDigit(character) {
+			count++
+		}
+	}
+	return count
+}
+
+// hintText shows the first hints letters and digits of answer, with a blank for each of the
+// rest. Spaces and punctuation are always shown, e.g. "G_____ __________" for one hint on
+// George Washington.
+func hintText(answer string, hints int) string {
+	var hint strings.Builder
+	revealed := 0
+	for _, character := range answer {
+		if !unicode.IsLetter(character) && !unicode.IsDigit(character) {
+			hint.WriteRune(character)
+		} else if revealed < hints {
+			hint.WriteRune(character)
+			revealed++
+		} else {
+			hint.WriteRune('_')
+		}
+	}
+	return hint.String()
+}
+
+// questionScore is what an answer is worth: a point for a right answer, less the share of
+// it that hints gave away
+func questionScore(match answerMatch, hints int, hintable int) float64 {
+	if !match.correct() {
+		return 0
+	}
+	return 1 - hintShare(hints, hintable)
+}
+
+// hintShare is the share of an answer with hintable letters and digits that hints gave away
+func hintShare(hints int, hintable int) float64 {
+	if hintable == 0 || hints == 0 {
+		return 0
+	}
+	if hints >= hintable {
+		return 1
+	}
+	return float64(hints) / float64(hintable)
+}
+
+// scoreBar draws the score so far, e.g. Score 3.5/5 [#######...]
+func scoreBar(points float64, asked int, width int) string {
+	filled := 0
+	if asked > 0 {
+		filled = int(points / float64(asked) * float64(width))
+	}
+	return fmt.Sprintf("Score %s/%d [%s%s]", strings.TrimSuffix(fmt.Sprintf("%.1f", points), ".0"), asked, strings.Repeat("#", filled), strings.Repeat(".", width-filled))
+}
+
+// formatClock formats a duration as minutes and seconds, e.g. 2:05
+func formatClock(duration time.Duration) string {
+	if duration < 0 {
+		duration = 0
+	}
+	seconds := int(duration.Round(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// frame returns the lines of the screen as of now
+func (screen *quizScreen) frame(now time.Time) []string {
+	clocks := []string{screen.title, scoreBar(screen.points, screen.asked, 20)}
+	if screen.question != nil && screen.questionDeadline > 0 {
+		if remaining := screen.questionDeadline - now.Sub(screen.question.started); remaining >= 0 {
+			clocks = append(clocks, "Answer in "+formatClock(remaining))
+		} else {
+			clocks = append(clocks, "Late by "+formatClock(-remaining))
+		}
+	}
+	if !screen.deadline.IsZero() {
+		clocks = append(clocks, "Time left "+formatClock(screen.deadline.Sub(now)))
+	} else if screen.question != nil && screen.questionDeadline == 0 {
+		clocks = append(clocks, "Time "+formatClock(now.Sub(screen.question.started)))
+	}
+	header := []string{
+		strings.Join(clocks, "   "),
+		strings.Repeat("-", screen.width),
+	}
+
+	question := make([]string, 0)
+	if screen.question != nil {
+		question = append(question, "")
+		question = append(question, strings.Split(screen.question.question.prompt, "\n")...)
+		if screen.question.question.hint != "" {
+			question = append(question, fmt.Sprintf("(Hint: %s)", screen.question.question.hint))
+		}
+		for index, choice := range screen.question.question.choices {
+			question = append(question, fmt.Sprintf("  %s) %s", choiceLetter(index), choice))
+		}
+		if screen.question.hints > 0 {
+			question = append(question, "Hint: "+hintText(screen.question.question.response, screen.question.hints))
+		}
+		if screen.question.question.response != "" {
+			question = append(question, "", "Tab: hint   Ctrl-N: skip   Ctrl-C: quit")
+		}
+		question = append(question, "> "+string(screen.question.typed))
+	}
+
+	// the log gets whatever room is left, showing the most recent lines
+	log := screen.log
+	if room := screen.height - len(header) - len(question); len(log) > room {
+		if room < 0 {
+			room = 0
+		}
+		log = log[len(log)-room:]
+	}
+
+	lines := append(header, log...)
+	return append(lines, question...)
+}
+
+// draw clears the screen and draws the frame, leaving the cursor at the end of the answer
+func (screen *quizScreen) draw(now time.Time) {
+	lines := screen.frame(now)
+	for index, line := range lines {
+		if runes := []rune(line); len(runes) > screen.width {
+			lines[index] = string(runes[:screen.width])
+		}
+	}
+	fmt.Fprint(screen.out, "\u001b[H\u001b[2J"+strings.Join(lines, "\r\n"))
+}
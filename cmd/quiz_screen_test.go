@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHintText(t *testing.T) {
+	cases := map[int]string{
+		0:  "______ __________",
+		1:  "G_____ __________",
+		7:  "George W_________",
+		20: "George Washington",
+	}
+	for hints, expected := range cases {
+		if actual := hintText("George Washington", hints); actual != expected {
+			t.Errorf("Expected %q for %d hints but got %q", expected, hints, actual)
+		}
+	}
+	if actual := hintText("3,141", 2); actual != "3,1__" {
+		t.Errorf("Expected digits to be revealed one at a time but got %q", actual)
+	}
+}
+
+func TestQuestionScore(t *testing.T) {
+	cases := []struct {
+		match    answerMatch
+		hints    int
+		expected float64
+	}{
+		{answerCorrect, 0, 1},
+		{answerTypo, 0, 1},
+		{answerCorrect, 1, 0.75},
+		{answerCorrect, 4, 0},
+		{answerClose, 0, 0},
+		{answerIncorrect, 1, 0},
+	}
+	for _, c := range cases {
+		if actual := questionScore(c.match, c.hints, 4); actual != c.expected {
+			t.Errorf("Expected %v with %d hints to score %v but got %v", c.match, c.hints, c.expected, actual)
+		}
+	}
+}
+
+func TestScoreBar(t *testing.T) {
+	if bar := scoreBar(1.5, 2, 8); bar != "Score 1.5/2 [######..]" {
+		t.Errorf("Unexpected score bar %q", bar)
+	}
+	if bar := scoreBar(0, 0, 4); bar != "Score 0/0 [....]" {
+		t.Errorf("Unexpected empty score bar %q", bar)
+	}
+}
+
+func TestQuestionStateKeys(t *testing.T) {
+	state := &questionState{question: promptAndResponse{prompt: "Capital of France?", response: "Paris"}}
+	for _, key := range "Pax\u007fr\u001b[Di\t\t" {
+		if done, skipped := state.handleKey(key); done || skipped {
+			t.Fatalf("Didn't expect %q to finish the question", key)
+		}
+	}
+	if string(state.typed) != "Pari" || state.hints != 2 {
+		t.Errorf("Expected Pari with two hints but got %q with %d", string(state.typed), state.hints)
+	}
+	if done, _ := state.handleKey('\r'); !done {
+		t.Errorf("Expected enter to finish the question")
+	}
+	if _, skipped := state.handleKey(keyCtrlN); !skipped {
+		t.Errorf("Expected Ctrl-N to skip the question")
+	}
+}
+
+func TestQuizScreenReadAnswer(t *testing.T) {
+	keys := make(chan rune, 20)
+	for _, key := range "Lyo\tn\r" {
+		keys <- key
+	}
+	var out bytes.Buffer
+	screen := newQuizScreen(&out, keys, "memoryquiz")
+	question := promptAndResponse{prompt: "What is the capital of the Rhône?", response: "Lyon"}
+
+	if answer := screen.readAnswer(question); answer != "Lyon" {
+		t.Errorf("Expected Lyon but got %q", answer)
+	}
+	screen.score(matchAnswer("Lyon", question), question)
+	if screen.points != 0.75 || screen.asked != 1 {
+		t.Errorf("Expected a hint to cost a quarter point but got %v of %d", screen.points, screen.asked)
+	}
+	if !strings.Contains(out.String(), "Hint: L___") {
+		t.Errorf("Expected the hint to be drawn")
+	}
+	if len(screen.log) != 1 || screen.log[0] != "What is the capital of the Rhône? Lyon" {
+		t.Errorf("Expected the answered question in the log but got %v", screen.log)
+	}
+}
+
+func TestQuizScreenFrame(t *testing.T) {
+	screen := newQuizScreen(&bytes.Buffer{}, nil, "memoryquiz")
+	screen.height = 12
+	started := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		screen.log = append(screen.log, strings.Repeat("x", i+1))
+	}
+	screen.question = &questionState{question: promptAndResponse{prompt: "Pick one", response: "b", choices: []string{"a", "b"}}, started: started}
+
+	lines := screen.frame(started.Add(65 * time.Second))
+	if len(lines) != 12 || lines[2] != "xxxxxxxx" {
+		t.Fatalf("Expected the log to be cut to fit but got %d lines: %v", len(lines), lines)
+	}
+	if !strings.HasSuffix(lines[0], "Time 1:05") {
+		t.Errorf("Expected the time on the question in the header but got %q", lines[0])
+	}
+	screen.deadline = started.Add(2 * time.Minute)
+	if lines = screen.frame(started.Add(65 * time.Second)); !strings.HasSuffix(lines[0], "Time left 0:55") {
+		t.Errorf("Expected the countdown in the header but got %q", lines[0])
+	}
+	if lines[len(lines)-1] != "> " || lines[len(lines)-4] != "  B) b" {
+		t.Errorf("Expected the choices and answer at the bottom but got %v", lines)
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Quiz sessions ask a series of questions in one process and summarize how it went,
+// rather than asking one question and exiting.
+
+var sessionQuestions int
+var sessionTimeLimit time.Duration
+
+// quizResult is the outcome of one question asked during a session
+type quizResult struct {
+	area         string
+	questionType string
+	question     promptAndResponse
+	correct      bool
+	// close is set for wrong answers that were near the right one
+	close bool
+	took  time.Duration
+	// score is the speed-weighted score. See speedScore.
+	score float64
+}
+
+type quizSession struct {
+	results []quizResult
+}
+
+// the session currently collecting results, if any
+var activeQuizSession *quizSession
+
+func (session *quizSession) add(result quizResult) {
+	session.results = append(session.results, result)
+}
+
+// accuracy returns the fraction of questions answered correctly
+func (session *quizSession) accuracy() float64 {
+	if len(session.results) == 0 {
+		return 0
+	}
+	correct := 0
+	for _, result := range session.results {
+		if result.correct {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(session.results))
+}
+
+// score returns the total speed-weighted score
+func (session *quizSession) score() float64 {
+	score := 0.0
+	for _, result := range session.results {
+		score += result.score
+	}
+	return score
+}
+
+// medianDuration returns the median time taken to answer
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func (session *quizSession) durations() []time.Duration {
+	durations := make([]time.Duration, 0, len(session.results))
+	for _, result := range session.results {
+		durations = append(durations, result.took)
+	}
+	return durations
+}
+
+// slowest returns the result that took the longest to answer
+func (session *quizSession) slowest() quizResult {
+	var slowest quizResult
+	for _, result := range session.results {
+		if result.took > slowest.took {
+			slowest = result
+		}
+	}
+	return slowest
+}
+
+// missed returns the results that were wrong and not close
+func (session *quizSession) missed() []quizResult {
+	missed := make([]quizResult, 0)
+	for _, result := range session.results {
+		if !result.correct && !result.close {
+			missed = append(missed, result)
+		}
+	}
+	return missed
+}
+
+// closeCalls returns the results that were wrong but near the right answer
+func (session *quizSession) closeCalls() []quizResult {
+	closeCalls := make([]quizResult, 0)
+	for _, result := range session.results {
+		if result.close {
+			closeCalls = append(closeCalls, result)
+		}
+	}
+	return closeCalls
+}
+
+func (session *quizSession) printSummary() {
+	fmt.Println()
+	if len(session.results) == 0 {
+		fmt.Println("No questions answered")
+		return
+	}
+
+	fmt.Printf("Questions: %d\n", len(session.results))
+	fmt.Printf("Seed     : %d (use --seed %d to repeat this session)\n", quizSeed, quizSeed)
+	fmt.Printf("Accuracy : %.1f%%\n", session.accuracy()*100)
+	fmt.Printf("Score    : %.1f of %d\n", session.score(), len(session.results))
+	fmt.Printf("Median   : %v\n", medianDuration(session.durations()).Round(time.Millisecond))
+	slowest := session.slowest()
+	fmt.Printf("Slowest  : %v (%s)\n", slowest.took.Round(time.Millisecond), slowest.question.prompt)
+
+	printResults("Close:", session.closeCalls())
+	printResults("Missed:", session.missed())
+}
+
+func printResults(heading string, results []quizResult) {
+	if len(results) > 0 {
+		fmt.Println(heading)
+		for _, result := range results {
+			fmt.Printf("  %s -> %s\n", result.question.prompt, result.question.response)
+		}
+	}
+}
+
+// sessionOver reports whether the session has asked enough questions or run out of time.
+// countSet is whether --questions was given; with a time limit, the question count only
+// applies if it was.
+func sessionOver(asked int, started time.Time, countSet bool) bool {
+	if stdinClosed {
+		return true
+	}
+	if sessionTimeLimit > 0 {
+		return time.Since(started) >= sessionTimeLimit || (countSet && asked >= sessionQuestions)
+	}
+	return asked >= sessionQuestions
+}
+
+// runQuizSession calls run once, or, if --questions or --time-limit were set, repeatedly
+// until the session is over, and then prints a summary. A question in progress when the time
+// limit passes is allowed to finish, except on the full screen, where its countdown runs out.
+// The quiz history is saved once the questions are done.
+func runQuizSession(run func(*cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		exitOnDatasetLoadProblems()
+		defer saveQuizHistory()
+		if sessionQuestions <= 1 && sessionTimeLimit == 0 {
+			withQuizScreen(cmd.CommandPath(), func() { run(cmd, args) })
+			return
+		}
+
+		activeQuizSession = &quizSession{}
+		started := time.Now()
+		withQuizScreen(cmd.CommandPath(), func() {
+			if activeQuizScreen != nil && sessionTimeLimit > 0 {
+				activeQuizScreen.deadline = started.Add(sessionTimeLimit)
+			}
+			countSet := cmd.Flags().Changed("questions")
+			for asked := 0; !sessionOver(asked, started, countSet); asked++ {
+				run(cmd, args)
+				quizPrintln()
+			}
+		})
+		activeQuizSession.printSummary()
+		activeQuizSession = nil
+	}
+}
+
+// addSessionFlags adds the session flags to a quiz command and its subcommands
+func addSessionFlags(command *cobra.Command) {
+	command.PersistentFlags().IntVarP(&sessionQuestions, "questions", "q", 1, "The number of questions to ask")
+	command.PersistentFlags().DurationVar(&sessionTimeLimit, "time-limit", 0, "Keep asking questions until this much time has passed (e.g. 5m)")
+	command.PersistentFlags().BoolVar(&fullScreen, "tui", false, "Run the quiz full screen, with a countdown, hints, and a score bar")
+	command.PersistentFlags().DurationVar(&questionDeadline, "deadline", 0, "How long to allow for each answer (e.g. 10s), overriding the area's deadlines")
+	command.PersistentFlags().Var(&lateAnswers, "late", "How late answers count: partial (less credit) or miss")
+}
+
+func init() {
+	addSessionFlags(memoryquizCmd)
+}
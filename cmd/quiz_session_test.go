@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"bufio"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMedianDuration(t *testing.T) {
+	tests := []struct {
+		durations []time.Duration
+		expected  time.Duration
+	}{
+		{[]time.Duration{}, 0},
+		{[]time.Duration{3 * time.Second}, 3 * time.Second},
+		{[]time.Duration{5 * time.Second, time.Second, 3 * time.Second}, 3 * time.Second},
+		{[]time.Duration{4 * time.Second, time.Second, 2 * time.Second, 10 * time.Second}, 3 * time.Second},
+	}
+
+	for _, test := range tests {
+		actual := medianDuration(test.durations)
+		if actual != test.expected {
+			t.Errorf("Expected median of %v to be %v but got %v", test.durations, test.expected, actual)
+		}
+	}
+}
+
+func TestSessionStatistics(t *testing.T) {
+	session := &quizSession{}
+	session.add(quizResult{"states", "crossQueryStateInfo", promptAndResponse{prompt: "q1", response: "a1"}, true, false, time.Second, 1})
+	session.add(quizResult{"states", "crossQueryStateInfo", promptAndResponse{prompt: "q2", response: "a2"}, false, false, 7 * time.Second, 0})
+	session.add(quizResult{"states", "quizStatesWithBird", promptAndResponse{prompt: "q3", response: "a3"}, true, false, 2 * time.Second, 1})
+	session.add(quizResult{"states", "quizStatesWithBird", promptAndResponse{prompt: "q4", response: "a4"}, true, false, 3 * time.Second, 1})
+
+	if session.accuracy() != 0.75 {
+		t.Errorf("Expected accuracy of 0.75 but got %v", session.accuracy())
+	}
+
+	if slowest := session.slowest(); slowest.question.prompt != "q2" {
+		t.Errorf("Expected q2 to be the slowest but got %s", slowest.question.prompt)
+	}
+
+	missed := session.missed()
+	if len(missed) != 1 || missed[0].question.prompt != "q2" {
+		t.Errorf("Expected only q2 to be missed but got %v", missed)
+	}
+}
+
+func TestSessionOver(t *testing.T) {
+	defer func(questions int, limit time.Duration) { sessionQuestions, sessionTimeLimit = questions, limit }(sessionQuestions, sessionTimeLimit)
+	sessionQuestions, sessionTimeLimit = 1, 5*time.Minute
+	if !sessionOver(1, time.Now(), true) {
+		t.Errorf("Expected -q 1 to end a timed session after one question")
+	}
+	if sessionOver(1, time.Now(), false) {
+		t.Errorf("Expected a timed session to keep going when --questions wasn't given")
+	}
+}
+
+func TestLargeNumbersAreRecorded(t *testing.T) {
+	defer func(scanner *bufio.Scanner, length int) {
+		stdinScanner, numberLength, stdinClosed = scanner, length, false
+		loadedQuizHistory, loadedMnemonicImages, quizHistoryFile, mnemonicImagesFile = nil, nil, "", ""
+		activeQuizSession = nil
+	}(stdinScanner, numberLength)
+	quizHistoryFile = filepath.Join(t.TempDir(), "quiz_history.json")
+	mnemonicImagesFile = filepath.Join(t.TempDir(), "images.json")
+	loadedQuizHistory, loadedMnemonicImages = nil, nil
+	numberLength = 5
+
+	seedQuizRand(1)
+	number := generateNumberStringOfLength(numberLength)
+	seedQuizRand(1)
+	stdinScanner = bufio.NewScanner(strings.NewReader("\n" + number + "\n"))
+	activeQuizSession = &quizSession{}
+	quizLargeNumbers(nil, nil)
+	saveQuizHistory()
+
+	if len(activeQuizSession.results) != 1 || !activeQuizSession.results[0].correct {
+		t.Fatalf("Expected one correct result but got %+v", activeQuizSession.results)
+	}
+	history, err := loadQuizHistory(quizHistoryFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item, ok := history.Items[historyKey("numbers", "quizLargeNumbers", "Memorize a 5 digit number")]; !ok || len(item.Attempts) != 1 {
+		t.Errorf("Expected the number to be recorded in the history but got %v", history.Items)
+	}
+}
+
+func TestNumberRecallHasNoHints(t *testing.T) {
+	state := questionState{question: numberRecallPrompt}
+	state.handleKey(keyTab)
+	if state.hints != 0 {
+		t.Errorf("Expected no hints for the number being recalled but got %d", state.hints)
+	}
+}
+
+func TestNumberScore(t *testing.T) {
+	if score := numberScore("31415", "31425"); score != 3 {
+		t.Errorf("Expected 3 digits right before the mistake but got %d", score)
+	}
+}
@@ -0,0 +1,355 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// memoryquiz stats reports on the quiz history: accuracy and median time by area and by question
+// type, the items missed most, streaks, and how accuracy and speed are trending. It can also
+// export every attempt as CSV or JSON for analysis elsewhere.
+
+var statsFormat string
+var statsPeriod string
+var statsMostMissed int
+
+// answeredQuestion is one attempt at a question, with what it was asking
+type answeredQuestion struct {
+	When         time.Time     `json:"when"`
+	Area         string        `json:"area"`
+	QuestionType string        `json:"questionType"`
+	Prompt       string        `json:"prompt"`
+	Response     string        `json:"response"`
+	Correct      bool          `json:"correct"`
+	Close        bool          `json:"close"`
+	Duration     time.Duration `json:"duration"`
+	Deadline     time.Duration `json:"deadline"`
+	Score        float64       `json:"score"`
+}
+
+// answeredQuestions flattens the history into a list of attempts, oldest first
+func (history *quizHistory) answeredQuestions() []answeredQuestion {
+	answered := make([]answeredQuestion, 0)
+	for _, item := range history.Items {
+		for _, attempt := range item.Attempts {
+			answered = append(answered, answeredQuestion{
+				When:         attempt.When,
+				Area:         item.Area,
+				QuestionType: item.QuestionType,
+				Prompt:       item.Prompt,
+				Response:     item.Response,
+				Correct:      attempt.Correct,
+				Close:        attempt.Close,
+				Duration:     attempt.Duration,
+				Deadline:     attempt.Deadline,
+				Score:        attempt.Score,
+			})
+		}
+	}
+	sort.Slice(answered, func(i, j int) bool {
+		if !answered[i].When.Equal(answered[j].When) {
+			return answered[i].When.Before(answered[j].When)
+		}
+		return historyKey(answered[i].Area, answered[i].QuestionType, answered[i].Prompt) < historyKey(answered[j].Area, answered[j].QuestionType, answered[j].Prompt)
+	})
+	return answered
+}
+
+// answerStats summarizes a group of attempts, such as all the attempts in one area
+type answerStats struct {
+	name      string
+	answered  int
+	correct   int
+	durations []time.Duration
+}
+
+func (stats *answerStats) add(question answeredQuestion) {
+	stats.answered++
+	if question.Correct {
+		stats.correct++
+	}
+	stats.durations = append(stats.durations, question.Duration)
+}
+
+func (stats *answerStats) accuracy() float64 {
+	if stats.answered == 0 {
+		return 0
+	}
+	return float64(stats.correct) / float64(stats.answered)
+}
+
+func (stats *answerStats) misses() int {
+	return stats.answered - stats.correct
+}
+
+// groupAnswers collects stats for each group of attempts, as named by groupName, in order of name
+func groupAnswers(answered []answeredQuestion, groupName func(answeredQuestion) string) []*answerStats {
+	groups := make(map[string]*answerStats)
+	for _, question := range answered {
+		name := groupName(question)
+		if _, exists := groups[name]; !exists {
+			groups[name] = &answerStats{name: name}
+		}
+		groups[name].add(question)
+	}
+
+	sorted := make([]*answerStats, 0, len(groups))
+	for _, stats := range groups {
+		sorted = append(sorted, stats)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].name < sorted[j].name })
+	return sorted
+}
+
+// mostMissed returns up to count items with the most misses, ties going to the item with the
+// worse accuracy
+func mostMissed(answered []answeredQuestion, count int) []*answerStats {
+	items := groupAnswers(answered, func(question answeredQuestion) string {
+		return fmt.Sprintf("%s: %s -> %s", question.Area, question.Prompt, question.Response)
+	})
+	missed := make([]*answerStats, 0, len(items))
+	for _, item := range items {
+		if item.misses() > 0 {
+			missed = append(missed, item)
+		}
+	}
+	sort.SliceStable(missed, func(i, j int) bool {
+		if missed[i].misses() != missed[j].misses() {
+			return missed[i].misses() > missed[j].misses()
+		}
+		return missed[i].accuracy() < missed[j].accuracy()
+	})
+	if len(missed) > count {
+		missed = missed[:count]
+	}
+	return missed
+}
+
+// answerStreaks returns the current and longest runs of right answers
+func answerStreaks(answered []answeredQuestion) (current int, longest int) {
+	for _, question := range answered {
+		if question.Correct {
+			current++
+		} else {
+			current = 0
+		}
+		if current > longest {
+			longest = current
+		}
+	}
+	return current, longest
+}
+
+// dayStreaks returns the current and longest runs of days with at least one question answered.
+// The current run isn't broken until a whole day goes by without a quiz.
+func dayStreaks(answered []answeredQuestion, now time.Time) (current int, longest int) {
+	var lastDay time.Time
+	for _, question := range answered {
+		day := startOfDay(question.When)
+		if day.Equal(lastDay) {
+			continue
+		}
+		if day.Equal(lastDay.AddDate(0, 0, 1)) {
+			current++
+		} else {
+			current = 1
+		}
+		if current > longest {
+			longest = current
+		}
+		lastDay = day
+	}
+
+	if today := startOfDay(now); !lastDay.Equal(today) && !lastDay.Equal(today.AddDate(0, 0, -1)) {
+		current = 0
+	}
+	return current, longest
+}
+
+func startOfDay(when time.Time) time.Time {
+	year, month, day := when.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, when.Location())
+}
+
+// periodStart returns the start of the day, week (starting Monday), or month that when is in
+func periodStart(when time.Time, period string) time.Time {
+	day := startOfDay(when)
+	switch period {
+	case "day":
+		return day
+	case "month":
+		return day.AddDate(0, 0, 1-day.Day())
+	default:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+}
+
+// trend groups the attempts by period, oldest first
+func trend(answered []answeredQuestion, period string) []*answerStats {
+	return groupAnswers(answered, func(question answeredQuestion) string {
+		return periodStart(question.When, period).Format("2006-01-02")
+	})
+}
+
+func formatPercent(fraction float64) string {
+	return fmt.Sprintf("%.1f%%", fraction*100)
+}
+
+func printStatsTable(out io.Writer, heading string, groups []*answerStats) {
+	fmt.Fprintln(out, heading)
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tANSWERED\tACCURACY\tMEDIAN")
+	for _, stats := range groups {
+		fmt.Fprintf(writer, "%s\t%d\t%s\t%v\n", stats.name, stats.answered, formatPercent(stats.accuracy()), medianDuration(stats.durations).Round(time.Millisecond))
+	}
+	writer.Flush()
+	fmt.Fprintln(out)
+}
+
+// printTrendChart draws one bar per period, using value to get the bar's length
+func printTrendChart(out io.Writer, heading string, periods []*answerStats, value func(*answerStats) int) {
+	fmt.Fprintln(out, heading)
+	data := dataSet(make(map[string]*dataPoint))
+	labels := make([]string, 0, len(periods))
+	for _, stats := range periods {
+		data.addDataPoint(stats.name, value(stats))
+		labels = append(labels, stats.name)
+	}
+	for _, row := range barChartRows(labels, data, 80) {
+		fmt.Fprintln(out, row)
+	}
+	fmt.Fprintln(out)
+}
+
+// printStatsReport writes the full report for the attempts
+func printStatsReport(out io.Writer, answered []answeredQuestion, now time.Time) {
+	if len(answered) == 0 {
+		fmt.Fprintln(out, "No quiz history yet")
+		return
+	}
+
+	overall := groupAnswers(answered, func(answeredQuestion) string { return "all" })[0]
+	fmt.Fprintf(out, "Answered %d questions, %s right, median time %v\n\n", overall.answered, formatPercent(overall.accuracy()), medianDuration(overall.durations).Round(time.Millisecond))
+
+	printStatsTable(out, "By area", groupAnswers(answered, func(question answeredQuestion) string { return question.Area }))
+	printStatsTable(out, "By question type", groupAnswers(answered, func(question answeredQuestion) string {
+		return question.Area + " " + question.QuestionType
+	}))
+
+	fmt.Fprintln(out, "Most missed")
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "MISSED\tANSWERED\tQUESTION")
+	for _, item := range mostMissed(answered, statsMostMissed) {
+		fmt.Fprintf(writer, "%d\t%d\t%s\n", item.misses(), item.answered, item.name)
+	}
+	writer.Flush()
+	fmt.Fprintln(out)
+
+	currentAnswers, longestAnswers := answerStreaks(answered)
+	currentDays, longestDays := dayStreaks(answered, now)
+	fmt.Fprintf(out, "Right answers in a row: %d (longest %d)\n", currentAnswers, longestAnswers)
+	fmt.Fprintf(out, "Days in a row         : %d (longest %d)\n\n", currentDays, longestDays)
+
+	periods := trend(answered, statsPeriod)
+	printTrendChart(out, fmt.Sprintf("Accuracy (%%) by %s", statsPeriod), periods, func(stats *answerStats) int {
+		return int(stats.accuracy()*100 + 0.5)
+	})
+	printTrendChart(out, fmt.Sprintf("Median time (ms) by %s", statsPeriod), periods, func(stats *answerStats) int {
+		return int(medianDuration(stats.durations).Milliseconds())
+	})
+}
+
+// exportAnswersCsv writes one row per attempt, with durations in milliseconds
+func exportAnswersCsv(out io.Writer, answered []answeredQuestion) error {
+	writer := csv.NewWriter(out)
+	writer.Write([]string{"when", "area", "questionType", "prompt", "response", "correct", "close", "durationMs", "deadlineMs", "score"})
+	for _, question := range answered {
+		writer.Write([]string{
+			question.When.Format(time.RFC3339),
+			question.Area,
+			question.QuestionType,
+			question.Prompt,
+			question.Response,
+			strconv.FormatBool(question.Correct),
+			strconv.FormatBool(question.Close),
+			strconv.FormatInt(question.Duration.Milliseconds(), 10),
+			strconv.FormatInt(question.Deadline.Milliseconds(), 10),
+			strconv.FormatFloat(question.Score, 'f', -1, 64),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func exportAnswersJson(out io.Writer, answered []answeredQuestion) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(answered)
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Report on your quiz history",
+	Long: `Show accuracy and median answer time by area and question type, the questions you
+miss most, streaks, and trends over time. Use --format csv or --format json to export every
+attempt instead, for analysis elsewhere.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fileName, err := quizHistoryPath()
+		var history *quizHistory
+		if err == nil {
+			history, err = loadQuizHistory(fileName)
+		}
+		if err == nil {
+			err = writeStats(os.Stdout, history.answeredQuestions(), statsFormat, time.Now())
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// writeStats writes the report, or the attempts in csv or json
+func writeStats(out io.Writer, answered []answeredQuestion, format string, now time.Time) error {
+	switch format {
+	case "table":
+		printStatsReport(out, answered, now)
+		return nil
+	case "csv":
+		return exportAnswersCsv(out, answered)
+	case "json":
+		return exportAnswersJson(out, answered)
+	}
+	return fmt.Errorf("Unknown format %s. Use table, csv, or json", format)
+}
+
+func init() {
+	memoryquizCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringVar(&statsFormat, "format", "table", "table for the report, or csv or json to export every attempt")
+	statsCmd.Flags().StringVar(&statsPeriod, "period", "week", "Show trends by day, week, or month")
+	statsCmd.Flags().IntVar(&statsMostMissed, "missed", 10, "How many of the most missed questions to show")
+}
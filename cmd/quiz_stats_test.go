@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// statsTestHistory has two items, one in each of two areas, answered over three days
+func statsTestHistory(start time.Time) *quizHistory {
+	attempt := func(day int, correct bool, took time.Duration) quizAttempt {
+		return quizAttempt{When: start.AddDate(0, 0, day), Correct: correct, Duration: took}
+	}
+	return &quizHistory{map[string]*itemHistory{
+		"a": {Area: "states", QuestionType: "quizStateCapital", Prompt: "Capital of Ohio?", Response: "Columbus", Attempts: []quizAttempt{
+			attempt(0, false, 4*time.Second),
+			attempt(1, false, 6*time.Second),
+			attempt(2, true, 2*time.Second),
+		}},
+		"b": {Area: "greek", QuestionType: "quizLetterBefore", Prompt: "Before beta?", Response: "alpha", Attempts: []quizAttempt{
+			attempt(0, true, time.Second),
+			attempt(1, false, 3*time.Second),
+			attempt(2, true, time.Second),
+		}},
+	}}
+}
+
+func TestAnswerStats(t *testing.T) {
+	start := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	answered := statsTestHistory(start).answeredQuestions()
+	if len(answered) != 6 || !answered[0].When.Equal(start) || !answered[5].When.Equal(start.AddDate(0, 0, 2)) {
+		t.Fatalf("Expected six attempts, oldest first, but got %v", answered)
+	}
+
+	areas := groupAnswers(answered, func(question answeredQuestion) string { return question.Area })
+	if len(areas) != 2 || areas[0].name != "greek" || areas[0].correct != 2 || areas[1].accuracy() != 1.0/3 {
+		t.Errorf("Unexpected stats by area: %+v %+v", areas[0], areas[1])
+	}
+	if median := medianDuration(areas[1].durations); median != 4*time.Second {
+		t.Errorf("Expected a median of 4s for states but got %v", median)
+	}
+
+	missed := mostMissed(answered, 1)
+	if len(missed) != 1 || missed[0].name != "states: Capital of Ohio? -> Columbus" || missed[0].misses() != 2 {
+		t.Errorf("Expected Columbus to be the most missed but got %v", missed)
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	start := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	answered := statsTestHistory(start).answeredQuestions()
+	if current, longest := answerStreaks(answered); current != 2 || longest != 2 {
+		t.Errorf("Expected streaks of 2 and 2 but got %d and %d", current, longest)
+	}
+
+	if current, longest := dayStreaks(answered, start.AddDate(0, 0, 3)); current != 3 || longest != 3 {
+		t.Errorf("Expected three days in a row but got %d (longest %d)", current, longest)
+	}
+	if current, longest := dayStreaks(answered, start.AddDate(0, 0, 5)); current != 0 || longest != 3 {
+		t.Errorf("Expected the streak to be broken after two days off but got %d (longest %d)", current, longest)
+	}
+}
+
+func TestPeriodStart(t *testing.T) {
+	// June 1, 2022 was a Wednesday
+	when := time.Date(2022, 6, 1, 15, 30, 0, 0, time.UTC)
+	cases := map[string]string{"day": "2022-06-01", "week": "2022-05-30", "month": "2022-06-01"}
+	for period, expected := range cases {
+		if actual := periodStart(when, period).Format("2006-01-02"); actual != expected {
+			t.Errorf("Expected the %s to start %s but got %s", period, expected, actual)
+		}
+	}
+	if actual := periodStart(time.Date(2022, 6, 19, 0, 0, 0, 0, time.UTC), "month").Format("2006-01-02"); actual != "2022-06-01" {
+		t.Errorf("Expected the month to start on the first but got %s", actual)
+	}
+}
+
+func TestStatsReport(t *testing.T) {
+	start := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	if err := writeStats(&out, statsTestHistory(start).answeredQuestions(), "table", start.AddDate(0, 0, 2)); err != nil {
+		t.Fatalf("Could not write stats: %v", err)
+	}
+
+	report := out.String()
+	for _, expected := range []string{
+		"Answered 6 questions, 50.0% right",
+		"greek   3         66.7%     1s",
+		"states quizStateCapital",
+		"2       3         states: Capital of Ohio? -> Columbus",
+		"Right answers in a row: 2 (longest 2)",
+		"2022-05-30 | ",
+	} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Expected %q in the report:\n%s", expected, report)
+		}
+	}
+
+	out.Reset()
+	writeStats(&out, nil, "table", start)
+	if out.String() != "No quiz history yet\n" {
+		t.Errorf("Unexpected report for no history: %s", out.String())
+	}
+}
+
+func TestStatsExport(t *testing.T) {
+	start := time.Date(2022, 6, 1, 9, 0, 0, 0, time.UTC)
+	answered := statsTestHistory(start).answeredQuestions()
+
+	var out bytes.Buffer
+	if err := writeStats(&out, answered, "csv", start); err != nil {
+		t.Fatalf("Could not export csv: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 7 || lines[1] != "2022-06-01T09:00:00Z,greek,quizLetterBefore,Before beta?,alpha,true,false,1000,0,0" {
+		t.Errorf("Unexpected csv export:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := writeStats(&out, answered, "json", start); err != nil {
+		t.Fatalf("Could not export json: %v", err)
+	}
+	var exported []answeredQuestion
+	if err := json.Unmarshal(out.Bytes(), &exported); err != nil || len(exported) != 6 || exported[5].Prompt != "Capital of Ohio?" {
+		t.Errorf("Unexpected json export (%v): %s", err, out.String())
+	}
+
+	if err := writeStats(&out, answered, "xml", start); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
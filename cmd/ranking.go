@@ -0,0 +1,234 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Comparison questions for any dataset with a field that puts its entities in order, like the
+// size rank of a country or the year a president took office. Like crossquery, it's driven by
+// struct tags: rank:"name" marks the field that names the entity in the questions, and
+// rank:"asc" or rank:"desc" marks a numeric field to compare on. asc means the quality grows
+// with the value (a bigger bottle has more ml), and desc that it shrinks (the biggest country is
+// rank 1). rankwords says how to ask about it: the comparatives and then the superlatives, with
+// the quality growing first, as in
+//
+//	type country struct {
+//	  name       string `rank:"name"`
+//	  rankInArea int    `rank:"desc" rankwords:"is bigger,is smaller,is the largest,is the smallest"`
+//	}
+//
+// which asks "Which country is bigger: Chad or Peru?", "Which country is the largest: Chad,
+// Peru, Iran, or Mali?", and "Put these in order, starting with the one that is the smallest:
+// ...". Entities with the same value are never compared.
+
+// rankedChoices is how many entities superlative and ordering questions ask about
+const rankedChoices = 4
+
+// rankField is a field tagged with rank:"asc" or rank:"desc"
+type rankField struct {
+	index     int
+	ascending bool
+	// words are the comparatives and superlatives: more, less, most, least
+	words []string
+}
+
+// rankFields returns the index of the rank:"name" field and the ranked fields of entityType
+func rankFields(entityType reflect.Type) (int, []rankField) {
+	nameIndex := -1
+	fields := make([]rankField, 0)
+	for i := 0; i < entityType.NumField(); i++ {
+		field := entityType.Field(i)
+		switch rank := field.Tag.Get("rank"); rank {
+		case "":
+			continue
+		case "name":
+			nameIndex = i
+		case "asc", "desc":
+			words := strings.Split(field.Tag.Get("rankwords"), ",")
+			if len(words) != 4 {
+				// this is effectively a syntax error, so kill the program
+				panic(fmt.Sprintf("rankwords for %s.%s needs four words", entityType.Name(), field.Name))
+			}
+			fields = append(fields, rankField{index: i, ascending: rank == "asc", words: words})
+		default:
+			panic(fmt.Sprintf("Invalid value for rank: %s", rank))
+		}
+	}
+	if nameIndex < 0 || len(fields) == 0 {
+		panic(fmt.Sprintf("%s needs a rank:\"name\" field and a rank:\"asc\" or rank:\"desc\" field", entityType.Name()))
+	}
+	return nameIndex, fields
+}
+
+// rankedEntity is an entity as the ranking questions see it
+type rankedEntity struct {
+	names []string
+	// quality is the value of the ranked field, negated for desc, so the higher the quality the more
+	quality float64
+}
+
+// rankedEntities picks count entities with different values of a random ranked field and returns
+// them (in random order) with that field
+func rankedEntities[S ~[]E, E any](entities S, count int) ([]rankedEntity, rankField) {
+	nameIndex, fields := rankFields(reflect.TypeOf(entities).Elem())
+	field := fields[quizRand.Intn(len(fields))]
+
+	picked := make([]rankedEntity, 0, count)
+	for _, index := range quizRand.Perm(len(entities)) {
+		if len(picked) == count {
+			break
+		}
+		entity := reflect.ValueOf(entities[index])
+		ranked := rankedEntity{names: []string{entity.Field(nameIndex).String()}, quality: rankValue(entity.Field(field.index))}
+		if !field.ascending {
+			ranked.quality = -ranked.quality
+		}
+		if aliaser, ok := interface{}(entities[index]).(answerAliaser); ok {
+			ranked.names = append(ranked.names, aliaser.answerAliases(reflect.TypeOf(entities[index]).Field(nameIndex).Name)...)
+		}
+
+		duplicate := false
+		for _, other := range picked {
+			duplicate = duplicate || other.quality == ranked.quality
+		}
+		if !duplicate {
+			picked = append(picked, ranked)
+		}
+	}
+	return picked, field
+}
+
+func rankValue(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	panic(fmt.Sprintf("Can't rank a %v", v.Kind()))
+}
+
+// bestRanked returns the entity with the most (or least) of the quality
+func bestRanked(picked []rankedEntity, most bool) rankedEntity {
+	best := picked[0]
+	for _, entity := range picked[1:] {
+		if (entity.quality > best.quality) == most {
+			best = entity
+		}
+	}
+	return best
+}
+
+func rankedNames(picked []rankedEntity) []string {
+	names := make([]string, 0, len(picked))
+	for _, entity := range picked {
+		names = append(names, entity.names[0])
+	}
+	return names
+}
+
+// joinWithOr joins names as in "A, B, or C"
+func joinWithOr(names []string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	case 2:
+		return names[0] + " or " + names[1]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + ", or " + names[len(names)-1]
+}
+
+// quizCompareRanked asks which of two entities has more (or less) of the quality, as in "Which
+// country is bigger: Chad or Peru?"
+func quizCompareRanked[S ~[]E, E any](entityType string, entities S, more bool) promptAndResponse {
+	picked, field := rankedEntities(entities, 2)
+	word := field.words[1]
+	if more {
+		word = field.words[0]
+	}
+	answer := bestRanked(picked, more)
+	names := rankedNames(picked)
+	return promptAndResponse{
+		prompt:      fmt.Sprintf("Which %s %s: %s?", entityType, word, joinWithOr(names)),
+		response:    answer.names[0],
+		aliases:     answer.names[1:],
+		distractors: names,
+		closedSet:   true,
+		oneOff:      true,
+	}
+}
+
+// quizSuperlativeRanked asks which of several entities has the most (or least) of the quality
+func quizSuperlativeRanked[S ~[]E, E any](entityType string, entities S, most bool) promptAndResponse {
+	picked, field := rankedEntities(entities, rankedChoices)
+	word := field.words[3]
+	if most {
+		word = field.words[2]
+	}
+	answer := bestRanked(picked, most)
+	names := rankedNames(picked)
+	return promptAndResponse{
+		prompt:      fmt.Sprintf("Which %s %s: %s?", entityType, word, joinWithOr(names)),
+		response:    answer.names[0],
+		aliases:     answer.names[1:],
+		distractors: names,
+		closedSet:   true,
+		oneOff:      true,
+	}
+}
+
+// quizOrderRanked asks for several entities in order of the quality, scored like a recitation
+func quizOrderRanked[S ~[]E, E any](entities S, mostFirst bool) promptAndResponse {
+	picked, field := rankedEntities(entities, rankedChoices)
+	names := rankedNames(picked)
+	word := field.words[3]
+	if mostFirst {
+		word = field.words[2]
+	}
+
+	sort.Slice(picked, func(i, j int) bool { return (picked[i].quality > picked[j].quality) == mostFirst })
+	sequence := make([][]string, 0, len(picked))
+	for _, entity := range picked {
+		sequence = append(sequence, entity.names)
+	}
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("Put these in order, starting with the one that %s: %s (separate them with commas)", word, strings.Join(names, ", ")),
+		response: strings.Join(rankedNames(picked), ", "),
+		sequence: sequence,
+		oneOff:   true,
+	}
+}
+
+// quizRanked asks one of the ranking questions about entities
+func quizRanked[S ~[]E, E any](entityType string, entities S) promptAndResponse {
+	more := quizRand.Intn(2) == 0
+	switch quizRand.Intn(3) {
+	case 0:
+		return quizCompareRanked(entityType, entities, more)
+	case 1:
+		return quizSuperlativeRanked(entityType, entities, more)
+	}
+	return quizOrderRanked(entities, more)
+}
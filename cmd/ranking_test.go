@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+type rankingTestBottle struct {
+	name     string `rank:"name"`
+	sizeInMl int    `rank:"asc" rankwords:"is bigger,is smaller,is the biggest,is the smallest"`
+}
+
+var rankingTestBottles = []rankingTestBottle{{"Split", 187}, {"Half", 375}, {"Standard", 750}, {"Magnum", 1500}, {"Other Standard", 750}}
+
+func TestQuizCompareRanked(t *testing.T) {
+	seedQuizRand(1)
+	for i := 0; i < 50; i++ {
+		question := quizCompareRanked("bottle", rankingTestBottles, true)
+		if strings.Contains(question.prompt, "Standard or Other Standard") || strings.Contains(question.prompt, "Other Standard or Standard") {
+			t.Fatalf("Bottles of the same size were compared: %s", question.prompt)
+		}
+		if question.prompt == "Which bottle is bigger: Split or Magnum?" && question.response != "Magnum" {
+			t.Errorf("Expected Magnum to be bigger than Split but got %s", question.response)
+		}
+	}
+
+	// the biggest country is rank 1
+	question := quizCompareRanked("country", countries[:2], false)
+	if !strings.HasPrefix(question.prompt, "Which country is smaller: ") || question.response != countries[1].name {
+		t.Errorf("Expected %s to be smaller but got %q -> %q", countries[1].name, question.prompt, question.response)
+	}
+}
+
+func TestQuizSuperlativeRanked(t *testing.T) {
+	seedQuizRand(2)
+	question := quizSuperlativeRanked("bottle", rankingTestBottles[:4], false)
+	if !strings.HasPrefix(question.prompt, "Which bottle is the smallest: ") || !strings.Contains(question.prompt, ", or ") || question.response != "Split" || len(question.distractors) != 4 {
+		t.Errorf("Unexpected question %q -> %q", question.prompt, question.response)
+	}
+}
+
+func TestQuizOrderRanked(t *testing.T) {
+	seedQuizRand(3)
+	question := quizOrderRanked(rankingTestBottles[:4], true)
+	if !strings.HasPrefix(question.prompt, "Put these in order, starting with the one that is the biggest: ") || question.response != "Magnum, Standard, Half, Split" {
+		t.Errorf("Unexpected question %q -> %q", question.prompt, question.response)
+	}
+	if match := matchAnswer("Magnum, Half, Standard, Split", question); match != answerClose {
+		t.Errorf("Expected a partly right order to be close but got %v", match)
+	}
+
+	// Grover Cleveland can be named without his term
+	presidentsQuestion := quizOrderRanked(presidents[21:24], false)
+	if match := matchAnswer("Grover Cleveland, Benjamin Harrison, Grover Cleveland", presidentsQuestion); match != answerCorrect {
+		t.Errorf("Expected %q to accept Grover Cleveland but got %v", presidentsQuestion.response, match)
+	}
+
+	commaBottles := []rankingTestBottle{{"Magnum, Double", 3000}, {"Split, Piccolo", 187}, {"Standard", 750}}
+	commaQuestion := quizOrderRanked(commaBottles, true)
+	if match := matchAnswer(commaQuestion.response, commaQuestion); match != answerCorrect {
+		t.Errorf("Expected %q to be correct but got %v", commaQuestion.response, match)
+	}
+}
+
+func TestRankFieldsNeedWords(t *testing.T) {
+	type unranked struct {
+		name  string `rank:"name"`
+		order int    `rank:"asc"`
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for a rank field without rankwords")
+		}
+	}()
+	quizRanked("thing", []unranked{{"a", 1}, {"b", 2}})
+}
+
+func TestJoinWithOr(t *testing.T) {
+	cases := map[string][]string{
+		"":                    {},
+		"Chad":                {"Chad"},
+		"Chad or Peru":        {"Chad", "Peru"},
+		"Chad, Peru, or Fiji": {"Chad", "Peru", "Fiji"},
+	}
+	for expected, names := range cases {
+		if joined := joinWithOr(names); joined != expected {
+			t.Errorf("Expected %q for %v but got %q", expected, names, joined)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Recitation questions ask for a span of an ordered list, like presidents 5 through 9, in
+// order. They're scored item by item, like the numbers quiz scores digits, by lining up the
+// recitation with the list so that one missed item doesn't throw off the rest. A wrong answer
+// gets a diff showing the items that were missed and the ones that didn't belong.
+
+const (
+	reciteMinimumSpan = 3
+	reciteMaximumSpan = 7
+)
+
+// quizReciteSpan asks for a random span of a list, in order. plural names the items, as in
+// "presidents". positions are the items' numbers in the list (e.g. each president's number) and
+// each item of sequence is its accepted answers, the first being the one shown.
+func quizReciteSpan(plural string, positions []int, sequence [][]string) promptAndResponse {
+	span := reciteMinimumSpan + quizRand.Intn(reciteMaximumSpan-reciteMinimumSpan+1)
+	if span > len(sequence) {
+		span = len(sequence)
+	}
+	start := quizRand.Intn(len(sequence) - span + 1)
+	end := start + span
+
+	names := make([]string, 0, span)
+	for _, accepted := range sequence[start:end] {
+		names = append(names, accepted[0])
+	}
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("Recite %s %d through %d, in order (separate them with commas)", plural, positions[start], positions[end-1]),
+		response: strings.Join(names, ", "),
+		sequence: sequence[start:end],
+	}
+}
+
+// quizReciteList asks for a span of a list of strings, numbered from 1
+func quizReciteList(plural string, items []string) promptAndResponse {
+	positions := make([]int, 0, len(items))
+	sequence := make([][]string, 0, len(items))
+	for index, item := range items {
+		positions = append(positions, index+1)
+		sequence = append(sequence, []string{item})
+	}
+	return quizReciteSpan(plural, positions, sequence)
+}
+
+// quizReciteOrdered asks for a span of entities in the order given by order, such as
+// presidents by number. names returns an entity's accepted answers.
+func quizReciteOrdered[S ~[]E, E any](plural string, entities S, order func(E) int, names func(E) []string) promptAndResponse {
+	sorted := make([]E, len(entities))
+	copy(sorted, entities)
+	sort.SliceStable(sorted, func(i, j int) bool { return order(sorted[i]) < order(sorted[j]) })
+
+	positions := make([]int, 0, len(sorted))
+	sequence := make([][]string, 0, len(sorted))
+	for _, entity := range sorted {
+		positions = append(positions, order(entity))
+		sequence = append(sequence, names(entity))
+	}
+	return quizReciteSpan(plural, positions, sequence)
+}
+
+// recitationLine is one line of the diff between a recitation and the list
+type recitationLine struct {
+	// kind is ' ' for an item recited in its place, '-' for one missed, and '+' for one that doesn't belong
+	kind rune
+	text string
+	// match is how well a recited item matched, for typos
+	match answerMatch
+}
+
+// splitRecitation splits a comma separated recitation into its items. Some items have commas
+// of their own, like Henry VI, Part 2, so pieces are joined back together whenever they make up
+// one of the items of the list, taking the longest that does.
+func splitRecitation(userResponse string, sequence [][]string) []string {
+	pieces := make([]string, 0)
+	for _, piece := range strings.Split(userResponse, ",") {
+		if strings.TrimSpace(piece) != "" {
+			pieces = append(pieces, strings.TrimSpace(piece))
+		}
+	}
+
+	longest := 1
+	for _, accepted := range sequence {
+		for _, item := range accepted {
+			if commas := strings.Count(item, ","); commas+1 > longest {
+				longest = commas + 1
+			}
+		}
+	}
+
+	recited := make([]string, 0, len(pieces))
+	for start := 0; start < len(pieces); {
+		count := 1
+		for joined := longest; joined > 1; joined-- {
+			if start+joined <= len(pieces) && recitesAnItem(strings.Join(pieces[start:start+joined], ", "), sequence) {
+				count = joined
+				break
+			}
+		}
+		recited = append(recited, strings.Join(pieces[start:start+count], ", "))
+		start += count
+	}
+	return recited
+}
+
+func recitesAnItem(recited string, sequence [][]string) bool {
+	for _, accepted := range sequence {
+		if matchRecitedItem(recited, accepted).correct() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRecitedItem is how well one recited item matches one item of the list
+func matchRecitedItem(recited string, accepted []string) answerMatch {
+	best := answerIncorrect
+	for _, acceptable := range accepted {
+		if match := matchNormalizedAnswer(normalizeAnswer(recited), normalizeAnswer(acceptable)); match > best {
+			best = match
+		}
+	}
+	return best
+}
+
+// diffRecitation lines up the recitation with the list, keeping as many items as possible in
+// order (the longest common subsequence), and returns the diff
+func diffRecitation(recited []string, sequence [][]string) []recitationLine {
+	// common[i][j] is how many items of recited[i:] and sequence[j:] can be lined up
+	common := make([][]int, len(recited)+1)
+	for i := range common {
+		common[i] = make([]int, len(sequence)+1)
+	}
+	for i := len(recited) - 1; i >= 0; i-- {
+		for j := len(sequence) - 1; j >= 0; j-- {
+			if matchRecitedItem(recited[i], sequence[j]).correct() {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]recitationLine, 0, len(recited)+len(sequence))
+	i, j := 0, 0
+	for i < len(recited) || j < len(sequence) {
+		switch {
+		case i < len(recited) && j < len(sequence) && matchRecitedItem(recited[i], sequence[j]).correct() && common[i][j] == common[i+1][j+1]+1:
+			lines = append(lines, recitationLine{kind: ' ', text: sequence[j][0], match: matchRecitedItem(recited[i], sequence[j])})
+			i++
+			j++
+		case j < len(sequence) && (i == len(recited) || common[i][j+1] >= common[i+1][j]):
+			lines = append(lines, recitationLine{kind: '-', text: sequence[j][0]})
+			j++
+		default:
+			lines = append(lines, recitationLine{kind: '+', text: recited[i]})
+			i++
+		}
+	}
+	return lines
+}
+
+// recitationScore is how many items of the list were recited in order
+func recitationScore(lines []recitationLine) int {
+	score := 0
+	for _, line := range lines {
+		if line.kind == ' ' {
+			score++
+		}
+	}
+	return score
+}
+
+// matchSequence matches a recitation against the list. All of it in order, and nothing else,
+// is correct; some of it is close.
+func matchSequence(userResponse string, sequence [][]string) answerMatch {
+	lines := diffRecitation(splitRecitation(userResponse, sequence), sequence)
+	typos := false
+	for _, line := range lines {
+		if line.kind != ' ' {
+			if recitationScore(lines) > 0 {
+				return answerClose
+			}
+			return answerIncorrect
+		}
+		typos = typos || line.match == answerTypo
+	}
+	if typos {
+		return answerTypo
+	}
+	return answerCorrect
+}
+
+// printRecitationDiff shows where a recitation went wrong and its score
+func printRecitationDiff(userResponse string, sequence [][]string) {
+	lines := diffRecitation(splitRecitation(userResponse, sequence), sequence)
+	quizPrintln("Where it went wrong (- missed, + doesn't belong there):")
+	for _, line := range lines {
+		quizPrintf("  %c %s\n", line.kind, line.text)
+	}
+	quizPrintf("Score: %d/%d\n", recitationScore(lines), len(sequence))
+}
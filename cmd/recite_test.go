@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testRecitation = [][]string{{"George Washington"}, {"John Adams"}, {"Thomas Jefferson"}, {"James Madison"}, {"Grover Cleveland (22)", "Grover Cleveland"}}
+
+func TestDiffRecitation(t *testing.T) {
+	lines := diffRecitation(splitRecitation("george washington, thomas jefferson, james monroe, james madison, grover cleveland", testRecitation), testRecitation)
+	expected := []recitationLine{
+		{kind: ' ', text: "George Washington", match: answerCorrect},
+		{kind: '-', text: "John Adams"},
+		{kind: ' ', text: "Thomas Jefferson", match: answerCorrect},
+		{kind: '+', text: "james monroe"},
+		{kind: ' ', text: "James Madison", match: answerCorrect},
+		{kind: ' ', text: "Grover Cleveland (22)", match: answerCorrect},
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Expected %v but got %v", expected, lines)
+	}
+	if score := recitationScore(lines); score != 4 {
+		t.Errorf("Expected a score of 4 but got %d", score)
+	}
+}
+
+func TestMatchSequence(t *testing.T) {
+	cases := map[string]answerMatch{
+		"George Washington, John Adams, Thomas Jefferson, James Madison, Grover Cleveland": answerCorrect,
+		"John Adams, George Washington, Thomas Jefferson, James Madison, Grover Cleveland": answerClose,
+		"George Washington, John Adams": answerClose,
+		"Abraham Lincoln":               answerIncorrect,
+		"":                              answerIncorrect,
+	}
+	for recited, expected := range cases {
+		if match := matchAnswer(recited, promptAndResponse{response: "...", sequence: testRecitation}); match != expected {
+			t.Errorf("Expected %q to be %v but got %v", recited, expected, match)
+		}
+	}
+}
+
+func TestMatchSequenceWithCommas(t *testing.T) {
+	plays := [][]string{{"Henry VI, Part 2"}, {"Henry VI, Part 3"}, {"Henry VI, Part 1"}, {"Pericles, Prince of Tyre"}, {"Henry VIII"}}
+	question := promptAndResponse{response: "Henry VI, Part 2, Henry VI, Part 3, Henry VI, Part 1, Pericles, Prince of Tyre, Henry VIII", sequence: plays}
+	if match := matchAnswer(question.response, question); match != answerCorrect {
+		t.Errorf("Expected the response itself to be correct but got %v", match)
+	}
+	if match := matchAnswer("Henry VI, Part 3, Henry VI, Part 2, Henry VI, Part 1, Pericles, Prince of Tyre, Henry VIII", question); match != answerClose {
+		t.Errorf("Expected two plays swapped to be close but got %v", match)
+	}
+
+	expected := []string{"Henry VI, Part 2", "Pericles, Prince of Tyre", "Hamlet"}
+	if recited := splitRecitation("Henry VI, Part 2, Pericles, Prince of Tyre, Hamlet", plays); !reflect.DeepEqual(recited, expected) {
+		t.Errorf("Expected %v but got %v", expected, recited)
+	}
+}
+
+func TestQuizReciteOrdered(t *testing.T) {
+	seedQuizRand(3)
+	for i := 0; i < 20; i++ {
+		question := quizReciteStates(states)
+		if len(question.sequence) < reciteMinimumSpan || len(question.sequence) > reciteMaximumSpan {
+			t.Fatalf("Unexpected span of %d states", len(question.sequence))
+		}
+		if !strings.HasPrefix(question.prompt, "Recite states ") || matchAnswer(question.response, question) != answerCorrect {
+			t.Errorf("Unexpected recitation %q -> %q", question.prompt, question.response)
+		}
+	}
+
+	// the entities are put in order first
+	shuffled := []englishRoyal{{3, "Charles", ""}, {1, "Alfred", "the Great"}, {2, "Edward", ""}}
+	question := quizReciteRoyals(shuffled)
+	if question.prompt != "Recite English monarchs 1 through 3, in order (separate them with commas)" || question.response != "Alfred, Edward, Charles" {
+		t.Errorf("Unexpected recitation %q -> %q", question.prompt, question.response)
+	}
+	if match := matchAnswer("Alfred the Great, Edward, Charles", question); match != answerCorrect {
+		t.Errorf("Expected the sobriquet to be accepted but got %v", match)
+	}
+}
@@ -14,156 +14,173 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var riversCmd = &cobra.Command{
-	Use:   "rivers",
-	Short: "Quiz rivers over 1000km",
-	Run:   quizRivers,
-}
+var riversCmd = registerQuizArea(quizArea{
+	name:       "rivers",
+	tags:       []string{"geography"},
+	short:      "Quiz rivers over 1000km",
+	generators: riverQuestions,
+	dataset:    &rivers,
+	entity:     "river",
+})
 
 type river struct {
 	order int    `crossquery:"all"`
-	name  string `crossquery:"all"`
+	name  string `crossquery:"all" geo:"name"`
+	// latitude and longitude are the river's mouth, or where it joins the river it feeds
+	latitude  float64 `geo:"latitude"`
+	longitude float64 `geo:"longitude"`
 }
 
 var rivers = []river{
-	{1, "Nile"},
-	{2, "Amazon"},
-	{3, "Yangtze"},
-	{4, "Mississippi"},
-	{5, "Yenisey"},
-	{6, "Yellow"},
-	{7, "Lower Ob"},
-	{8, "Rio de la Plata"},
-	{9, "Congo"},
-	{10, "Amur"},
-	{11, "Lena"},
-	{12, "Mekong"},
-	{13, "Mackenzie"},
-	{14, "Niger"},
-	{15, "Brahmaputra"},
-	{16, "Murray"},
-	{17, "Tocantins"},
-	{18, "Volga"},
-	{19, "Shatt al-Arab"},
-	{20, "Madeira"},
-	{21, "Purus"},
-	{22, "Yukon"},
-	{23, "Indus"},
-	{24, "Sao Francisco"},
-	{25, "Syr Darya"},
-	{26, "Salween"},
-	{27, "Saint Lawrence"},
-	{28, "Rio Grande"},
-	{29, "Lower Tunguska"},
-	{30, "Colorado"},
-	{31, "Danube"},
-	{32, "Irrawaddy"},
-	{33, "Zambezi"},
-	{34, "Vilyuy"},
-	{35, "Padma"},
-	{36, "Amu Darya"},
-	{37, "Japura"},
-	{38, "Nelson"},
-	{39, "Paraguay"},
-	{40, "Kolmya"},
-	{41, "Pilcomayo"},
-	{42, "Upper Ob"},
-	{43, "Ishim"},
-	{44, "Orange"},
-	{45, "Ural"},
-	{46, "Jurua"},
-	{47, "Arkansas"},
-	{48, "Songhua"},
-	{49, "Olenyok"},
-	{50, "Dnieper"},
-	{51, "Aldan"},
-	{52, "Ubangi"},
-	{53, "Negro"},
-	{54, "Columbia"},
-	{55, "Tapajos"},
-	{56, "Pearl"},
-	{57, "Red"},
-	{58, "Kasai"},
-	{59, "Ohio"},
-	{60, "Orinoco"},
-	{61, "Tarim"},
-	{62, "Xingu"},
-	{63, "Jubba"},
-	{64, "Brazos"},
-	{65, "Northern Salado"},
-	{66, "Iça"},
-	{67, "Vitim"},
-	{68, "Chenab"},
-	{69, "Tigris"},
-	{70, "Don"},
-	{71, "Stony Tunguska"},
-	{72, "Pechora"},
-	{73, "Kama"},
-	{74, "Limpopo"},
-	{75, "Chulym"},
-	{76, "Guaviare"},
-	{77, "Marañon"},
-	{78, "Indigirka"},
-	{79, "Platte"},
-	{80, "Senegal"},
-	{81, "Khatanga"},
-	{82, "Upper Jubba"},
-	{83, "Uruguay"},
-	{84, "Churchill"},
-	{85, "Blue Nile"},
-	{86, "Okavango"},
-	{87, "Volta"},
-	{88, "Beni"},
-	{89, "Shilka"},
-	{90, "Tobol"},
-	{91, "Alazeya"},
-	{92, "Kafue"},
-	{93, "Yalong"},
-	{94, "Magdalena"},
-	{95, "Han"},
-	{96, "Kura"},
-	{97, "Oka"},
-	{98, "Upper Murray"},
-	{99, "Yana"},
-	{100, "Pecos"},
-	{101, "Murrumbidgee"},
-	{102, "Yenisey"},
-	{103, "Godavari"},
-	{104, "Sangha"},
-	{105, "Vaal"},
-	{106, "Sutlej"},
-	{107, "Ili"},
-	{108, "Olyokma"},
-	{109, "Upper Columbia"},
-	{110, "Upper Tocantins"},
-	{111, "Belaya"},
-	{112, "Cooper"},
-	{113, "Dniester"},
-	{114, "Taz"},
-	{115, "Benue"},
+	{1, "Nile", 31.5, 31.0},
+	{2, "Amazon", 0.0, -50.0},
+	{3, "Yangtze", 31.4, 121.9},
+	{4, "Mississippi", 29.15, -89.25},
+	{5, "Yenisey", 71.8, 82.7},
+	{6, "Yellow", 37.76, 119.16},
+	{7, "Lower Ob", 66.6, 71.5},
+	{8, "Rio de la Plata", -35.5, -56.0},
+	{9, "Congo", -6.0, 12.4},
+	{10, "Amur", 52.9, 141.1},
+	{11, "Lena", 72.4, 126.8},
+	{12, "Mekong", 9.9, 106.6},
+	{13, "Mackenzie", 68.9, -136.0},
+	{14, "Niger", 4.3, 6.0},
+	{15, "Brahmaputra", 23.8, 89.7},
+	{16, "Murray", -35.55, 138.9},
+	{17, "Tocantins", -1.8, -49.2},
+	{18, "Volga", 45.8, 47.6},
+	{19, "Shatt al-Arab", 29.9, 48.6},
+	{20, "Madeira", -3.4, -58.8},
+	{21, "Purus", -3.7, -61.5},
+	{22, "Yukon", 62.6, -164.8},
+	{23, "Indus", 24.0, 67.5},
+	{24, "Sao Francisco", -10.5, -36.4},
+	{25, "Syr Darya", 46.1, 61.0},
+	{26, "Salween", 16.5, 97.6},
+	{27, "Saint Lawrence", 49.5, -66.5},
+	{28, "Rio Grande", 25.96, -97.15},
+	{29, "Lower Tunguska", 65.8, 88.0},
+	{30, "Colorado", 31.8, -114.8},
+	{31, "Danube", 45.2, 29.7},
+	{32, "Irrawaddy", 15.8, 95.0},
+	{33, "Zambezi", -18.8, 36.3},
+	{34, "Vilyuy", 64.4, 126.4},
+	{35, "Padma", 23.2, 90.6},
+	{36, "Amu Darya", 43.8, 59.0},
+	{37, "Japura", -3.1, -64.8},
+	{38, "Nelson", 57.0, -92.5},
+	{39, "Paraguay", -27.3, -58.6},
+	{40, "Kolmya", 69.5, 161.5},
+	{41, "Pilcomayo", -25.35, -57.65},
+	{42, "Upper Ob", 61.0, 69.0},
+	{43, "Ishim", 57.7, 71.2},
+	{44, "Orange", -28.6, 16.5},
+	{45, "Ural", 47.0, 51.8},
+	{46, "Jurua", -2.6, -65.8},
+	{47, "Arkansas", 33.8, -91.1},
+	{48, "Songhua", 47.7, 132.5},
+	{49, "Olenyok", 73.0, 120.0},
+	{50, "Dnieper", 46.5, 32.3},
+	{51, "Aldan", 63.4, 129.6},
+	{52, "Ubangi", -0.6, 17.7},
+	{53, "Negro", -3.1, -59.9},
+	{54, "Columbia", 46.25, -124.05},
+	{55, "Tapajos", -2.4, -54.7},
+	{56, "Pearl", 22.5, 113.7},
+	{57, "Red", 31.0, -91.7},
+	{58, "Kasai", -3.2, 16.2},
+	{59, "Ohio", 37.0, -89.1},
+	{60, "Orinoco", 8.6, -60.5},
+	{61, "Tarim", 39.5, 88.3},
+	{62, "Xingu", -1.5, -51.9},
+	{63, "Jubba", -0.25, 42.6},
+	{64, "Brazos", 28.9, -95.4},
+	{65, "Northern Salado", -31.65, -60.7},
+	{66, "Iça", -3.1, -67.9},
+	{67, "Vitim", 59.4, 112.6},
+	{68, "Chenab", 29.35, 71.0},
+	{69, "Tigris", 31.0, 47.4},
+	{70, "Don", 47.1, 39.3},
+	{71, "Stony Tunguska", 61.6, 90.1},
+	{72, "Pechora", 68.2, 54.2},
+	{73, "Kama", 55.2, 49.3},
+	{74, "Limpopo", -25.2, 33.5},
+	{75, "Chulym", 57.7, 83.9},
+	{76, "Guaviare", 4.0, -67.7},
+	{77, "Marañon", -4.5, -73.5},
+	{78, "Indigirka", 71.3, 150.0},
+	{79, "Platte", 41.05, -95.9},
+	{80, "Senegal", 15.8, -16.5},
+	{81, "Khatanga", 72.9, 106.0},
+	{82, "Upper Jubba", 4.2, 42.1},
+	{83, "Uruguay", -34.0, -58.4},
+	{84, "Churchill", 58.8, -94.2},
+	{85, "Blue Nile", 15.6, 32.5},
+	{86, "Okavango", -19.3, 22.9},
+	{87, "Volta", 5.8, 0.7},
+	{88, "Beni", -10.4, -65.4},
+	{89, "Shilka", 53.3, 121.5},
+	{90, "Tobol", 58.2, 68.2},
+	{91, "Alazeya", 70.8, 153.7},
+	{92, "Kafue", -15.9, 28.9},
+	{93, "Yalong", 26.6, 101.8},
+	{94, "Magdalena", 11.1, -74.85},
+	{95, "Han", 30.55, 114.3},
+	{96, "Kura", 39.3, 49.3},
+	{97, "Oka", 56.3, 44.0},
+	{98, "Upper Murray", -34.1, 141.9},
+	{99, "Yana", 71.5, 136.5},
+	{100, "Pecos", 29.7, -101.4},
+	{101, "Murrumbidgee", -34.7, 143.2},
+	{102, "Yenisey", 71.8, 82.7},
+	{103, "Godavari", 16.6, 82.3},
+	{104, "Sangha", -1.2, 16.8},
+	{105, "Vaal", -29.1, 23.7},
+	{106, "Sutlej", 29.35, 71.0},
+	{107, "Ili", 45.4, 74.1},
+	{108, "Olyokma", 60.4, 120.7},
+	{109, "Upper Columbia", 46.2, -119.0},
+	{110, "Upper Tocantins", -5.3, -48.3},
+	{111, "Belaya", 56.0, 54.0},
+	{112, "Cooper", -28.4, 137.7},
+	{113, "Dniester", 46.35, 30.25},
+	{114, "Taz", 67.5, 78.8},
+	{115, "Benue", 7.8, 6.75},
 }
 
 type riverQuestion func([]river) promptAndResponse
 
-func quizRivers(cmd *cobra.Command, args []string) {
+func riverQuestions() []questionGenerator {
 
 	var promptFuncs = []riverQuestion{
 		crossQueryRiverInfo,
+		crossQueryRiverInfo,
+		crossQueryRiverInfo,
+		quizRiverMouthFurther,
+		quizNearestCapitalToRiverMouth,
+		quizRiverMouthOnMap,
 	}
 
-	function := randomItemFromSlice(promptFuncs)
-	promptAndCheckResponse(function(rivers))
+	return generatorsFor(promptFuncs, rivers)
 }
 
 func crossQueryRiverInfo(rivers []river) promptAndResponse {
-	foundRiver := randomItemFromSlice(rivers)
-	return constructCrossQuery("river", foundRiver)
+	return constructCrossQueryFromSlice("river", rivers)
+}
+
+func quizRiverMouthFurther(rivers []river) promptAndResponse {
+	return quizFurther("river's mouth", geoPlaces(rivers))
+}
+
+func quizNearestCapitalToRiverMouth(rivers []river) promptAndResponse {
+	mouths := geoPlaces(rivers)
+	for index := range mouths {
+		mouths[index].names = []string{"the mouth of the " + mouths[index].name()}
+	}
+	return quizNearest(mouths, "capital", geoPlaces(countries))
 }
 
-func init() {
-	memoryquizCmd.AddCommand(riversCmd)
+func quizRiverMouthOnMap(rivers []river) promptAndResponse {
+	return quizMarkedOnMap("river's mouth", geoPlaces(rivers), worldMap)
 }
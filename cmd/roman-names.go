@@ -14,15 +14,14 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
-var romanNamesCmd = &cobra.Command{
-	Use:   "roman-names",
-	Short: "Quiz Roman names for British places",
-	Run:   quizRomanNames,
-}
+var romanNamesCmd = registerQuizArea(quizArea{
+	name:       "roman-names",
+	tags:       []string{"history", "geography"},
+	short:      "Quiz Roman names for British places",
+	generators: romanNameQuestions,
+	dataset:    &romanNames,
+	entity:     "place",
+})
 
 type romanName struct {
 	modernName string `crossquery:"all" crossqueryname:"modern name"`
@@ -64,11 +63,11 @@ var romanNames = []romanName{
 	{"Norwich", "Venta Icenorum"},
 }
 
-func quizRomanNames(cmd *cobra.Command, args []string) {
-	chosenPlace := randomItemFromSlice(romanNames)
-	promptAndCheckResponse(constructCrossQuery("place", chosenPlace))
+func romanNameQuestions() []questionGenerator {
+	promptFuncs := []func([]romanName) promptAndResponse{crossQueryRomanName}
+	return generatorsFor(promptFuncs, romanNames)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(romanNamesCmd)
+func crossQueryRomanName(places []romanName) promptAndResponse {
+	return constructCrossQueryFromSlice("place", places)
 }
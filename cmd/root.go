@@ -19,10 +19,8 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"math/rand"
 	"os"
 	"strings"
-	"time"
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
@@ -56,8 +54,7 @@ func Execute() {
 }
 
 func init() {
-	rand.Seed(time.Now().UnixNano())
-	cobra.OnInitialize(initConfig)
+	cobra.OnInitialize(initConfig, loadDatasets, initQuizRand)
 
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
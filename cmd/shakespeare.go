@@ -15,17 +15,16 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
 // shakespeareCmd represents the shakespeare command
-var shakespeareCmd = &cobra.Command{
-	Use:   "shakespeare",
-	Short: "Test recall of the names of Shakespeare's plays",
-	Long:  `The exact chronology of Shakespeare's plays is difficult to gauge. This uses the ordering found at https://en.wikipedia.org/wiki/Chronology_of_Shakespeare%27s_plays as of 2021-03-08`,
-	Run:   quizShakespeare,
-}
+var shakespeareCmd = registerQuizArea(quizArea{
+	name:       "shakespeare",
+	tags:       []string{"literature"},
+	short:      "Test recall of the names of Shakespeare's plays",
+	long:       `The exact chronology of Shakespeare's plays is difficult to gauge. This uses the ordering found at https://en.wikipedia.org/wiki/Chronology_of_Shakespeare%27s_plays as of 2021-03-08`,
+	generators: shakespeareQuestions,
+	dataset:    &shakespearePlays,
+	ordinal:    "Shakespeare play",
+})
 
 var shakespearePlays = []string{
 	"The Two Gentlemen of Verona",
@@ -74,14 +73,14 @@ var shakespearePlays = []string{
 
 type shakespeareQuiz func([]string) promptAndResponse
 
-func quizShakespeare(cmd *cobra.Command, args []string) {
+func shakespeareQuestions() []questionGenerator {
 	quizzes := []shakespeareQuiz{
 		quizShakespearePlayFromIndex,
 		quizIndexOfShakespearePlay,
+		quizReciteShakespearePlays,
 	}
 
-	quiz := randomItemFromSlice(quizzes)
-	promptAndCheckResponse(quiz(shakespearePlays))
+	return generatorsFor(quizzes, shakespearePlays)
 }
 
 func quizShakespearePlayFromIndex(plays []string) promptAndResponse {
@@ -92,6 +91,6 @@ func quizIndexOfShakespearePlay(plays []string) promptAndResponse {
 	return quizIndexOfStringInList(plays)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(shakespeareCmd)
+func quizReciteShakespearePlays(plays []string) promptAndResponse {
+	return quizReciteList("Shakespeare plays", plays)
 }
@@ -15,16 +15,16 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"github.com/spf13/cobra"
-)
-
 // hebrewCmd represents the hebrew command
-var sheepCountingCmd = &cobra.Command{
-	Use:   "sheep",
-	Short: "Quiz command of English sheep counting",
-	Run:   quizSheepCounting,
-}
+var sheepCountingCmd = registerQuizArea(quizArea{
+	name:       "sheep",
+	aliases:    []string{"sheep-counting"},
+	tags:       []string{"language"},
+	short:      "Quiz command of English sheep counting",
+	generators: sheepCountingQuestions,
+	dataset:    &sheepCounting,
+	ordinal:    "sheep counting term",
+})
 
 var sheepCounting = []string{
 	"yain",
@@ -51,7 +51,7 @@ var sheepCounting = []string{
 
 type quizSheepCountingFunc func([]string) promptAndResponse
 
-func quizSheepCounting(cmd *cobra.Command, args []string) {
+func sheepCountingQuestions() []questionGenerator {
 	funcs := []quizHebrewFunc{
 		quizPositionFromLetter,
 		quizSheepCountingTermFromPosition,
@@ -59,14 +59,9 @@ func quizSheepCounting(cmd *cobra.Command, args []string) {
 		quizLetterAfter,
 	}
 
-	function := randomItemFromSlice(funcs)
-	promptAndCheckResponse(function(sheepCounting))
+	return generatorsFor(funcs, sheepCounting)
 }
 
 func quizSheepCountingTermFromPosition(alphabet []string) promptAndResponse {
 	return quizStringAtIndexInList("sheep counting term", alphabet)
 }
-
-func init() {
-	memoryquizCmd.AddCommand(sheepCountingCmd)
-}
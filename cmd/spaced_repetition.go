@@ -0,0 +1,354 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// Spaced repetition for memoryquiz, based on the SM-2 algorithm used by SuperMemo and Anki.
+// Every question asked through askScheduledQuestion is recorded in a history file, keyed by
+// quiz area, question type (the name of the function that generated it), and the prompt itself
+// (which identifies the item being asked about). Questions made up on the spot, such as
+// arithmetic problems, are keyed by area and question type alone, since the prompt won't come
+// up again. The history is saved when the quiz session ends. When picking the next question, a quiz generates
+// a batch of candidates and asks whichever one is most overdue, so weak items keep coming back
+// and items you know cold get pushed further and further out.
+
+var quizHistoryFile string
+
+// the number of candidate questions to generate when looking for one that's due
+const scheduleCandidates = 25
+
+// SM-2 constants
+const (
+	initialEasiness = 2.5
+	minimumEasiness = 1.3
+	// a missed item should come back in the same workout rather than tomorrow
+	relearnInterval = 10 * time.Minute
+	oneDay          = 24 * time.Hour
+)
+
+// response times used to turn a correct answer into an SM-2 quality score
+const (
+	fastResponse = 5 * time.Second
+	slowResponse = 15 * time.Second
+)
+
+type quizAttempt struct {
+	When     time.Time     `json:"when"`
+	Correct  bool          `json:"correct"`
+	Duration time.Duration `json:"duration"`
+	// Close marks a wrong answer that was near the right one
+	Close bool `json:"close,omitempty"`
+	// Deadline is how long there was to answer, if there was a deadline
+	Deadline time.Duration `json:"deadline,omitempty"`
+	// Hints is how many letters of the answer were given away by hints on the full screen
+	Hints int `json:"hints,omitempty"`
+	// Score is the speed-weighted score for the answer, less what hints gave away. See
+	// answerScore.
+	Score float64 `json:"score"`
+}
+
+// itemHistory is the scheduling state and attempt log for one (area, question type, item)
+type itemHistory struct {
+	Area         string        `json:"area"`
+	QuestionType string        `json:"questionType"`
+	Prompt       string        `json:"prompt"`
+	Response     string        `json:"response"`
+	Easiness     float64       `json:"easiness"`
+	Interval     time.Duration `json:"interval"`
+	Repetitions  int           `json:"repetitions"`
+	Due          time.Time     `json:"due"`
+	Attempts     []quizAttempt `json:"attempts"`
+}
+
+type quizHistory struct {
+	Items map[string]*itemHistory `json:"items"`
+}
+
+// scheduledQuestion is a candidate question along with the information needed to track it in history
+type scheduledQuestion struct {
+	area         string
+	questionType string
+	question     promptAndResponse
+	deadline     time.Duration
+}
+
+func (q scheduledQuestion) key() string {
+	if q.question.oneOff {
+		return historyKey(q.area, q.questionType, "")
+	}
+	return historyKey(q.area, q.questionType, q.question.prompt)
+}
+
+func historyKey(area, questionType, prompt string) string {
+	return fmt.Sprintf("%s|%s|%s", area, questionType, prompt)
+}
+
+// responseQuality maps an answer onto SM-2's 0-5 quality scale.
+// Misses are a 1; correct answers score higher the faster they came. Hints, given as the share
+// of the answer they gave away, make a right answer a 3 at best, and a 2 (which counts as
+// forgotten) if they gave away half of it or more.
+func responseQuality(correct bool, took time.Duration, hinted float64) int {
+	switch {
+	case !correct:
+		return 1
+	case hinted >= 0.5:
+		return 2
+	case hinted > 0:
+		return 3
+	}
+	if took < fastResponse {
+		return 5
+	} else if took < slowResponse {
+		return 4
+	}
+	return 3
+}
+
+// update applies one SM-2 review to the item and records the attempt
+func (item *itemHistory) update(correct bool, took time.Duration, hinted float64, now time.Time) {
+	quality := responseQuality(correct, took, hinted)
+	item.Attempts = append(item.Attempts, quizAttempt{When: now, Correct: correct, Duration: took})
+
+	if item.Easiness == 0 {
+		item.Easiness = initialEasiness
+	}
+	qualityGap := float64(5 - quality)
+	item.Easiness += 0.1 - qualityGap*(0.08+qualityGap*0.02)
+	if item.Easiness < minimumEasiness {
+		item.Easiness = minimumEasiness
+	}
+
+	if quality < 3 {
+		item.Repetitions = 0
+		item.Interval = relearnInterval
+	} else {
+		switch item.Repetitions {
+		case 0:
+			item.Interval = oneDay
+		case 1:
+			item.Interval = 6 * oneDay
+		default:
+			item.Interval = time.Duration(float64(item.Interval) * item.Easiness)
+		}
+		item.Repetitions++
+	}
+	item.Due = now.Add(item.Interval)
+}
+
+// record updates the history for the given question. hints is how many hints were taken.
+func (history *quizHistory) record(q scheduledQuestion, match answerMatch, took time.Duration, hints int, now time.Time) {
+	key := q.key()
+	item, exists := history.Items[key]
+	if !exists {
+		item = &itemHistory{Area: q.area, QuestionType: q.questionType}
+		history.Items[key] = item
+	}
+	// one-off questions share an item, which shows the latest of them
+	item.Prompt = q.question.prompt
+	item.Response = q.question.response
+	item.update(match.correct(), took, hintShare(hints, hintableCount(q.question.response)), now)
+	attempt := &item.Attempts[len(item.Attempts)-1]
+	attempt.Close = match == answerClose
+	attempt.Deadline = q.deadline
+	attempt.Hints = hints
+	attempt.Score = answerScore(match, took, q, hints)
+}
+
+// answerScore is the speed-weighted score for an answer, less the share of it hints gave away
+func answerScore(match answerMatch, took time.Duration, q scheduledQuestion, hints int) float64 {
+	return speedScore(match.correct(), took, q.deadline, lateAnswers) * questionScore(match, hints, hintableCount(q.question.response))
+}
+
+// pickScheduledQuestion chooses which of the candidates to ask. Items that are due come first,
+// most overdue first. After that come items that have never been asked, then whichever item
+// will come due soonest.
+func (history *quizHistory) pickScheduledQuestion(candidates []scheduledQuestion, now time.Time) scheduledQuestion {
+	var mostOverdue, neverAsked, soonestDue *scheduledQuestion
+	var mostOverdueItem, soonestDueItem *itemHistory
+	for index := range candidates {
+		candidate := &candidates[index]
+		item, exists := history.Items[candidate.key()]
+		if !exists {
+			if neverAsked == nil {
+				neverAsked = candidate
+			}
+		} else if !item.Due.After(now) {
+			if mostOverdueItem == nil || item.Due.Before(mostOverdueItem.Due) {
+				mostOverdue = candidate
+				mostOverdueItem = item
+			}
+		} else if soonestDueItem == nil || item.Due.Before(soonestDueItem.Due) {
+			soonestDue = candidate
+			soonestDueItem = item
+		}
+	}
+
+	if mostOverdue != nil {
+		return *mostOverdue
+	} else if neverAsked != nil {
+		return *neverAsked
+	}
+	return *soonestDue
+}
+
+func defaultQuizHistoryFile() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".derrick_tools", "quiz_history.json"), nil
+}
+
+func quizHistoryPath() (string, error) {
+	if quizHistoryFile != "" {
+		return quizHistoryFile, nil
+	}
+	return defaultQuizHistoryFile()
+}
+
+// loadQuizHistory reads the history file. A missing file is an empty history.
+func loadQuizHistory(fileName string) (*quizHistory, error) {
+	history := &quizHistory{make(map[string]*itemHistory)}
+	contents, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(contents, history); err != nil {
+		return nil, fmt.Errorf("Could not parse quiz history %s: %v", fileName, err)
+	}
+	if history.Items == nil {
+		history.Items = make(map[string]*itemHistory)
+	}
+	return history, nil
+}
+
+// save writes the history to a temporary file and moves it into place
+// so an interrupted quiz can't leave a half-written history behind
+func (history *quizHistory) save(fileName string) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return err
+	}
+	contents, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	tempFile := fileName + ".tmp"
+	if err := os.WriteFile(tempFile, contents, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempFile, fileName)
+}
+
+// the history is loaded once per run and shared by every question asked
+var loadedQuizHistory *quizHistory
+
+func currentQuizHistory() *quizHistory {
+	if loadedQuizHistory != nil {
+		return loadedQuizHistory
+	}
+
+	fileName, err := quizHistoryPath()
+	if err == nil {
+		loadedQuizHistory, err = loadQuizHistory(fileName)
+	}
+	if err != nil {
+		// history is a nicety; don't stop the quiz for it
+		fmt.Printf("Could not load quiz history: %v\n", err)
+		loadedQuizHistory = &quizHistory{make(map[string]*itemHistory)}
+	}
+	return loadedQuizHistory
+}
+
+// askScheduledQuestion generates candidate questions for the area, asks whichever one is
+// most in need of review, and records the result
+func askScheduledQuestion(area string, generators []questionGenerator) bool {
+	history := currentQuizHistory()
+
+	candidates := make([]scheduledQuestion, 0, scheduleCandidates)
+	generatorsByType := make(map[string]func() promptAndResponse)
+	for i := 0; i < scheduleCandidates; i++ {
+		generator := randomItemFromSlice(generators)
+		questionType := generator.name
+		if multipleChoice {
+			questionType += multipleChoiceSuffix
+		}
+		generatorsByType[questionType] = generator.generate
+		candidates = append(candidates, scheduledQuestion{area, questionType, generator.generate(), deadlineFor(area, generator)})
+	}
+	question := history.pickScheduledQuestion(candidates, time.Now())
+	if multipleChoice {
+		question.question = withMultipleChoice(question.question, generatorsByType[question.questionType])
+	}
+
+	if activeQuizScreen != nil {
+		activeQuizScreen.questionDeadline = question.deadline
+	}
+	match, took, hints := timedPromptAndMatchResponse(question.question)
+	if activeQuizScreen != nil {
+		activeQuizScreen.questionDeadline = 0
+	}
+	if stdinClosed {
+		// nobody answered, so there's nothing to record
+		return false
+	}
+	if match.correct() && isLate(took, question.deadline) {
+		if lateAnswers == lateIsMiss {
+			quizPrintf("But that was too late to count. You had %v.\n", question.deadline)
+		} else {
+			quizPrintf("But that was late, so it's only partial credit. You had %v.\n", question.deadline)
+		}
+		match = applyLatePolicy(match, took, question.deadline, lateAnswers)
+	}
+	recordAnswer(question, match, took, hints)
+	return match.correct()
+}
+
+// recordAnswer adds an answered question to the history, and to the session if there is one
+func recordAnswer(question scheduledQuestion, match answerMatch, took time.Duration, hints int) {
+	history := currentQuizHistory()
+	history.record(question, match, took, hints, time.Now())
+	if activeQuizSession != nil {
+		score := answerScore(match, took, question, hints)
+		activeQuizSession.add(quizResult{question.area, question.questionType, question.question, match.correct(), match == answerClose, took, score})
+	}
+}
+
+// saveQuizHistory writes the history, if any questions were asked. See runQuizSession.
+func saveQuizHistory() {
+	if loadedQuizHistory == nil {
+		return
+	}
+	fileName, err := quizHistoryPath()
+	if err == nil {
+		err = loadedQuizHistory.save(fileName)
+	}
+	if err != nil {
+		fmt.Printf("Could not save quiz history: %v\n", err)
+	}
+}
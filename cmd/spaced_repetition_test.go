@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSM2Intervals(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	item := &itemHistory{}
+
+	item.update(true, time.Second, 0, now)
+	if item.Interval != oneDay {
+		t.Errorf("Expected first interval of a day but got %v", item.Interval)
+	}
+
+	item.update(true, time.Second, 0, now)
+	if item.Interval != 6*oneDay {
+		t.Errorf("Expected second interval of six days but got %v", item.Interval)
+	}
+
+	item.update(true, time.Second, 0, now)
+	if item.Interval <= 6*oneDay {
+		t.Errorf("Expected third interval to grow past six days but got %v", item.Interval)
+	}
+
+	item.update(false, time.Second, 0, now)
+	if item.Interval != relearnInterval || item.Repetitions != 0 {
+		t.Errorf("Expected a miss to reset the item but got interval %v and %d repetitions", item.Interval, item.Repetitions)
+	}
+
+	if !item.Due.Equal(now.Add(relearnInterval)) {
+		t.Errorf("Expected item to be due at %v but was %v", now.Add(relearnInterval), item.Due)
+	}
+
+	if len(item.Attempts) != 4 {
+		t.Errorf("Expected 4 attempts but got %d", len(item.Attempts))
+	}
+}
+
+func TestEasinessHasFloor(t *testing.T) {
+	item := &itemHistory{}
+	for i := 0; i < 20; i++ {
+		item.update(false, time.Second, 0, time.Now())
+	}
+	if item.Easiness != minimumEasiness {
+		t.Errorf("Expected easiness to bottom out at %v but was %v", minimumEasiness, item.Easiness)
+	}
+}
+
+func TestPickScheduledQuestion(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := &quizHistory{make(map[string]*itemHistory)}
+
+	notDue := scheduledQuestion{"test", "quizTest", promptAndResponse{prompt: "not due", response: "a"}, 0}
+	slightlyOverdue := scheduledQuestion{"test", "quizTest", promptAndResponse{prompt: "slightly overdue", response: "b"}, 0}
+	veryOverdue := scheduledQuestion{"test", "quizTest", promptAndResponse{prompt: "very overdue", response: "c"}, 0}
+	neverAsked := scheduledQuestion{"test", "quizTest", promptAndResponse{prompt: "never asked", response: "d"}, 0}
+
+	history.Items[notDue.key()] = &itemHistory{Due: now.Add(time.Hour)}
+	history.Items[slightlyOverdue.key()] = &itemHistory{Due: now.Add(-time.Minute)}
+	history.Items[veryOverdue.key()] = &itemHistory{Due: now.Add(-time.Hour)}
+
+	picked := history.pickScheduledQuestion([]scheduledQuestion{notDue, neverAsked, slightlyOverdue, veryOverdue}, now)
+	if picked.question.prompt != "very overdue" {
+		t.Errorf("Expected the most overdue question but got %s", picked.question.prompt)
+	}
+
+	picked = history.pickScheduledQuestion([]scheduledQuestion{notDue, neverAsked}, now)
+	if picked.question.prompt != "never asked" {
+		t.Errorf("Expected the new question but got %s", picked.question.prompt)
+	}
+
+	picked = history.pickScheduledQuestion([]scheduledQuestion{notDue}, now)
+	if picked.question.prompt != "not due" {
+		t.Errorf("Expected the only question but got %s", picked.question.prompt)
+	}
+}
+
+func TestQuizHistoryRoundTrip(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "history", "quiz_history.json")
+	history, err := loadQuizHistory(fileName)
+	if err != nil {
+		t.Fatalf("Missing history file should not be an error: %v", err)
+	}
+
+	question := scheduledQuestion{"presidents", "quizBefore", promptAndResponse{prompt: "Who was President before John Adams?", response: "George Washington"}, 0}
+	history.record(question, answerCorrect, 3*time.Second, 0, time.Now())
+	if err := history.save(fileName); err != nil {
+		t.Fatalf("Could not save history: %v", err)
+	}
+
+	reloaded, err := loadQuizHistory(fileName)
+	if err != nil {
+		t.Fatalf("Could not reload history: %v", err)
+	}
+	item, exists := reloaded.Items[question.key()]
+	if !exists {
+		t.Fatalf("Expected %s in reloaded history", question.key())
+	}
+	if item.Response != "George Washington" || len(item.Attempts) != 1 || item.Attempts[0].Duration != 3*time.Second {
+		t.Errorf("Reloaded item did not match what was saved: %+v", item)
+	}
+}
+
+func TestHintsLowerScoreAndQuality(t *testing.T) {
+	history := &quizHistory{make(map[string]*itemHistory)}
+	question := scheduledQuestion{"presidents", "quizBefore", promptAndResponse{prompt: "Who was President before John Adams?", response: "George Washington"}, 0}
+	// George Washington has 16 letters, so 4 hints give away a quarter of it
+	history.record(question, answerCorrect, time.Second, 4, time.Now())
+	item := history.Items[question.key()]
+	if attempt := item.Attempts[0]; attempt.Score != 0.75 || attempt.Hints != 4 {
+		t.Errorf("Expected a quarter off the score for 4 hints but got %+v", attempt)
+	}
+	if item.Repetitions != 1 || item.Easiness >= initialEasiness {
+		t.Errorf("Expected a hinted answer to pass with lower easiness but got %+v", item)
+	}
+
+	if quality := responseQuality(true, time.Second, 0.25); quality != 3 {
+		t.Errorf("Expected a quick but hinted answer to be a 3 but got %d", quality)
+	}
+	if quality := responseQuality(true, time.Second, 0.5); quality != 2 {
+		t.Errorf("Expected an answer that was half given away to be a 2 but got %d", quality)
+	}
+}
+
+func TestOneOffQuestionsShareAnItem(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := &quizHistory{make(map[string]*itemHistory)}
+	for i := 0; i < 3; i++ {
+		speedMath := scheduledQuestion{"speedmath", "speedMathAddition", speedMathAddition(), 0}
+		history.record(speedMath, answerCorrect, time.Second, 0, now)
+	}
+
+	if len(history.Items) != 1 {
+		t.Fatalf("Expected one item for the question type but got %d", len(history.Items))
+	}
+	if item := history.Items[historyKey("speedmath", "speedMathAddition", "")]; item == nil || len(item.Attempts) != 3 {
+		t.Errorf("Expected three attempts on the question type but got %v", history.Items)
+	}
+}
@@ -17,76 +17,87 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"strconv"
-
-	"github.com/spf13/cobra"
+	"time"
 )
 
 // speedmathCmd represents the speedmath command
-var speedmathCmd = &cobra.Command{
-	Use:   "speedmath",
-	Short: "Quizzes to test speed math abilities",
-	Run:   speedMathTesting,
-}
+var speedmathCmd = registerQuizArea(quizArea{
+	name:       "speedmath",
+	tags:       []string{"math"},
+	short:      "Quizzes to test speed math abilities",
+	generators: speedMathQuestions,
+	parent:     rootCmd,
+})
 
 type speedMathFunc func() promptAndResponse
 
-func speedMathTesting(cmd *cobra.Command, args []string) {
-	speedMathFuncs := []speedMathFunc{
-		speedMathAddition,
-		speedMathSubtraction,
-		speedMath1xNMultiplication,
-		speedMathSquareTwoDigits,
-		speedMath2x2Multiplication,
-		speedMathSquareThreeDigits,
-		speedMathCubeTwoDigits,
-		speedMathDivideBySingleDight,
-		speedMathDivideByTwoDigits,
+// speedMathProblem is a kind of problem and how long it should take to solve
+type speedMathProblem struct {
+	quiz     speedMathFunc
+	deadline time.Duration
+}
+
+func speedMathQuestions() []questionGenerator {
+	speedMathProblems := []speedMathProblem{
+		{speedMathAddition, 10 * time.Second},
+		{speedMathSubtraction, 10 * time.Second},
+		{speedMath1xNMultiplication, 10 * time.Second},
+		{speedMathSquareTwoDigits, 15 * time.Second},
+		{speedMath2x2Multiplication, 20 * time.Second},
+		{speedMathSquareThreeDigits, 45 * time.Second},
+		{speedMathCubeTwoDigits, 45 * time.Second},
+		{speedMathDivideBySingleDight, 15 * time.Second},
+		{speedMathDivideByTwoDigits, 30 * time.Second},
 	}
 
-	mathFunc := speedMathFuncs[rand.Intn(len(speedMathFuncs))]
-	promptAndCheckResponse(mathFunc())
+	generators := make([]questionGenerator, 0, len(speedMathProblems))
+	for _, problem := range speedMathProblems {
+		generator := namedGenerators([]speedMathFunc{problem.quiz})[0]
+		generator.deadline = problem.deadline
+		generators = append(generators, generator)
+	}
+	return generators
 }
 
 func speedMathAddition() promptAndResponse {
-	addend1 := rand.Intn(10000)
-	addend2 := rand.Intn(10000)
-	return promptAndResponse{fmt.Sprintf("%d + %d = ", addend1, addend2), strconv.Itoa(addend1 + addend2)}
+	addend1 := quizRand.Intn(10000)
+	addend2 := quizRand.Intn(10000)
+	return promptAndResponse{prompt: fmt.Sprintf("%d + %d = ", addend1, addend2), response: strconv.Itoa(addend1 + addend2), oneOff: true}
 }
 
 func speedMathSubtraction() promptAndResponse {
-	minuend := rand.Intn(9900)
-	minuend += 100                   // ensure that minuend is always a reasonably sized number
-	subtrahend := rand.Intn(minuend) // ensure that subtrahend is always smaller
-	return promptAndResponse{fmt.Sprintf("%d - %d = ", minuend, subtrahend), strconv.Itoa(minuend - subtrahend)}
+	minuend := quizRand.Intn(9900)
+	minuend += 100                       // ensure that minuend is always a reasonably sized number
+	subtrahend := quizRand.Intn(minuend) // ensure that subtrahend is always smaller
+	return promptAndResponse{prompt: fmt.Sprintf("%d - %d = ", minuend, subtrahend), response: strconv.Itoa(minuend - subtrahend), oneOff: true}
 }
 
 func speedMath1xNMultiplication() promptAndResponse {
-	factor1 := rand.Intn(1000)
-	factor2 := rand.Intn(10)
-	return promptAndResponse{fmt.Sprintf("%d * %d = ", factor1, factor2), strconv.Itoa(factor1 * factor2)}
+	factor1 := quizRand.Intn(1000)
+	factor2 := quizRand.Intn(10)
+	return promptAndResponse{prompt: fmt.Sprintf("%d * %d = ", factor1, factor2), response: strconv.Itoa(factor1 * factor2), oneOff: true}
 }
 
 func speedMathSquareTwoDigits() promptAndResponse {
 	base := twoDigitNumber()
-	return promptAndResponse{fmt.Sprintf("%d^2 = ", base), strconv.Itoa(base * base)}
+	return promptAndResponse{prompt: fmt.Sprintf("%d^2 = ", base), response: strconv.Itoa(base * base), oneOff: true}
 }
 
 func speedMath2x2Multiplication() promptAndResponse {
 	factor1 := twoDigitNumber()
 	factor2 := twoDigitNumber()
-	return promptAndResponse{fmt.Sprintf("%d * %d =", factor1, factor2), strconv.Itoa(factor1 * factor2)}
+	return promptAndResponse{prompt: fmt.Sprintf("%d * %d =", factor1, factor2), response: strconv.Itoa(factor1 * factor2), oneOff: true}
 }
 
 func speedMathSquareThreeDigits() promptAndResponse {
 	base := randNumberBetween(100, 1000)
-	return promptAndResponse{fmt.Sprintf("%d^2 = ", base), strconv.Itoa(base * base)}
+	return promptAndResponse{prompt: fmt.Sprintf("%d^2 = ", base), response: strconv.Itoa(base * base), oneOff: true}
 }
 
 func speedMathCubeTwoDigits() promptAndResponse {
 	base := twoDigitNumber()
-	return promptAndResponse{fmt.Sprintf("%d^3 = ", base), strconv.Itoa(base * base * base)}
+	return promptAndResponse{prompt: fmt.Sprintf("%d^3 = ", base), response: strconv.Itoa(base * base * base), oneOff: true}
 }
 
 func speedMathDivideBySingleDight() promptAndResponse {
@@ -94,7 +105,7 @@ func speedMathDivideBySingleDight() promptAndResponse {
 	divisor := randNumberBetween(1, 10)
 	quotient := dividend / divisor
 	remainder := dividend % divisor
-	return promptAndResponse{fmt.Sprintf("%d/%d = (separate quotient and remainder with R)", dividend, divisor), fmt.Sprintf("%dR%d", quotient, remainder)}
+	return promptAndResponse{prompt: fmt.Sprintf("%d/%d = (separate quotient and remainder with R)", dividend, divisor), response: fmt.Sprintf("%dR%d", quotient, remainder), oneOff: true}
 }
 
 func speedMathDivideByTwoDigits() promptAndResponse {
@@ -102,7 +113,7 @@ func speedMathDivideByTwoDigits() promptAndResponse {
 	divisor := twoDigitNumber()
 	quotient := dividend / divisor
 	remainder := dividend % divisor
-	return promptAndResponse{fmt.Sprintf("%d/%d = (separate quotient and remainder with R)", dividend, divisor), fmt.Sprintf("%dR%d", quotient, remainder)}
+	return promptAndResponse{prompt: fmt.Sprintf("%d/%d = (separate quotient and remainder with R)", dividend, divisor), response: fmt.Sprintf("%dR%d", quotient, remainder), oneOff: true}
 }
 
 func twoDigitNumber() int {
@@ -116,12 +127,8 @@ func randNumberBetween(lower, upper int) int {
 	}
 
 	if lower > upper {
-		return rand.Intn(lower-upper) + upper
+		return quizRand.Intn(lower-upper) + upper
 	} else {
-		return rand.Intn(upper-lower) + lower
+		return quizRand.Intn(upper-lower) + lower
 	}
 }
-
-func init() {
-	rootCmd.AddCommand(speedmathCmd)
-}
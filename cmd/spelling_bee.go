@@ -17,17 +17,26 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
+	"sort"
 	"strings"
-
-	"github.com/spf13/cobra"
 )
 
-var spellingBeeCmd = &cobra.Command{
-	Use:   "spellingbee",
-	Short: "Quiz Spelling Bee word sets",
-	Run:   quizSpellingBee,
-}
+var spellingBeeCmd = registerQuizArea(quizArea{
+	name:       "spellingbee",
+	aliases:    []string{"spelling-bee"},
+	tags:       []string{"words"},
+	short:      "Quiz Spelling Bee word sets",
+	generators: spellingBeeQuestions,
+	dataset:    &spellingBeeSets,
+	validate:   validateSpellingBeeSets,
+})
+
+// Spelling Bee words are at least four letters long and use the letters of a seven letter hive,
+// always including the center letter
+const (
+	spellingBeeMinimumLength = 4
+	spellingBeeHiveSize      = 7
+)
 
 var spellingBeeSets = [][]string{
 	{"FAIR", "FRIAR", "AFFAIR", "RIFFRAFF", "RAFFIA"},
@@ -68,7 +77,6 @@ var spellingBeeSets = [][]string{
 	{"NICE", "NIECE"},
 	{"ACID", "ACIDIC", "CICADA"},
 	{"INTEL", "LENTIL", "LINTEL", "LINNET", "INLET", "ENTITLE", "LENIENT"},
-	{"LAIC", "LAICAL", "LILAC", "ILIAC"},
 	{"DANCE", "CANED", "CANNED", "DECADENCE", "DANCED", "CADENCE"},
 	{"LAMA", "LLAMA", "MAMMAL", "MALL"},
 	{"ABLE", "BALE", "LABEL", "BABBLE", "BABEL"},
@@ -87,7 +95,7 @@ var spellingBeeSets = [][]string{
 	{"DUNE", "DUNNED", "UNNEEDED", "NUDE", "ENDUED", "ENDUE", "DENUDE", "DENUDED", "UNDUE"},
 	{"DUEL", "DELUDED", "ELUDE", "DULLED", "ELUDED", "DELUDE", "LULLED"},
 	{"APNEA", "PANE", "NEAP", "PAEAN", "NAPE"},
-	{"TOME", "TOTEM", "EMOTE", "MOTE", "MOTE"},
+	{"TOME", "TOTEM", "EMOTE", "MOTE"},
 	{"OWNED", "WOODEN", "ENDOWED", "ENDOW", "DOWNED"},
 	{"LEWD", "WELLED", "WELDED", "WELD", "DWELLED", "DWELL"},
 	{"TACTICIAN", "CANTINA", "INCANT", "INTACT", "ANTIC", "TITANIC", "TANNIC"},
@@ -384,7 +392,7 @@ var spellingBeeSets = [][]string{
 	{"CADET", "ACTED"},
 	{"DEAD", "ADDED"},
 	{"DATE", "TATTED", "DATED"},
-	{"ACCEDED", "ACCEDED", "ACED", "DECADE", "ACCEDE"},
+	{"ACCEDED", "ACED", "DECADE", "ACCEDE"},
 	{"FUROR", "FOUR", "FROUFROU"},
 	{"FROG", "FORGO"},
 	{"EXACT", "EXACTA"},
@@ -515,35 +523,74 @@ var spellingBeeSets = [][]string{
 	{"GRAB", "RAGBAG", "GARB"},
 }
 
-func quizSpellingBee(cmd *cobra.Command, args []string) {
-	wordSet := spellingBeeSets[rand.Intn(len(spellingBeeSets))]
-	word := wordSet[rand.Intn(len(wordSet))]
-	inputSet := responseFromPrompt(promptAndResponse{fmt.Sprintf("What are other Spelling Bee words for %s (separate by commas)?", word), ""})
+// validateSpellingBeeSets checks that each set could come from one puzzle and that no two
+// sets are the same puzzle
+func validateSpellingBeeSets() []datasetProblem {
+	problems := make([]datasetProblem, 0)
+	setsForLetters := make(map[string]int)
+	for index, wordSet := range spellingBeeSets {
+		letters := make(map[rune]bool)
+		var centerLetters map[rune]bool
+		for _, word := range wordSet {
+			if len(word) < spellingBeeMinimumLength {
+				problems = append(problems, datasetError("list %d: %s is shorter than %d letters", index+1, word, spellingBeeMinimumLength))
+			}
 
-	enteredWords := strings.Split(inputSet, ",")
+			wordLetters := make(map[rune]bool)
+			for _, letter := range word {
+				letters[letter] = true
+				wordLetters[letter] = true
+			}
+			// the center letter has to be in every word
+			if centerLetters == nil {
+				centerLetters = wordLetters
+			}
+			for letter := range centerLetters {
+				if !wordLetters[letter] {
+					delete(centerLetters, letter)
+				}
+			}
+		}
 
-	error := false
-	// verify that entered words doesn't have entries not in the list
-	for _, enteredWord := range enteredWords {
-		if !isStringInSlice(enteredWord, wordSet) {
-			error = true
-			fmt.Printf("%s is not in the list of words for %s\n", enteredWord, word)
+		if len(letters) > spellingBeeHiveSize {
+			problems = append(problems, datasetError("list %d (%s): uses %d letters, more than fit in a hive", index+1, strings.Join(wordSet, ", "), len(letters)))
+		}
+		if len(centerLetters) == 0 {
+			problems = append(problems, datasetError("list %d (%s): no letter is in every word, so there's no center letter", index+1, strings.Join(wordSet, ", ")))
 		}
-	}
 
-	// and now verify that the list isn't missing any words
-	for _, validWord := range wordSet {
-		if !isStringInSlice(validWord, enteredWords) && validWord != word {
-			error = true
-			fmt.Printf("You missed %s\n", validWord)
+		sortedLetters := make([]string, 0, len(letters))
+		for letter := range letters {
+			sortedLetters = append(sortedLetters, string(letter))
+		}
+		sort.Strings(sortedLetters)
+		key := strings.Join(sortedLetters, "")
+		if first, exists := setsForLetters[key]; exists {
+			problems = append(problems, datasetError("list %d: uses the same letters (%s) as list %d", index+1, key, first))
+		} else {
+			setsForLetters[key] = index + 1
 		}
 	}
+	return problems
+}
 
-	if !error {
-		fmt.Println("You got them all!")
-	}
+func spellingBeeQuestions() []questionGenerator {
+	return generatorsFor([]func([][]string) promptAndResponse{quizSpellingBee}, spellingBeeSets)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(spellingBeeCmd)
+// quizSpellingBee asks for the rest of the words in a random word's set
+func quizSpellingBee(sets [][]string) promptAndResponse {
+	wordSet := sets[quizRand.Intn(len(sets))]
+	word := wordSet[quizRand.Intn(len(wordSet))]
+	otherWords := make([]string, 0, len(wordSet)-1)
+	for _, other := range wordSet {
+		if other != word {
+			otherWords = append(otherWords, other)
+		}
+	}
+	return promptAndResponse{
+		prompt:   fmt.Sprintf("What are other Spelling Bee words for %s (separate by commas)?", word),
+		response: strings.Join(otherWords, ", "),
+		allOf:    otherWords,
+	}
 }
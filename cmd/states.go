@@ -16,89 +16,95 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
-
-	"github.com/spf13/cobra"
 )
 
-var statesCmd = &cobra.Command{
-	Use:   "states",
-	Short: "Quiz state information",
-	Run:   quizStates,
-}
+var statesCmd = registerQuizArea(quizArea{
+	name:       "states",
+	tags:       []string{"geography", "history"},
+	short:      "Quiz state information",
+	generators: stateQuestions,
+	dataset:    &states,
+	validate:   validateStateBorders,
+	entity:     "state",
+})
 
 type state struct {
-	orderInUnion int      `crossquery:"all" crossqueryname:"order"`
-	name         string   `crossquery:"all"`
-	capital      string   `crossquery:"all"`
-	yearJoined   int      `crossquery:"guess" crossqueryname:"year of joining"`
+	orderInUnion int      `crossquery:"all" crossqueryname:"order" rank:"asc" rankwords:"joined later,joined earlier,joined last,joined first"`
+	name         string   `crossquery:"all" rank:"name" nameall:"name" borders:"name"`
+	capital      string   `crossquery:"all" geo:"name"`
+	yearJoined   int      `crossquery:"guess" crossqueryname:"year of joining" nameall:"that joined in %v"`
 	nicknames    []string `crossquery:"given"`
 	flowers      []string `crossquery:"guess" crossqueryname:"flower"`
 	stateBird    string   `crossquery:"guess" crossqueryname:"state bird"`
 	entryDate    string   `crossquery:"all" crossqueryname:"entry date"`
 	abbreviation string   `crossquery:"all"`
+	// latitude and longitude are the capital's
+	latitude  float64 `geo:"latitude"`
+	longitude float64 `geo:"longitude"`
+	// neighbors share a land border. States that only meet at a corner, like Arizona and Colorado, don't count.
+	neighbors []string `borders:"neighbors"`
 }
 
 var states = []state{
-	{1, "Delaware", "Dover", 1787, []string{"First State"}, []string{"Peach Blossom"}, "Delaware Blue Hen", "12/07/1787", "DE"},
-	{2, "Pennsylvania", "Harrisburg", 1787, []string{"Keystone State"}, []string{"Mountain Laurel"}, "", "12/12/1787", "PA"},
-	{3, "New Jersey", "Trenton", 1787, []string{"Garden State"}, []string{"Violet"}, "Eastern Goldfinch", "12/18/1787", "NJ"},
-	{4, "Georgia", "Atlanta", 1788, []string{"Peach State"}, []string{"Cherokee Rose"}, "Brown Thrasher", "01/02/1788", "GA"},
-	{5, "Connecticut", "Hartford", 1788, []string{"Constitution State"}, []string{"Mountain Laurel"}, "American Robin", "01/09/1788", "CT"},
-	{6, "Massachusetts", "Boston", 1788, []string{"Bay State"}, []string{"Mayflower"}, "Black-capped Chickadee", "02/06/1788", "MA"},
-	{7, "Maryland", "Annapolis", 1788, []string{"Free State", "Old Line State"}, []string{"Black-eyed Susan"}, "Baltimore Oriole", "04/28/1788", "MD"},
-	{8, "South Carolina", "Columbia", 1788, []string{"Palmetto State"}, []string{"Yellow Jessamine"}, "Carolina Wren", "05/23/1788", "SC"},
-	{9, "New Hampshire", "Concord", 1788, []string{"Granite State"}, []string{"Purple Lilac"}, "Purple Finch", "06/21/1788", "NH"},
-	{10, "Virginia", "Richmond", 1788, []string{"Old Dominion"}, []string{"American Dogwood"}, "Northern Cardinal", "06/25/1788", "VA"},
-	{11, "New York", "Albany", 1788, []string{"Empire State"}, []string{"Rose"}, "Eastern Bluebird", "07/26/1788", "NY"},
-	{12, "North Carolina", "Raleigh", 1789, []string{"Tarheel State"}, []string{"Flowering Dogwood"}, "Northern Cardinal", "11/21/1789", "NC"},
-	{13, "Rhode Island", "Providence", 1790, []string{"Ocean State"}, []string{"Violet"}, "Rhode Island Red", "05/29/1790", "RI"},
-	{14, "Vermont", "Montpelier", 1791, []string{"Green Mountain State"}, []string{"Red Clover"}, "Hermit Thrush", "03/04/1791", "VT"},
-	{15, "Kentucky", "Frankfort", 1792, []string{"Bluegrass State"}, []string{"Goldenrod"}, "Northern Cardinal", "06/01/1792", "KY"},
-	{16, "Tennessee", "Nashville", 1796, []string{"Volunteer State"}, []string{"Iris"}, "Northern Mockingbird", "06/01/1796", "TN"},
-	{17, "Ohio", "Columbus", 1803, []string{"Buckeye State"}, []string{"Scarlet Carnation"}, "Northern Cardinal", "03/01/1803", "OH"},
-	{18, "Louisiana", "Baton Rouge", 1812, []string{"Pelican State"}, []string{"Magnolia"}, "Brown Pelican", "04/30/1812", "LA"},
-	{19, "Indiana", "Indianapolis", 1816, []string{"Hoosier State"}, []string{"Peony"}, "Northern Cardinal", "12/11/1816", "IN"},
-	{20, "Mississippi", "Jackson", 1817, []string{"Magnolia State"}, []string{"Magnolia"}, "Northern Mockingbird", "12/10/1817", "MS"},
-	{21, "Illinois", "Springfield", 1818, []string{"Prairie State"}, []string{"Violet"}, "Northern Cardinal", "12/03/1818", "IL"},
-	{22, "Alabama", "Montgomery", 1819, []string{"Heart of Dixie"}, []string{"Camellia"}, "Yellowhammer", "12/14/1819", "AL"},
-	{23, "Maine", "Augusta", 1820, []string{"Pine Tree State", "Vacationland"}, []string{"White Pine Cone and Tassel"}, "Chickadee", "03/15/1820", "ME"},
-	{24, "Missouri", "Jefferson City", 1821, []string{"Show Me State"}, []string{"Hawthorn"}, "Eastern Bluebird", "08/10/1821", "MO"},
-	{25, "Arkansas", "Little Rock", 1836, []string{"Natural State"}, []string{"Apple Blossom"}, "Northern Mockingbird", "06/15/1836", "AR"},
-	{26, "Michigan", "Lansing", 1837, []string{"Wolverine State", "Great Lakes State"}, []string{"Apple Blossom"}, "American Robin", "01/26/1837", "MI"},
-	{27, "Florida", "Tallahassee", 1845, []string{"Sunshine State"}, []string{"Orange Blossom"}, "Northern Mockingbird", "03/03/1845", "FL"},
-	{28, "Texas", "Austin", 1845, []string{"Lone Star State"}, []string{"Bluebonnet"}, "Northern Mockingbird", "12/29/1845", "TX"},
-	{29, "Iowa", "Des Moines", 1846, []string{"Hawkeye State"}, []string{"Wild Rose"}, "Eastern Goldfinch", "12/28/1846", "IA"},
-	{30, "Wisconsin", "Madison", 1848, []string{"America's Dairyland"}, []string{"Wood Violet"}, "American Robin", "05/29/1848", "WI"},
-	{31, "California", "Sacramento", 1850, []string{"Golden State"}, []string{"California Poppy"}, "California Quail", "09/09/1850", "CA"},
-	{32, "Minnesota", "Saint Paul", 1853, []string{"Land of 10,000 Lakes"}, []string{"Pink and White Lady's Slipper"}, "Common Loon", "05/11/1853", "MN"},
-	{33, "Oregon", "Salem", 1859, []string{"Beaver State"}, []string{"Oregon Rose"}, "Western Meadowlark", "02/14/1859", "OR"},
-	{34, "Kansas", "Topeka", 1861, []string{"Sunflower State"}, []string{"Sunflower"}, "Western Meadowlark", "01/29/1861", "KS"},
-	{35, "West Virginia", "Charleston", 1863, []string{"Mountain State"}, []string{"Rhododendron"}, "Northern Cardinal", "06/20/1863", "WV"},
-	{36, "Nevada", "Carson City", 1864, []string{"Silver State"}, []string{"Sagebrush"}, "Mountain Bluebird", "10/31/1864", "NV"},
-	{37, "Nebraska", "Lincoln", 1867, []string{"Cornhusker State"}, []string{"Goldenrod"}, "Western Meadowlark", "03/01/1867", "NE"},
-	{38, "Colorado", "Denver", 1876, []string{"Centennial State"}, []string{"Colorado blue columbine"}, "Lark Bunting", "08/01/1876", "CO"},
-	{39, "North Dakota", "Bismarck", 1889, []string{"Peace Garden State"}, []string{"Wild Prairie Rose"}, "Western Meadowlark", "11/02/1889", "ND"},
-	{40, "South Dakota", "Pierre", 1889, []string{"Mount Rushmore State"}, []string{"Pasque Flower"}, "Ring-necked Pheasant", "11/02/1889", "SD"},
-	{41, "Montana", "Helena", 1889, []string{"Treasure State"}, []string{"Bitterroot"}, "Western Meadowlark", "11/08/1889", "MT"},
-	{42, "Washington", "Olympia", 1889, []string{"Evergreen State"}, []string{"Coast rhododendron"}, "Willow Goldfinch", "11/11/1889", "WA"},
-	{43, "Idaho", "Boise", 1890, []string{"Gem State"}, []string{"Syringa"}, "Mountain Bluebird", "07/03/1889", "ID"},
-	{44, "Wyoming", "Cheyenne", 1890, []string{"Equality State"}, []string{"Indian Paintbrush"}, "Western Meadowlark", "07/10/1890", "WY"},
-	{45, "Utah", "Salt Lake City", 1896, []string{"Beehive State"}, []string{"Sego Lily"}, "California Gull", "01/04/1896", "UT"},
-	{46, "Oklahoma", "Oklahoma City", 1907, []string{"Sooner State"}, []string{"Oklahoma Rose"}, "Scissor-tailed Flycatcher", "11/16/1907", "OK"},
-	{47, "New Mexico", "Santa Fe", 1912, []string{"Land of Enchantment"}, []string{"Yucca Flower"}, "Greater Roadrunner", "01/06/1912", "NM"},
-	{48, "Arizona", "Phoenix", 1912, []string{"Grand Canyon State"}, []string{"Saguaro Cactus Blossom"}, "Cactus Wren", "02/14/1912", "AZ"},
-	{49, "Alaska", "Juneau", 1959, []string{"Last Frontier"}, []string{"Forget-me-not"}, "Willow Ptarmigan", "01/03/1959", "AK"},
-	{50, "Hawaii", "Honolulu", 1959, []string{"Aloha State"}, []string{"Hawaiian Hibiscus"}, "Nene", "08/21/1959", "HI"},
+	{1, "Delaware", "Dover", 1787, []string{"First State"}, []string{"Peach Blossom"}, "Delaware Blue Hen", "12/07/1787", "DE", 39.16, -75.52, []string{"Maryland", "New Jersey", "Pennsylvania"}},
+	{2, "Pennsylvania", "Harrisburg", 1787, []string{"Keystone State"}, []string{"Mountain Laurel"}, "", "12/12/1787", "PA", 40.27, -76.88, []string{"Delaware", "Maryland", "New Jersey", "New York", "Ohio", "West Virginia"}},
+	{3, "New Jersey", "Trenton", 1787, []string{"Garden State"}, []string{"Violet"}, "Eastern Goldfinch", "12/18/1787", "NJ", 40.22, -74.76, []string{"Delaware", "New York", "Pennsylvania"}},
+	{4, "Georgia", "Atlanta", 1788, []string{"Peach State"}, []string{"Cherokee Rose"}, "Brown Thrasher", "01/02/1788", "GA", 33.75, -84.39, []string{"Alabama", "Florida", "North Carolina", "South Carolina", "Tennessee"}},
+	{5, "Connecticut", "Hartford", 1788, []string{"Constitution State"}, []string{"Mountain Laurel"}, "American Robin", "01/09/1788", "CT", 41.76, -72.68, []string{"Massachusetts", "New York", "Rhode Island"}},
+	{6, "Massachusetts", "Boston", 1788, []string{"Bay State"}, []string{"Mayflower"}, "Black-capped Chickadee", "02/06/1788", "MA", 42.36, -71.06, []string{"Connecticut", "New Hampshire", "New York", "Rhode Island", "Vermont"}},
+	{7, "Maryland", "Annapolis", 1788, []string{"Free State", "Old Line State"}, []string{"Black-eyed Susan"}, "Baltimore Oriole", "04/28/1788", "MD", 38.98, -76.49, []string{"Delaware", "Pennsylvania", "Virginia", "West Virginia"}},
+	{8, "South Carolina", "Columbia", 1788, []string{"Palmetto State"}, []string{"Yellow Jessamine"}, "Carolina Wren", "05/23/1788", "SC", 34.0, -81.03, []string{"Georgia", "North Carolina"}},
+	{9, "New Hampshire", "Concord", 1788, []string{"Granite State"}, []string{"Purple Lilac"}, "Purple Finch", "06/21/1788", "NH", 43.21, -71.54, []string{"Maine", "Massachusetts", "Vermont"}},
+	{10, "Virginia", "Richmond", 1788, []string{"Old Dominion"}, []string{"American Dogwood"}, "Northern Cardinal", "06/25/1788", "VA", 37.54, -77.44, []string{"Kentucky", "Maryland", "North Carolina", "Tennessee", "West Virginia"}},
+	{11, "New York", "Albany", 1788, []string{"Empire State"}, []string{"Rose"}, "Eastern Bluebird", "07/26/1788", "NY", 42.65, -73.76, []string{"Connecticut", "Massachusetts", "New Jersey", "Pennsylvania", "Vermont"}},
+	{12, "North Carolina", "Raleigh", 1789, []string{"Tarheel State"}, []string{"Flowering Dogwood"}, "Northern Cardinal", "11/21/1789", "NC", 35.78, -78.64, []string{"Georgia", "South Carolina", "Tennessee", "Virginia"}},
+	{13, "Rhode Island", "Providence", 1790, []string{"Ocean State"}, []string{"Violet"}, "Rhode Island Red", "05/29/1790", "RI", 41.82, -71.41, []string{"Connecticut", "Massachusetts"}},
+	{14, "Vermont", "Montpelier", 1791, []string{"Green Mountain State"}, []string{"Red Clover"}, "Hermit Thrush", "03/04/1791", "VT", 44.26, -72.58, []string{"Massachusetts", "New Hampshire", "New York"}},
+	{15, "Kentucky", "Frankfort", 1792, []string{"Bluegrass State"}, []string{"Goldenrod"}, "Northern Cardinal", "06/01/1792", "KY", 38.2, -84.87, []string{"Illinois", "Indiana", "Missouri", "Ohio", "Tennessee", "Virginia", "West Virginia"}},
+	{16, "Tennessee", "Nashville", 1796, []string{"Volunteer State"}, []string{"Iris"}, "Northern Mockingbird", "06/01/1796", "TN", 36.16, -86.78, []string{"Alabama", "Arkansas", "Georgia", "Kentucky", "Mississippi", "Missouri", "North Carolina", "Virginia"}},
+	{17, "Ohio", "Columbus", 1803, []string{"Buckeye State"}, []string{"Scarlet Carnation"}, "Northern Cardinal", "03/01/1803", "OH", 39.96, -83.0, []string{"Indiana", "Kentucky", "Michigan", "Pennsylvania", "West Virginia"}},
+	{18, "Louisiana", "Baton Rouge", 1812, []string{"Pelican State"}, []string{"Magnolia"}, "Brown Pelican", "04/30/1812", "LA", 30.45, -91.19, []string{"Arkansas", "Mississippi", "Texas"}},
+	{19, "Indiana", "Indianapolis", 1816, []string{"Hoosier State"}, []string{"Peony"}, "Northern Cardinal", "12/11/1816", "IN", 39.77, -86.16, []string{"Illinois", "Kentucky", "Michigan", "Ohio"}},
+	{20, "Mississippi", "Jackson", 1817, []string{"Magnolia State"}, []string{"Magnolia"}, "Northern Mockingbird", "12/10/1817", "MS", 32.3, -90.18, []string{"Alabama", "Arkansas", "Louisiana", "Tennessee"}},
+	{21, "Illinois", "Springfield", 1818, []string{"Prairie State"}, []string{"Violet"}, "Northern Cardinal", "12/03/1818", "IL", 39.8, -89.64, []string{"Indiana", "Iowa", "Kentucky", "Missouri", "Wisconsin"}},
+	{22, "Alabama", "Montgomery", 1819, []string{"Heart of Dixie"}, []string{"Camellia"}, "Yellowhammer", "12/14/1819", "AL", 32.38, -86.3, []string{"Florida", "Georgia", "Mississippi", "Tennessee"}},
+	{23, "Maine", "Augusta", 1820, []string{"Pine Tree State", "Vacationland"}, []string{"White Pine Cone and Tassel"}, "Chickadee", "03/15/1820", "ME", 44.31, -69.78, []string{"New Hampshire"}},
+	{24, "Missouri", "Jefferson City", 1821, []string{"Show Me State"}, []string{"Hawthorn"}, "Eastern Bluebird", "08/10/1821", "MO", 38.58, -92.17, []string{"Arkansas", "Illinois", "Iowa", "Kansas", "Kentucky", "Nebraska", "Oklahoma", "Tennessee"}},
+	{25, "Arkansas", "Little Rock", 1836, []string{"Natural State"}, []string{"Apple Blossom"}, "Northern Mockingbird", "06/15/1836", "AR", 34.75, -92.29, []string{"Louisiana", "Mississippi", "Missouri", "Oklahoma", "Tennessee", "Texas"}},
+	{26, "Michigan", "Lansing", 1837, []string{"Wolverine State", "Great Lakes State"}, []string{"Apple Blossom"}, "American Robin", "01/26/1837", "MI", 42.73, -84.56, []string{"Indiana", "Ohio", "Wisconsin"}},
+	{27, "Florida", "Tallahassee", 1845, []string{"Sunshine State"}, []string{"Orange Blossom"}, "Northern Mockingbird", "03/03/1845", "FL", 30.44, -84.28, []string{"Alabama", "Georgia"}},
+	{28, "Texas", "Austin", 1845, []string{"Lone Star State"}, []string{"Bluebonnet"}, "Northern Mockingbird", "12/29/1845", "TX", 30.27, -97.74, []string{"Arkansas", "Louisiana", "New Mexico", "Oklahoma"}},
+	{29, "Iowa", "Des Moines", 1846, []string{"Hawkeye State"}, []string{"Wild Rose"}, "Eastern Goldfinch", "12/28/1846", "IA", 41.59, -93.62, []string{"Illinois", "Minnesota", "Missouri", "Nebraska", "South Dakota", "Wisconsin"}},
+	{30, "Wisconsin", "Madison", 1848, []string{"America's Dairyland"}, []string{"Wood Violet"}, "American Robin", "05/29/1848", "WI", 43.07, -89.4, []string{"Illinois", "Iowa", "Michigan", "Minnesota"}},
+	{31, "California", "Sacramento", 1850, []string{"Golden State"}, []string{"California Poppy"}, "California Quail", "09/09/1850", "CA", 38.58, -121.49, []string{"Arizona", "Nevada", "Oregon"}},
+	{32, "Minnesota", "Saint Paul", 1853, []string{"Land of 10,000 Lakes"}, []string{"Pink and White Lady's Slipper"}, "Common Loon", "05/11/1853", "MN", 44.95, -93.09, []string{"Iowa", "North Dakota", "South Dakota", "Wisconsin"}},
+	{33, "Oregon", "Salem", 1859, []string{"Beaver State"}, []string{"Oregon Rose"}, "Western Meadowlark", "02/14/1859", "OR", 44.94, -123.04, []string{"California", "Idaho", "Nevada", "Washington"}},
+	{34, "Kansas", "Topeka", 1861, []string{"Sunflower State"}, []string{"Sunflower"}, "Western Meadowlark", "01/29/1861", "KS", 39.05, -95.68, []string{"Colorado", "Missouri", "Nebraska", "Oklahoma"}},
+	{35, "West Virginia", "Charleston", 1863, []string{"Mountain State"}, []string{"Rhododendron"}, "Northern Cardinal", "06/20/1863", "WV", 38.35, -81.63, []string{"Kentucky", "Maryland", "Ohio", "Pennsylvania", "Virginia"}},
+	{36, "Nevada", "Carson City", 1864, []string{"Silver State"}, []string{"Sagebrush"}, "Mountain Bluebird", "10/31/1864", "NV", 39.16, -119.77, []string{"Arizona", "California", "Idaho", "Oregon", "Utah"}},
+	{37, "Nebraska", "Lincoln", 1867, []string{"Cornhusker State"}, []string{"Goldenrod"}, "Western Meadowlark", "03/01/1867", "NE", 40.81, -96.7, []string{"Colorado", "Iowa", "Kansas", "Missouri", "South Dakota", "Wyoming"}},
+	{38, "Colorado", "Denver", 1876, []string{"Centennial State"}, []string{"Colorado blue columbine"}, "Lark Bunting", "08/01/1876", "CO", 39.74, -104.99, []string{"Kansas", "Nebraska", "New Mexico", "Oklahoma", "Utah", "Wyoming"}},
+	{39, "North Dakota", "Bismarck", 1889, []string{"Peace Garden State"}, []string{"Wild Prairie Rose"}, "Western Meadowlark", "11/02/1889", "ND", 46.81, -100.78, []string{"Minnesota", "Montana", "South Dakota"}},
+	{40, "South Dakota", "Pierre", 1889, []string{"Mount Rushmore State"}, []string{"Pasque Flower"}, "Ring-necked Pheasant", "11/02/1889", "SD", 44.37, -100.35, []string{"Iowa", "Minnesota", "Montana", "Nebraska", "North Dakota", "Wyoming"}},
+	{41, "Montana", "Helena", 1889, []string{"Treasure State"}, []string{"Bitterroot"}, "Western Meadowlark", "11/08/1889", "MT", 46.59, -112.04, []string{"Idaho", "North Dakota", "South Dakota", "Wyoming"}},
+	{42, "Washington", "Olympia", 1889, []string{"Evergreen State"}, []string{"Coast rhododendron"}, "Willow Goldfinch", "11/11/1889", "WA", 47.04, -122.9, []string{"Idaho", "Oregon"}},
+	{43, "Idaho", "Boise", 1890, []string{"Gem State"}, []string{"Syringa"}, "Mountain Bluebird", "07/03/1889", "ID", 43.62, -116.2, []string{"Montana", "Nevada", "Oregon", "Utah", "Washington", "Wyoming"}},
+	{44, "Wyoming", "Cheyenne", 1890, []string{"Equality State"}, []string{"Indian Paintbrush"}, "Western Meadowlark", "07/10/1890", "WY", 41.14, -104.82, []string{"Colorado", "Idaho", "Montana", "Nebraska", "South Dakota", "Utah"}},
+	{45, "Utah", "Salt Lake City", 1896, []string{"Beehive State"}, []string{"Sego Lily"}, "California Gull", "01/04/1896", "UT", 40.76, -111.89, []string{"Arizona", "Colorado", "Idaho", "Nevada", "Wyoming"}},
+	{46, "Oklahoma", "Oklahoma City", 1907, []string{"Sooner State"}, []string{"Oklahoma Rose"}, "Scissor-tailed Flycatcher", "11/16/1907", "OK", 35.47, -97.52, []string{"Arkansas", "Colorado", "Kansas", "Missouri", "New Mexico", "Texas"}},
+	{47, "New Mexico", "Santa Fe", 1912, []string{"Land of Enchantment"}, []string{"Yucca Flower"}, "Greater Roadrunner", "01/06/1912", "NM", 35.69, -105.94, []string{"Arizona", "Colorado", "Oklahoma", "Texas"}},
+	{48, "Arizona", "Phoenix", 1912, []string{"Grand Canyon State"}, []string{"Saguaro Cactus Blossom"}, "Cactus Wren", "02/14/1912", "AZ", 33.45, -112.07, []string{"California", "Nevada", "New Mexico", "Utah"}},
+	{49, "Alaska", "Juneau", 1959, []string{"Last Frontier"}, []string{"Forget-me-not"}, "Willow Ptarmigan", "01/03/1959", "AK", 58.3, -134.42, nil},
+	{50, "Hawaii", "Honolulu", 1959, []string{"Aloha State"}, []string{"Hawaiian Hibiscus"}, "Nene", "08/21/1959", "HI", 21.31, -157.86, nil},
 }
 
 type statesQuestion func([]state) promptAndResponse
 
-func quizStates(cmd *cobra.Command, args []string) {
+func stateQuestions() []questionGenerator {
 
 	var promptFuncs = []statesQuestion{
 		crossQueryStateInfo,
@@ -110,55 +116,56 @@ func quizStates(cmd *cobra.Command, args []string) {
 		crossQueryStateInfo,
 		crossQueryStateInfo,
 		quizStateJoinedEarliest,
+		quizRankStates,
+		quizNameAllStates,
 		quizHowManyStatesInYear,
 		quizNicknamesForState,
 		quizStatesThatJoinedInAYear,
 		quizStatesWithBird,
+		quizReciteStates,
+		quizStateCapitalFurther,
+		quizNearestStateCapital,
+		quizStateCapitalOnMap,
+		quizStateNeighbors,
+		quizStatesShareBorder,
+		quizStateBordersBoth,
+		quizStateBorderPath,
 	}
 
-	function := promptFuncs[rand.Intn(len(promptFuncs))]
-	promptAndCheckResponse(function(states))
+	return generatorsFor(promptFuncs, states)
 }
 
 func crossQueryStateInfo(states []state) promptAndResponse {
-	foundState := randomState(states)
-	return constructCrossQuery("state", foundState)
+	return constructCrossQueryFromSlice("state", states)
 }
 
 func quizStateJoinedEarliest(states []state) promptAndResponse {
-	state1 := randomState(states)
-	state2 := randomState(states)
-	for state1.name == state2.name {
-		state2 = randomState(states)
-	}
+	return quizCompareRanked("state", states, false)
+}
 
-	prompt := fmt.Sprintf("Which state joined first? %s or %s?", state1.name, state2.name)
-	if state1.orderInUnion < state2.orderInUnion {
-		return promptAndResponse{prompt, state1.name}
-	} else {
-		return promptAndResponse{prompt, state2.name}
-	}
+func quizRankStates(states []state) promptAndResponse {
+	return quizRanked("state", states)
 }
 
 func quizHowManyStatesInYear(states []state) promptAndResponse {
 	today := time.Now()
 	thisYear := today.Year()
 	firstYear := states[0].yearJoined
-	possibleDelta := rand.Intn(thisYear - states[0].yearJoined)
+	possibleDelta := quizRand.Intn(thisYear - states[0].yearJoined)
 	targetYear := firstYear + possibleDelta
 
 	countOfStates := 0
 	for stateIndex := 0; stateIndex < len(states) && states[stateIndex].yearJoined <= targetYear; stateIndex++ {
 		countOfStates++
 	}
-	return promptAndResponse{fmt.Sprintf("How many states were in the Union by the end of %d?", targetYear), strconv.Itoa(countOfStates)}
+	return promptAndResponse{prompt: fmt.Sprintf("How many states were in the Union by the end of %d?", targetYear), response: strconv.Itoa(countOfStates)}
 }
 
 func quizNicknamesForState(states []state) promptAndResponse {
 	state := randomState(states)
 	sort.Strings(state.nicknames)
 	nicknames := strings.Join(state.nicknames, ",")
-	return promptAndResponse{fmt.Sprintf("What are the nicknames of %s?", state.name), nicknames}
+	return promptAndResponse{prompt: fmt.Sprintf("What are the nicknames of %s?", state.name), response: nicknames}
 }
 
 func quizStatesThatJoinedInAYear(states []state) promptAndResponse {
@@ -169,7 +176,7 @@ func quizStatesThatJoinedInAYear(states []state) promptAndResponse {
 			statesThatJoinedThatYear++
 		}
 	}
-	return promptAndResponse{fmt.Sprintf("How many states joined in %d?", state.yearJoined), strconv.Itoa(statesThatJoinedThatYear)}
+	return promptAndResponse{prompt: fmt.Sprintf("How many states joined in %d?", state.yearJoined), response: strconv.Itoa(statesThatJoinedThatYear)}
 }
 
 func quizStatesWithBird(states []state) promptAndResponse {
@@ -180,13 +187,53 @@ func quizStatesWithBird(states []state) promptAndResponse {
 			statesWithBird++
 		}
 	}
-	return promptAndResponse{fmt.Sprintf("How many states have %s as the state bird?", bird), strconv.Itoa(statesWithBird)}
+	return promptAndResponse{prompt: fmt.Sprintf("How many states have %s as the state bird?", bird), response: strconv.Itoa(statesWithBird)}
 }
 
 func randomState(states []state) state {
-	return states[rand.Intn(len(states))]
+	return states[quizRand.Intn(len(states))]
+}
+
+func quizReciteStates(states []state) promptAndResponse {
+	return quizReciteOrdered("states", states,
+		func(s state) int { return s.orderInUnion },
+		func(s state) []string { return []string{s.name} })
+}
+
+func quizNameAllStates(states []state) promptAndResponse {
+	return quizNameAll("state", states)
+}
+
+func quizStateCapitalFurther(states []state) promptAndResponse {
+	return quizFurther("state capital", geoPlaces(states))
+}
+
+func quizNearestStateCapital(states []state) promptAndResponse {
+	capitals := geoPlaces(states)
+	return quizNearest(capitals, "state capital", capitals)
+}
+
+// quizStateCapitalOnMap leaves out Alaska and Hawaii, which are off the map
+func quizStateCapitalOnMap(states []state) promptAndResponse {
+	return quizMarkedOnMap("state capital", geoPlaces(states), usMap)
+}
+
+func validateStateBorders() []datasetProblem {
+	return bordersOf(states).problems()
+}
+
+func quizStateNeighbors(states []state) promptAndResponse {
+	return quizNeighbors("state", bordersOf(states))
+}
+
+func quizStatesShareBorder(states []state) promptAndResponse {
+	return quizShareBorder(bordersOf(states))
+}
+
+func quizStateBordersBoth(states []state) promptAndResponse {
+	return quizBordersBoth("state", bordersOf(states))
 }
 
-func init() {
-	memoryquizCmd.AddCommand(statesCmd)
+func quizStateBorderPath(states []state) promptAndResponse {
+	return quizBorderPath("states", bordersOf(states))
 }
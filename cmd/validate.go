@@ -0,0 +1,219 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/spf13/cobra"
+)
+
+// Static checks on the quiz data, so mistakes show up as a report instead of a wrong answer
+// or a panic in the middle of a quiz. Every dataset gets the generic checks below, and an area
+// can add its own rules with quizArea.validate.
+
+// datasetProblem is one thing wrong with a dataset. Warnings are things that are allowed but
+// probably unfinished, like a crossquery field with no value.
+type datasetProblem struct {
+	dataset string
+	warning bool
+	message string
+}
+
+func datasetError(format string, args ...interface{}) datasetProblem {
+	return datasetProblem{message: fmt.Sprintf(format, args...)}
+}
+
+func datasetWarning(format string, args ...interface{}) datasetProblem {
+	return datasetProblem{warning: true, message: fmt.Sprintf(format, args...)}
+}
+
+// validateQuizAreas checks the dataset of every area that has one, after any dataset files
+// that couldn't be loaded
+func validateQuizAreas() []datasetProblem {
+	problems := append([]datasetProblem{}, datasetLoadProblems...)
+	for _, area := range sortedQuizAreas() {
+		if area.dataset == nil {
+			continue
+		}
+		areaProblems := validateDataset(area.dataset)
+		if area.validate != nil {
+			areaProblems = append(areaProblems, area.validate()...)
+		}
+		for _, problem := range areaProblems {
+			problem.dataset = area.name
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+// validateDataset runs the generic checks on a dataset, a pointer to a slice
+func validateDataset(dataset interface{}) []datasetProblem {
+	records := reflect.ValueOf(dataset).Elem()
+	if records.Len() == 0 {
+		return []datasetProblem{datasetError("dataset is empty")}
+	}
+
+	switch records.Type().Elem().Kind() {
+	case reflect.Struct:
+		return validateStructRecords(records)
+	case reflect.Slice:
+		problems := make([]datasetProblem, 0)
+		for index := 0; index < records.Len(); index++ {
+			for _, problem := range validateListRecords(records.Index(index)) {
+				problem.message = fmt.Sprintf("list %d: %s", index+1, problem.message)
+				problems = append(problems, problem)
+			}
+		}
+		return problems
+	default:
+		return validateListRecords(records)
+	}
+}
+
+// validateListRecords checks a list of plain values, like the Shakespeare plays, for blanks and repeats
+func validateListRecords(records reflect.Value) []datasetProblem {
+	problems := make([]datasetProblem, 0)
+	seen := make(map[string]int)
+	for index := 0; index < records.Len(); index++ {
+		value := reflectValueToString(records.Index(index))
+		if normalizeAnswer(value) == "" {
+			problems = append(problems, datasetError("item %d is empty", index+1))
+			continue
+		}
+		if first, exists := seen[normalizeAnswer(value)]; exists {
+			problems = append(problems, datasetError("%s is listed at %d and %d", value, first, index+1))
+		} else {
+			seen[normalizeAnswer(value)] = index + 1
+		}
+	}
+	return problems
+}
+
+// validateStructRecords checks crossquery tags, duplicate records, and that every record
+// can be asked about
+func validateStructRecords(records reflect.Value) []datasetProblem {
+	problems := make([]datasetProblem, 0)
+	recordType := records.Type().Elem()
+	hasCrossQuery := false
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		crossQuery, ok := field.Tag.Lookup("crossquery")
+		if !ok {
+			continue
+		}
+		hasCrossQuery = true
+		switch crossQuery {
+		case "", "all", "given", "guess":
+		default:
+			problems = append(problems, datasetError("field %s has an invalid crossquery tag of %q", field.Name, crossQuery))
+		}
+	}
+	if len(problems) > 0 {
+		// the records can't be checked any further without panicking
+		return problems
+	}
+
+	keyIndex := datasetKeyField(recordType)
+	seenKeys := make(map[string]int)
+	entityFields := make([][]crossQueryField, 0, records.Len())
+	for index := 0; index < records.Len(); index++ {
+		record := records.Index(index)
+		label := recordLabel(record, keyIndex, index)
+
+		for earlier := 0; earlier < index; earlier++ {
+			if reflect.DeepEqual(record.Interface(), records.Index(earlier).Interface()) {
+				problems = append(problems, datasetError("%s duplicates record %d", label, earlier+1))
+				break
+			}
+		}
+		if keyIndex >= 0 && canBeGiven(recordType.Field(keyIndex)) {
+			key := normalizeAnswer(reflectValueToString(record.Field(keyIndex)))
+			if first, exists := seenKeys[key]; exists && key != "" {
+				// a warning, since some names really do repeat (there are two Bernerays in the Outer Hebrides)
+				problems = append(problems, datasetWarning("%s has the same %s as record %d", label, humanReadableFieldName(recordType.Field(keyIndex)), first))
+			} else {
+				seenKeys[key] = index + 1
+			}
+		}
+
+		fields := crossQueryFields(record.Interface())
+		for _, field := range fields {
+			if len(field.values) == 0 && !field.optional {
+				problems = append(problems, datasetWarning("%s has no %s", label, field.name))
+			}
+		}
+		entityFields = append(entityFields, fields)
+	}
+
+	if hasCrossQuery {
+		for index := range entityFields {
+			if !canCrossQuery(askableFields(entityFields, index)) {
+				problems = append(problems, datasetError("%s can't be asked about: it needs a given and a different field to guess", recordLabel(records.Index(index), keyIndex, index)))
+			}
+		}
+	}
+	return problems
+}
+
+func canBeGiven(field reflect.StructField) bool {
+	crossQuery, ok := field.Tag.Lookup("crossquery")
+	return ok && crossQuery != "guess"
+}
+
+// recordLabel names a record in a problem report, e.g. record 3 (Thomas Jefferson)
+func recordLabel(record reflect.Value, keyIndex int, index int) string {
+	if keyIndex >= 0 {
+		if key := reflectValueToString(record.Field(keyIndex)); key != "" {
+			return fmt.Sprintf("record %d (%s)", index+1, key)
+		}
+	}
+	return fmt.Sprintf("record %d", index+1)
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the quiz datasets for mistakes",
+	Long: `Check every quiz dataset, including any changes from the datasets directory, for
+duplicates, empty fields, bad crossquery tags, and rules specific to a quiz (like the
+Spelling Bee's letters fitting in a hive). Exits with an error if there are problems
+other than warnings.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		errors := 0
+		for _, problem := range validateQuizAreas() {
+			severity := "error"
+			if problem.warning {
+				severity = "warning"
+			} else {
+				errors++
+			}
+			fmt.Printf("%s: %s: %s\n", problem.dataset, severity, problem.message)
+		}
+
+		if errors > 0 {
+			fmt.Printf("%d errors\n", errors)
+			os.Exit(1)
+		}
+		fmt.Println("No errors")
+	},
+}
+
+func init() {
+	memoryquizCmd.AddCommand(validateCmd)
+}
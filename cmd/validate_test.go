@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuizDatasetsHaveNoErrors(t *testing.T) {
+	for _, problem := range validateQuizAreas() {
+		if !problem.warning {
+			t.Errorf("%s: %s", problem.dataset, problem.message)
+		}
+	}
+}
+
+// problemMessages splits the problems into errors and warnings for easier checking
+func problemMessages(problems []datasetProblem) (errors []string, warnings []string) {
+	for _, problem := range problems {
+		if problem.warning {
+			warnings = append(warnings, problem.message)
+		} else {
+			errors = append(errors, problem.message)
+		}
+	}
+	return errors, warnings
+}
+
+type validateBadTag struct {
+	name  string `crossquery:"given"`
+	state string `crossquery:"sometimes"`
+}
+
+func TestValidateBadTag(t *testing.T) {
+	errors, _ := problemMessages(validateDataset(&[]validateBadTag{{"Columbus", "Ohio"}}))
+	if len(errors) != 1 || !strings.Contains(errors[0], `invalid crossquery tag of "sometimes"`) {
+		t.Errorf("Expected an error for the bad tag but got %v", errors)
+	}
+}
+
+func TestValidateStructRecords(t *testing.T) {
+	records := []crossQueryRegion{
+		{"France", "Europe", "Paris"},
+		{"France", "Europe", "Paris"},
+		{"Germany", "Europe", ""},
+		{"Germany", "Europe", "Bonn"},
+	}
+	errors, warnings := problemMessages(validateDataset(&records))
+	expectedErrors := []string{"record 2 (France) duplicates record 1"}
+	expectedWarnings := []string{
+		"record 2 (France) has the same name as record 1",
+		"record 3 (Germany) has no capital",
+		"record 4 (Germany) has the same name as record 3",
+	}
+	if strings.Join(errors, "\n") != strings.Join(expectedErrors, "\n") {
+		t.Errorf("Expected errors %v but got %v", expectedErrors, errors)
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expectedWarnings, "\n") {
+		t.Errorf("Expected warnings %v but got %v", expectedWarnings, warnings)
+	}
+}
+
+type validateOptionalField struct {
+	name               string `crossquery:"all"`
+	currency           string `crossquery:"guess"`
+	fractionalCurrency string `crossquery:"guess" crossqueryname:"fractional currency" crossqueryoptional:"true"`
+}
+
+func TestValidateOptionalField(t *testing.T) {
+	_, warnings := problemMessages(validateDataset(&[]validateOptionalField{{"Japan", "yen", ""}, {"Peru", "", "centimo"}}))
+	if len(warnings) != 1 || warnings[0] != "record 2 (Peru) has no currency" {
+		t.Errorf("Expected a warning for the missing currency only but got %v", warnings)
+	}
+}
+
+func TestValidateUnaskableRecords(t *testing.T) {
+	records := []crossQueryRegion{{"France", "Europe", "Paris"}, {"", "", "Berlin"}}
+	errors, _ := problemMessages(validateDataset(&records))
+	if len(errors) != 1 || !strings.HasPrefix(errors[0], "record 2 can't be asked about") {
+		t.Errorf("Expected the record with only a guess to be reported but got %v", errors)
+	}
+}
+
+func TestValidateListRecords(t *testing.T) {
+	errors, _ := problemMessages(validateDataset(&[]string{"Hamlet", "", "Macbeth", "hamlet"}))
+	expected := []string{"item 2 is empty", "hamlet is listed at 1 and 4"}
+	if strings.Join(errors, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %v but got %v", expected, errors)
+	}
+
+	errors, _ = problemMessages(validateDataset(&[][]string{{"TOME", "MOTE"}, {"MOTE", "MOTE"}}))
+	if len(errors) != 1 || errors[0] != "list 2: MOTE is listed at 1 and 2" {
+		t.Errorf("Expected the repeat in the second list but got %v", errors)
+	}
+
+	errors, _ = problemMessages(validateDataset(&[]string{}))
+	if len(errors) != 1 || errors[0] != "dataset is empty" {
+		t.Errorf("Expected an empty dataset to be reported but got %v", errors)
+	}
+}
+
+func TestValidateSpellingBeeSets(t *testing.T) {
+	defer func(sets [][]string) { spellingBeeSets = sets }(spellingBeeSets)
+	spellingBeeSets = [][]string{
+		{"LAIC", "LILAC", "ILIAC"},
+		{"TOME", "TOTEM", "ACE"},
+		{"ILIAC", "LAICAL"},
+		{"BACKFIRED", "FIRE"},
+		{"TOME", "CALL"},
+	}
+
+	errors, _ := problemMessages(validateSpellingBeeSets())
+	expected := []string{
+		"list 2: ACE is shorter than 4 letters",
+		"list 3: uses the same letters (ACIL) as list 1",
+		"list 4 (BACKFIRED, FIRE): uses 9 letters, more than fit in a hive",
+		"list 5 (TOME, CALL): no letter is in every word, so there's no center letter",
+	}
+	if strings.Join(errors, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected\n%s\nbut got\n%s", strings.Join(expected, "\n"), strings.Join(errors, "\n"))
+	}
+}
@@ -15,17 +15,14 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"math/rand"
-
-	"github.com/spf13/cobra"
-)
-
-var whosonfirstCmd = &cobra.Command{
-	Use:   "whosonfirst",
-	Short: "Test recall of the players in Who's On First",
-	Run:   quizWhosonfirst,
-}
+var whosonfirstCmd = registerQuizArea(quizArea{
+	name:       "whosonfirst",
+	tags:       []string{"sports", "comedy"},
+	short:      "Test recall of the players in Who's On First",
+	generators: whosOnFirstQuestions,
+	dataset:    &whosOnFirstPlayers,
+	entity:     "who's on first player",
+})
 
 type whosonfirst struct {
 	name     string `crossquery:"all"`
@@ -45,15 +42,11 @@ var whosOnFirstPlayers = []whosonfirst{
 
 type whosonfirstQuiz func([]whosonfirst) promptAndResponse
 
-func quizWhosonfirst(cmd *cobra.Command, args []string) {
-	player := randomPlayer(whosOnFirstPlayers)
-	promptAndCheckResponse(constructCrossQuery("who's on first player", player))
-}
-
-func randomPlayer(players []whosonfirst) whosonfirst {
-	return players[rand.Intn(len(players))]
+func whosOnFirstQuestions() []questionGenerator {
+	quizzes := []whosonfirstQuiz{crossQueryWhosonfirstPlayer}
+	return generatorsFor(quizzes, whosOnFirstPlayers)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(whosonfirstCmd)
+func crossQueryWhosonfirstPlayer(players []whosonfirst) promptAndResponse {
+	return constructCrossQueryFromSlice("who's on first player", players)
 }
@@ -14,23 +14,20 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"math/rand"
-
-	"github.com/spf13/cobra"
-)
-
-var wineBottlesCmd = &cobra.Command{
-	Use:   "bottles",
-	Short: "Quiz wine bottle sizes",
-	Run:   quizWineBottles,
-}
+var wineBottlesCmd = registerQuizArea(quizArea{
+	name:       "bottles",
+	tags:       []string{"wine"},
+	short:      "Quiz wine bottle sizes",
+	generators: wineBottleQuestions,
+	dataset:    &bottles,
+	entity:     "wine bottle",
+})
 
 type wineBottle struct {
 	sizeRank     int    `crossquery:"all" crossqueryname:"order"`
-	name         string `crossquery:"all"`
+	name         string `crossquery:"all" rank:"name"`
 	bordeauxName string `crossquery:"all" crossqueryname:"Bordeaux name"`
-	sizeInMl     int    `crossquery:"all" crossqueryname:"size in ml"`
+	sizeInMl     int    `crossquery:"all" crossqueryname:"size in ml" rank:"asc" rankwords:"is bigger,is smaller,is the biggest,is the smallest"`
 }
 
 var bottles = []wineBottle{
@@ -53,12 +50,15 @@ var bottles = []wineBottle{
 	{17, "Melchizedek/Midas", "", 30000},
 }
 
-func quizWineBottles(cmd *cobra.Command, args []string) {
+func wineBottleQuestions() []questionGenerator {
+	promptFuncs := []func([]wineBottle) promptAndResponse{crossQueryWineBottle, quizRankWineBottles}
+	return generatorsFor(promptFuncs, bottles)
+}
 
-	bottle := bottles[rand.Intn(len(bottles))]
-	promptAndCheckResponse(constructCrossQuery("wine bottle", bottle))
+func crossQueryWineBottle(bottles []wineBottle) promptAndResponse {
+	return constructCrossQueryFromSlice("wine bottle", bottles)
 }
 
-func init() {
-	memoryquizCmd.AddCommand(wineBottlesCmd)
+func quizRankWineBottles(bottles []wineBottle) promptAndResponse {
+	return quizRanked("wine bottle", bottles)
 }
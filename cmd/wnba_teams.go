@@ -14,17 +14,15 @@ limitations under the License.
 */
 package cmd
 
-import (
-	"math/rand"
-
-	"github.com/spf13/cobra"
-)
-
-var wnbaCmd = &cobra.Command{
-	Use:   "wnba-teams",
-	Short: "Quiz WNBA teams",
-	Run:   quizWnbaTeams,
-}
+var wnbaCmd = registerQuizArea(quizArea{
+	name:       "wnba-teams",
+	aliases:    []string{"wnba"},
+	tags:       []string{"sports"},
+	short:      "Quiz WNBA teams",
+	generators: wnbaTeamQuestions,
+	dataset:    &wnbaTeams,
+	entity:     "WNBA team",
+})
 
 type wnbaTeam struct {
 	index int    `crossquery:"all"`
@@ -50,21 +48,15 @@ var wnbaTeams = []wnbaTeam{
 
 type wnbaQuestion func([]wnbaTeam) promptAndResponse
 
-func quizWnbaTeams(cmd *cobra.Command, args []string) {
+func wnbaTeamQuestions() []questionGenerator {
 
 	var promptFuncs = []wnbaQuestion{
 		crossQueryWnbaTeamInfo,
 	}
 
-	function := promptFuncs[rand.Intn(len(promptFuncs))]
-	promptAndCheckResponse(function(wnbaTeams))
+	return generatorsFor(promptFuncs, wnbaTeams)
 }
 
 func crossQueryWnbaTeamInfo(teams []wnbaTeam) promptAndResponse {
-	foundTeam := teams[rand.Intn(len(teams))]
-	return constructCrossQuery("WNBA team", foundTeam)
-}
-
-func init() {
-	memoryquizCmd.AddCommand(wnbaCmd)
+	return constructCrossQueryFromSlice("WNBA team", teams)
 }
@@ -0,0 +1,397 @@
+/*
+Copyright © 2022 Derrick Schneider derrick.schneider@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Worksheets are quizzes on paper (or in a chat, or over email). memoryquiz worksheet asks the
+// areas' generators for questions and writes them out as text, Markdown, or HTML, along with an
+// answer key. The key is also saved as JSON, with everything needed to grade the answers, so
+// memoryquiz grade can check a filled-in answers file with the same matching as a live quiz.
+//
+// An answers file has one answer per line, either in order or numbered like the worksheet:
+//
+//	1. Columbus
+//	2) B
+//	3. Hannibal Hamlin, Andrew Johnson
+
+var worksheetFormat string
+var worksheetTitle string
+var worksheetOutput string
+var worksheetKeyFile string
+var worksheetAnswerKey string
+
+// how many times to try for a question that isn't already on the worksheet
+const worksheetAttempts = 20
+
+// worksheetQuestion is a question on a worksheet, as saved in the key
+type worksheetQuestion struct {
+	Area         string     `json:"area"`
+	QuestionType string     `json:"questionType"`
+	Prompt       string     `json:"prompt"`
+	Response     string     `json:"response"`
+	Aliases      []string   `json:"aliases,omitempty"`
+	AllOf        []string   `json:"allOf,omitempty"`
+	Sequence     [][]string `json:"sequence,omitempty"`
+	Choices      []string   `json:"choices,omitempty"`
+}
+
+// worksheetKey is everything needed to grade a worksheet
+type worksheetKey struct {
+	Title     string              `json:"title"`
+	Seed      int64               `json:"seed"`
+	Questions []worksheetQuestion `json:"questions"`
+}
+
+func newWorksheetQuestion(area string, questionType string, question promptAndResponse) worksheetQuestion {
+	return worksheetQuestion{
+		Area:         area,
+		QuestionType: questionType,
+		Prompt:       question.prompt,
+		Response:     question.response,
+		Aliases:      question.aliases,
+		AllOf:        question.allOf,
+		Sequence:     question.sequence,
+		Choices:      question.choices,
+	}
+}
+
+func (question worksheetQuestion) promptAndResponse() promptAndResponse {
+	return promptAndResponse{
+		prompt:   question.Prompt,
+		response: question.Response,
+		aliases:  question.Aliases,
+		allOf:    question.AllOf,
+		sequence: question.Sequence,
+		choices:  question.Choices,
+	}
+}
+
+// rightAnswer is the answer as it should appear in the key, e.g. "B) Columbus"
+func (question worksheetQuestion) rightAnswer() string {
+	for index, choice := range question.Choices {
+		if choice == question.Response {
+			return fmt.Sprintf("%s) %s", choiceLetter(index), choice)
+		}
+	}
+	return question.Response
+}
+
+// worksheetAreas looks up the areas named in args, or returns every area that asks questions
+// if there aren't any
+func worksheetAreas(args []string) ([]*quizArea, error) {
+	areas := make([]*quizArea, 0)
+	if len(args) == 0 {
+		for _, area := range sortedQuizAreas() {
+			if area.generators != nil {
+				areas = append(areas, area)
+			}
+		}
+		return areas, nil
+	}
+
+	for _, name := range args {
+		area, found := findQuizArea(name)
+		if !found {
+			return nil, fmt.Errorf("There's no quiz area called %s", name)
+		}
+		if area.generators == nil {
+			return nil, fmt.Errorf("%s is interactive and can't be put on a worksheet", area.name)
+		}
+		areas = append(areas, area)
+	}
+	return areas, nil
+}
+
+// generateWorksheet picks count questions from the areas, avoiding repeats where it can
+func generateWorksheet(title string, areas []*quizArea, count int) worksheetKey {
+	key := worksheetKey{Title: title, Seed: quizSeed}
+	asked := make(map[string]bool)
+	for len(key.Questions) < count {
+		area := randomItemFromSlice(areas)
+		generators := area.generators()
+
+		var generator questionGenerator
+		var question promptAndResponse
+		for i := 0; i < worksheetAttempts; i++ {
+			generator = randomItemFromSlice(generators)
+			question = generator.generate()
+			if !asked[question.prompt] {
+				break
+			}
+		}
+		if multipleChoice {
+			question = withMultipleChoice(question, generator.generate)
+		}
+		asked[question.prompt] = true
+		key.Questions = append(key.Questions, newWorksheetQuestion(area.name, generator.name, question))
+	}
+	return key
+}
+
+// splitPromptFigure splits a prompt into the question and what's drawn under it, like the map in
+// quizMarkedOnMap, which has to keep its line breaks and spacing
+func splitPromptFigure(prompt string) (string, string) {
+	question, figure, _ := strings.Cut(prompt, "\n")
+	return question, figure
+}
+
+// writeWorksheet writes the questions, or the answer key if withAnswers is set, in format
+func writeWorksheet(out io.Writer, key worksheetKey, format string, withAnswers bool) error {
+	title := key.Title
+	if withAnswers {
+		title += " (answers)"
+	}
+
+	switch format {
+	case "text":
+		fmt.Fprintf(out, "%s\n%s\n\n", title, strings.Repeat("=", len(title)))
+		if !withAnswers {
+			fmt.Fprintf(out, "Name: ______________________\n\n")
+		}
+		for index, question := range key.Questions {
+			fmt.Fprintf(out, "%d. %s\n", index+1, question.Prompt)
+			for choice, text := range question.Choices {
+				fmt.Fprintf(out, "   %s) %s\n", choiceLetter(choice), text)
+			}
+			if withAnswers {
+				fmt.Fprintf(out, "   Answer: %s\n\n", question.rightAnswer())
+			} else {
+				fmt.Fprintf(out, "   ______________________\n\n")
+			}
+		}
+	case "markdown":
+		fmt.Fprintf(out, "# %s\n\n", title)
+		for index, question := range key.Questions {
+			prompt, figure := splitPromptFigure(question.Prompt)
+			fmt.Fprintf(out, "%d. %s\n", index+1, prompt)
+			if figure != "" {
+				// a fenced block indented to stay in the list item
+				fmt.Fprintf(out, "\n   ```\n   %s\n   ```\n", strings.ReplaceAll(figure, "\n", "\n   "))
+			}
+			for choice, text := range question.Choices {
+				fmt.Fprintf(out, "   - %s) %s\n", choiceLetter(choice), text)
+			}
+			if withAnswers {
+				fmt.Fprintf(out, "\n   **%s**\n", question.rightAnswer())
+			}
+			fmt.Fprintln(out)
+		}
+	case "html":
+		fmt.Fprintf(out, "<!DOCTYPE html>\n<html>\n<head><meta charset=\"utf-8\"><title>%s</title></head>\n<body>\n", html.EscapeString(title))
+		fmt.Fprintf(out, "<h1>%s</h1>\n<ol>\n", html.EscapeString(title))
+		for _, question := range key.Questions {
+			prompt, figure := splitPromptFigure(question.Prompt)
+			fmt.Fprintf(out, "<li><p>%s</p>\n", html.EscapeString(prompt))
+			if figure != "" {
+				fmt.Fprintf(out, "<pre>%s</pre>\n", html.EscapeString(figure))
+			}
+			if len(question.Choices) > 0 {
+				fmt.Fprintf(out, "<ol type=\"A\">\n")
+				for _, text := range question.Choices {
+					fmt.Fprintf(out, "<li>%s</li>\n", html.EscapeString(text))
+				}
+				fmt.Fprintf(out, "</ol>\n")
+			}
+			if withAnswers {
+				fmt.Fprintf(out, "<p><strong>%s</strong></p>\n", html.EscapeString(question.rightAnswer()))
+			}
+			fmt.Fprintf(out, "</li>\n")
+		}
+		fmt.Fprintf(out, "</ol>\n</body>\n</html>\n")
+	default:
+		return fmt.Errorf("Unknown format %s. Use text, markdown, or html", format)
+	}
+	return nil
+}
+
+func saveWorksheetKey(fileName string, key worksheetKey) error {
+	contents, err := json.MarshalIndent(key, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, contents, 0644)
+}
+
+func loadWorksheetKey(fileName string) (worksheetKey, error) {
+	var key worksheetKey
+	contents, err := os.ReadFile(fileName)
+	if err != nil {
+		return key, err
+	}
+	if err := json.Unmarshal(contents, &key); err != nil {
+		return key, fmt.Errorf("Could not parse answer key %s: %v", fileName, err)
+	}
+	return key, nil
+}
+
+// writeToFile calls write with the named file, or stdout if fileName is empty
+func writeToFile(fileName string, write func(io.Writer) error) error {
+	if fileName == "" {
+		return write(os.Stdout)
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// numberedAnswer is a line like "3. Columbus". The space after the number is required, so an
+// unnumbered decimal like 55.845 isn't read as the answer to question 55.
+var numberedAnswer = regexp.MustCompile(`^\s*(\d+)\s*[.):](?:\s+|$)(.*)$`)
+
+// parseAnswers reads an answers file, returning the answer for each question number. Numbered
+// lines go with their number, and unnumbered ones go with the question after the last answer.
+func parseAnswers(contents string) map[int]string {
+	answers := make(map[int]string)
+	number := 0
+	for _, line := range strings.Split(contents, "\n") {
+		if matches := numberedAnswer.FindStringSubmatch(line); matches != nil {
+			number, _ = strconv.Atoi(matches[1])
+			answers[number] = strings.TrimSpace(matches[2])
+		} else if strings.TrimSpace(line) != "" {
+			number++
+			answers[number] = strings.TrimSpace(line)
+		}
+	}
+	return answers
+}
+
+// gradedAnswer is how one answer on a worksheet was graded
+type gradedAnswer struct {
+	question worksheetQuestion
+	answer   string
+	match    answerMatch
+}
+
+func gradeWorksheet(key worksheetKey, answers map[int]string) []gradedAnswer {
+	graded := make([]gradedAnswer, 0, len(key.Questions))
+	for index, question := range key.Questions {
+		answer := answers[index+1]
+		graded = append(graded, gradedAnswer{question, answer, matchAnswer(answer, question.promptAndResponse())})
+	}
+	return graded
+}
+
+func printGrades(out io.Writer, graded []gradedAnswer) {
+	correct := 0
+	for index, grade := range graded {
+		answer := grade.answer
+		if answer == "" {
+			answer = "(no answer)"
+		}
+		switch grade.match {
+		case answerCorrect:
+			fmt.Fprintf(out, "%d. Correct: %s\n", index+1, answer)
+		case answerTypo:
+			fmt.Fprintf(out, "%d. Close enough: %s for %s\n", index+1, answer, grade.question.rightAnswer())
+		case answerClose:
+			fmt.Fprintf(out, "%d. Close, but %s isn't %s\n", index+1, answer, grade.question.rightAnswer())
+		default:
+			fmt.Fprintf(out, "%d. Incorrect: %s. The right answer was %s\n", index+1, answer, grade.question.rightAnswer())
+		}
+		if grade.match.correct() {
+			correct++
+		}
+	}
+
+	percent := 0.0
+	if len(graded) > 0 {
+		percent = float64(correct) / float64(len(graded)) * 100
+	}
+	fmt.Fprintf(out, "\nScore: %d/%d (%.1f%%)\n", correct, len(graded), percent)
+}
+
+var worksheetCmd = &cobra.Command{
+	Use:   "worksheet [area...]",
+	Short: "Write a worksheet of questions and an answer key",
+	Long: `Write a worksheet of questions from the given areas (or all of them) as text, Markdown,
+or HTML. The answer key is saved as JSON for memoryquiz grade, and can also be written out in the
+worksheet's format with --answer-key. Use --seed to get the same worksheet again.
+
+  memoryquiz worksheet states presidents -q 20 --format markdown -o quiz.md --answer-key answers.md`,
+	Run: func(cmd *cobra.Command, args []string) {
+		exitOnDatasetLoadProblems()
+		count := sessionQuestions
+		if !cmd.Flags().Changed("questions") {
+			count = 20
+		}
+
+		areas, err := worksheetAreas(args)
+		if err == nil {
+			key := generateWorksheet(worksheetTitle, areas, count)
+			err = writeToFile(worksheetOutput, func(out io.Writer) error { return writeWorksheet(out, key, worksheetFormat, false) })
+			if err == nil && worksheetAnswerKey != "" {
+				err = writeToFile(worksheetAnswerKey, func(out io.Writer) error { return writeWorksheet(out, key, worksheetFormat, true) })
+			}
+			if err == nil {
+				err = saveWorksheetKey(worksheetKeyFile, key)
+			}
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var gradeCmd = &cobra.Command{
+	Use:   "grade KEY ANSWERS",
+	Short: "Grade a worksheet's answers",
+	Long: `Grade a file of answers to a worksheet, using the JSON answer key saved by memoryquiz
+worksheet. The answers file has one answer per line, in order or numbered (e.g. "3. Columbus").
+Multiple choice questions can be answered by letter.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		key, err := loadWorksheetKey(args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		contents, err := os.ReadFile(args[1])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		printGrades(os.Stdout, gradeWorksheet(key, parseAnswers(string(contents))))
+	},
+}
+
+func init() {
+	memoryquizCmd.AddCommand(worksheetCmd)
+	memoryquizCmd.AddCommand(gradeCmd)
+	worksheetCmd.Flags().StringVar(&worksheetFormat, "format", "text", "text, markdown, or html")
+	worksheetCmd.Flags().StringVar(&worksheetTitle, "title", "Memory Quiz", "The title at the top of the worksheet")
+	worksheetCmd.Flags().StringVarP(&worksheetOutput, "output", "o", "", "File for the worksheet (default is stdout)")
+	worksheetCmd.Flags().StringVar(&worksheetKeyFile, "key", "worksheet-key.json", "File for the answer key used by memoryquiz grade")
+	worksheetCmd.Flags().StringVar(&worksheetAnswerKey, "answer-key", "", "File for a printable answer key, in the worksheet's format")
+}
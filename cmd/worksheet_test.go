@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bytes"
+	"html"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testWorksheet = worksheetKey{
+	Title: "Quiz Night",
+	Questions: []worksheetQuestion{
+		{Area: "states", QuestionType: "crossQueryStateInfo", Prompt: "What is the capital of Ohio?", Response: "Columbus"},
+		{Area: "presidents", QuestionType: "quizVicePresidents", Prompt: "Who were Lincoln's VPs?", Response: "Hannibal Hamlin, Andrew Johnson", AllOf: []string{"Hannibal Hamlin", "Andrew Johnson"}},
+		{Area: "greek", QuestionType: "quizLetterAfter", Prompt: "What letter comes after alpha?", Response: "beta", Choices: []string{"gamma", "beta", "delta"}},
+		{Area: "presidents", QuestionType: "quizBefore", Prompt: "Who was President before Benjamin Harrison?", Response: "Grover Cleveland (22)", Aliases: []string{"Grover Cleveland"}},
+	},
+}
+
+func TestGenerateWorksheet(t *testing.T) {
+	area, _ := findQuizArea("greek")
+	seedQuizRand(5)
+	key := generateWorksheet("Greek", []*quizArea{area}, 10)
+	if len(key.Questions) != 10 || key.Seed != 5 {
+		t.Fatalf("Expected 10 questions from seed 5 but got %d from %d", len(key.Questions), key.Seed)
+	}
+	prompts := make(map[string]bool)
+	for _, question := range key.Questions {
+		if prompts[question.Prompt] {
+			t.Errorf("%s is on the worksheet twice", question.Prompt)
+		}
+		prompts[question.Prompt] = true
+		if question.Area != "greek" || question.Response == "" {
+			t.Errorf("Unexpected question %+v", question)
+		}
+	}
+
+	seedQuizRand(5)
+	if again := generateWorksheet("Greek", []*quizArea{area}, 10); !reflect.DeepEqual(key, again) {
+		t.Errorf("Expected the same seed to make the same worksheet")
+	}
+}
+
+func TestWorksheetAreas(t *testing.T) {
+	if areas, err := worksheetAreas([]string{"greek", "states"}); err != nil || len(areas) != 2 {
+		t.Errorf("Expected two areas but got %v, %v", areas, err)
+	}
+	if _, err := worksheetAreas([]string{"numbers"}); err == nil {
+		t.Errorf("Expected an error for an interactive area")
+	}
+	if _, err := worksheetAreas([]string{"astrology"}); err == nil {
+		t.Errorf("Expected an error for an unknown area")
+	}
+}
+
+func TestWriteWorksheet(t *testing.T) {
+	var out bytes.Buffer
+	writeWorksheet(&out, testWorksheet, "text", false)
+	if !strings.Contains(out.String(), "3. What letter comes after alpha?\n   A) gamma\n   B) beta\n") || strings.Contains(out.String(), "Columbus") {
+		t.Errorf("Unexpected text worksheet:\n%s", out.String())
+	}
+
+	out.Reset()
+	writeWorksheet(&out, testWorksheet, "markdown", true)
+	if !strings.HasPrefix(out.String(), "# Quiz Night (answers)") || !strings.Contains(out.String(), "**B) beta**") {
+		t.Errorf("Unexpected markdown answer key:\n%s", out.String())
+	}
+
+	out.Reset()
+	key := worksheetKey{Title: "Q&A", Questions: []worksheetQuestion{{Prompt: "Is 1 < 2?", Response: "yes"}}}
+	writeWorksheet(&out, key, "html", true)
+	if !strings.Contains(out.String(), "<h1>Q&amp;A (answers)</h1>") || !strings.Contains(out.String(), "<p>Is 1 &lt; 2?</p>") {
+		t.Errorf("Expected the html to be escaped:\n%s", out.String())
+	}
+
+	if err := writeWorksheet(&out, testWorksheet, "pdf", false); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
+
+// checkWorksheetFigure checks that a prompt with a drawing under it keeps the drawing's lines
+// in a code block in markdown and in a pre in html
+func checkWorksheetFigure(t *testing.T, area string, question promptAndResponse) {
+	key := worksheetKey{Title: "Figures", Questions: []worksheetQuestion{newWorksheetQuestion(area, "quizFigure", question)}}
+	prompt, figure := splitPromptFigure(question.prompt)
+	if figure == "" {
+		t.Fatalf("Expected a drawing under %q", question.prompt)
+	}
+
+	var out bytes.Buffer
+	writeWorksheet(&out, key, "markdown", false)
+	fenced := "1. " + prompt + "\n\n   ```\n   " + strings.ReplaceAll(figure, "\n", "\n   ") + "\n   ```\n"
+	if !strings.Contains(out.String(), fenced) {
+		t.Errorf("Expected the drawing in a code block in the list item:\n%s", out.String())
+	}
+
+	out.Reset()
+	writeWorksheet(&out, key, "html", false)
+	if !strings.Contains(out.String(), "<p>"+html.EscapeString(prompt)+"</p>\n<pre>"+html.EscapeString(figure)+"</pre>") {
+		t.Errorf("Expected the drawing in a pre:\n%s", out.String())
+	}
+}
+
+func TestWriteWorksheetMap(t *testing.T) {
+	seedQuizRand(1)
+	checkWorksheetFigure(t, "states", quizMarkedOnMap("state capital", geoPlaces(states), usMap))
+}
+
+func TestWriteWorksheetPeriodicTable(t *testing.T) {
+	seedQuizRand(1)
+	checkWorksheetFigure(t, "elements", quizBlankInPeriodicTable(elements))
+}
+
+func TestParseAnswers(t *testing.T) {
+	answers := parseAnswers("1. Columbus\n\n3) b\nsomething for 4\n 2: Andrew Johnson, Hannibal Hamlin\n")
+	expected := map[int]string{1: "Columbus", 2: "Andrew Johnson, Hannibal Hamlin", 3: "b", 4: "something for 4"}
+	if !reflect.DeepEqual(answers, expected) {
+		t.Errorf("Expected %v but got %v", expected, answers)
+	}
+
+	answers = parseAnswers("3.14159\n2. 55.845\n7\n")
+	expected = map[int]string{1: "3.14159", 2: "55.845", 3: "7"}
+	if !reflect.DeepEqual(answers, expected) {
+		t.Errorf("Expected decimals to be answers, not question numbers, but got %v", answers)
+	}
+}
+
+func TestGradeWorksheet(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "key.json")
+	if err := saveWorksheetKey(fileName, testWorksheet); err != nil {
+		t.Fatalf("Could not save the key: %v", err)
+	}
+	key, err := loadWorksheetKey(fileName)
+	if err != nil || !reflect.DeepEqual(key, testWorksheet) {
+		t.Fatalf("Expected the key to load as it was saved but got %+v, %v", key, err)
+	}
+
+	graded := gradeWorksheet(key, parseAnswers("columbus\nAndrew Johnson\nB\nGrover Cleveland"))
+	expected := []answerMatch{answerCorrect, answerClose, answerCorrect, answerCorrect}
+	for index, grade := range graded {
+		if grade.match != expected[index] {
+			t.Errorf("Expected answer %d to be %v but got %v", index+1, expected[index], grade.match)
+		}
+	}
+
+	var out bytes.Buffer
+	printGrades(&out, graded)
+	if !strings.Contains(out.String(), "2. Close, but Andrew Johnson isn't Hannibal Hamlin, Andrew Johnson") || !strings.HasSuffix(out.String(), "Score: 3/4 (75.0%)\n") {
+		t.Errorf("Unexpected grades:\n%s", out.String())
+	}
+}